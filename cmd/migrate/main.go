@@ -7,12 +7,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/vention/booking_api/internal/migrations"
 )
 
 func main() {
@@ -192,19 +192,5 @@ func runDown(m *migrate.Migrate, steps int) {
 }
 
 func getLatestMigrationVersion(path string) (uint, error) {
-	files, err := os.ReadDir(path)
-	if err != nil {
-		return 0, err
-	}
-	re := regexp.MustCompile(`^(\d+)_`)
-	var max uint
-	for _, f := range files {
-		if matches := re.FindStringSubmatch(f.Name()); len(matches) > 1 {
-			v, _ := strconv.ParseUint(matches[1], 10, 64)
-			if uint(v) > max {
-				max = uint(v)
-			}
-		}
-	}
-	return max, nil
+	return migrations.LatestVersion(os.DirFS(path))
 }
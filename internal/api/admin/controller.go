@@ -1,11 +1,13 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	common "github.com/vention/booking_api/internal/api/common"
 	"github.com/vention/booking_api/internal/services/admin"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
 )
 
 // CreateProfessional handles POST /api/admin/professionals
@@ -23,12 +25,12 @@ func (h *AdminsHandler) CreateProfessional(c *gin.Context) {
 		Password:    req.Password,
 	})
 	if err != nil {
-		// Check if it's a unique constraint violation
-		if common.IsUniqueConstraintError(err) {
-			common.HandleErrorResponse(c, http.StatusConflict, common.ErrorTypeConflict, common.ErrorMsgUsernameAlreadyExists, nil)
+		// The username is the only unique column set on creation
+		if errors.Is(err, svcCommon.ErrAlreadyExists) {
+			common.HandleErrorResponse(c, http.StatusConflict, common.ErrorTypeConflict, common.ErrorCodeUsernameAlreadyExists, common.ErrorMsgUsernameAlreadyExists, nil)
 			return
 		}
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToCreateProfessional, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeCreateProfessionalFailed, common.ErrorMsgFailedToCreateProfessional)
 		return
 	}
 
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// AdminsOperations describes the routes registered by AdminsRegister for the OpenAPI document
+func AdminsOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:  http.MethodPost,
+			Path:    "/admins/professionals",
+			Summary: "Create a professional",
+			Tags:    []string{"admins"},
+			Request: CreateProfessionalRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: CreateProfessionalResponse{}},
+				{Status: http.StatusConflict, Description: "Username already exists"},
+			},
+		},
+	}
+}
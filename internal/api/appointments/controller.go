@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	common "github.com/vention/booking_api/internal/api/common"
 	"github.com/vention/booking_api/internal/services/appointments"
 )
@@ -15,12 +16,12 @@ func (h *AppointmentsHandler) CreateAppointment(c *gin.Context) {
 		return
 	}
 
-	startTime, ok := common.ParseTime(c, req.StartTime, common.ErrorMsgInvalidTime)
+	startTime, ok := common.ParseTime(c, req.StartTime, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
 	if !ok {
 		return
 	}
 
-	endTime, ok := common.ParseTime(c, req.EndTime, common.ErrorMsgInvalidTime)
+	endTime, ok := common.ParseTime(c, req.EndTime, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
 	if !ok {
 		return
 	}
@@ -35,12 +36,22 @@ func (h *AppointmentsHandler) CreateAppointment(c *gin.Context) {
 		return
 	}
 
+	var holdID *uuid.UUID
+	if req.HoldID != "" {
+		id, ok := common.ParseUUID(c, req.HoldID, common.ErrorCodeInvalidHoldID, common.ErrorMsgInvalidHoldID)
+		if !ok {
+			return
+		}
+		holdID = &id
+	}
+
 	result, err := h.appointmentsService.CreateAppointment(c.Request.Context(), appointments.CreateAppointmentInput{
 		ClientID:       clientID,
 		ProfessionalID: professionalID,
 		StartTime:      startTime,
 		EndTime:        endTime,
 		Description:    "Personal training",
+		HoldID:         holdID,
 	})
 	if err != nil {
 		common.HandleServiceError(c, err)
@@ -48,5 +59,6 @@ func (h *AppointmentsHandler) CreateAppointment(c *gin.Context) {
 	}
 
 	response := mapAppointmentToCreateAppointmentResponse(result)
+	common.SetAppointmentETag(c, result.UpdatedAt)
 	c.JSON(http.StatusCreated, response)
 }
@@ -22,6 +22,7 @@ func NewAppointmentsHandler(service appointments.Service) *AppointmentsHandler {
 type AppointmentsHandlerParams struct {
 	Router              *gin.RouterGroup
 	AppointmentsService appointments.Service
+	Idempotency         gin.HandlerFunc // Makes retries with an Idempotency-Key header safe
 }
 
 func AppointmentsRegister(p AppointmentsHandlerParams) error {
@@ -33,11 +34,15 @@ func AppointmentsRegister(p AppointmentsHandlerParams) error {
 		return errors.New("missing appointments service")
 	}
 
+	if p.Idempotency == nil {
+		return errors.New("missing idempotency middleware")
+	}
+
 	h := NewAppointmentsHandler(p.AppointmentsService)
 
 	appointments := p.Router.Group("/appointments")
 	{
-		appointments.POST("/", h.CreateAppointment)
+		appointments.POST("/", p.Idempotency, h.CreateAppointment)
 	}
 	return nil
 }
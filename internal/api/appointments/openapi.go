@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// AppointmentsOperations describes the routes registered by AppointmentsRegister for the OpenAPI document
+func AppointmentsOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      http.MethodPost,
+			Path:        "/appointments/",
+			Summary:     "Book an appointment",
+			Description: "Pass the hold_id of a slot held with POST /professionals/{id}/holds to book it. The hold is released.",
+			Tags:        []string{"appointments"},
+			Params:      []openapi.Param{common.IdempotencyKeyParam},
+			Request:     CreateAppointmentRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: CreateAppointmentResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+				{Status: http.StatusNotFound, Description: "Professional, client or slot hold not found"},
+				{Status: http.StatusConflict, Description: "Client reached the daily or pending appointment limit of the professional, or the slot is held for another client"},
+			},
+		},
+	}
+}
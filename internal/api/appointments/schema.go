@@ -6,6 +6,7 @@ type CreateAppointmentRequest struct {
 	ProfessionalID string `json:"professional_id" binding:"required"`
 	StartTime      string `json:"start_time" binding:"required"`
 	EndTime        string `json:"end_time" binding:"required"`
+	HoldID         string `json:"hold_id,omitempty" binding:"omitempty,uuid"` // Hold of the slot returned by POST /professionals/{id}/holds
 }
 
 // CreateAppointmentResponse represents the response after creating an appointment
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/i18n"
+	"github.com/vention/booking_api/internal/services/calendar"
+	"github.com/vention/booking_api/internal/util"
+)
+
+const icsExtension = ".ics"
+
+// GetProfessionalFeed handles GET /ical/professionals/{id}.ics
+func (h *CalendarHandler) GetProfessionalFeed(c *gin.Context) {
+	professionalID, ok := parseFeedFile(c, common.ErrorCodeInvalidProfessionalID, common.ErrorMsgInvalidProfessionalID)
+	if !ok {
+		return
+	}
+
+	token, ok := common.RequireQueryParam(c, "token")
+	if !ok {
+		return
+	}
+
+	appointments, err := h.calendarService.GetProfessionalFeed(c.Request.Context(), professionalID, token)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	locale := common.GetLocale(c)
+	name := i18n.Translate(locale, "calendar.professional_feed", "")
+	writeCalendar(c, professionalID, renderCalendar(name, mapProfessionalAppointmentsToICSEvents(appointments, locale), util.GetAppTimezone()))
+}
+
+// GetClientFeed handles GET /ical/clients/{id}.ics
+func (h *CalendarHandler) GetClientFeed(c *gin.Context) {
+	clientID, ok := parseFeedFile(c, common.ErrorCodeInvalidClientID, common.ErrorMsgInvalidClientID)
+	if !ok {
+		return
+	}
+
+	token, ok := common.RequireQueryParam(c, "token")
+	if !ok {
+		return
+	}
+
+	appointments, err := h.calendarService.GetClientFeed(c.Request.Context(), clientID, token)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	locale := common.GetLocale(c)
+	name := i18n.Translate(locale, "calendar.client_feed", "")
+	writeCalendar(c, clientID, renderCalendar(name, mapClientAppointmentsToICSEvents(appointments, locale), util.GetAppTimezone()))
+}
+
+// RegenerateProfessionalToken handles POST /api/professionals/{id}/calendar_token
+func (h *CalendarHandler) RegenerateProfessionalToken(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	feed, err := h.calendarService.RegenerateProfessionalToken(c.Request.Context(), professionalID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRegenerateTokenFailed, common.ErrorMsgFailedToRegenerateToken)
+		return
+	}
+
+	response := mapCalendarFeedToRegenerateCalendarTokenResponse(feed, h.feedURL("professionals", professionalID, feed.Token))
+	c.JSON(http.StatusOK, response)
+}
+
+// RegenerateClientToken handles POST /api/clients/{id}/calendar_token
+func (h *CalendarHandler) RegenerateClientToken(c *gin.Context) {
+	clientID, ok := common.ParseClientID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	feed, err := h.calendarService.RegenerateClientToken(c.Request.Context(), clientID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRegenerateTokenFailed, common.ErrorMsgFailedToRegenerateToken)
+		return
+	}
+
+	response := mapCalendarFeedToRegenerateCalendarTokenResponse(feed, h.feedURL("clients", clientID, feed.Token))
+	c.JSON(http.StatusOK, response)
+}
+
+// GetExternalCalendars handles GET /api/professionals/{id}/external_calendars
+func (h *CalendarHandler) GetExternalCalendars(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	calendars, err := h.calendarService.ListExternalCalendars(c.Request.Context(), professionalID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveCalendarsFailed, common.ErrorMsgFailedToRetrieveCalendars)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapExternalCalendarsToGetExternalCalendarsResponse(calendars))
+}
+
+// RegisterExternalCalendar handles POST /api/professionals/{id}/external_calendars
+func (h *CalendarHandler) RegisterExternalCalendar(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[RegisterExternalCalendarRequest](c)
+	if !ok {
+		return
+	}
+
+	externalCalendar, err := h.calendarService.RegisterExternalCalendar(c.Request.Context(), calendar.RegisterExternalCalendarInput{
+		ProfessionalID: professionalID,
+		Name:           req.Name,
+		URL:            req.URL,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ExternalCalendarResponse{ExternalCalendar: mapExternalCalendar(externalCalendar)})
+}
+
+// UploadExternalCalendar handles POST /api/professionals/{id}/external_calendars/upload (multipart "file" and optional "name")
+func (h *CalendarHandler) UploadExternalCalendar(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeMissingRequiredField, common.ErrorMsgMissingRequiredField, err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidCalendarData, common.ErrorMsgInvalidCalendarFile, err)
+		return
+	}
+	defer file.Close()
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = fileHeader.Filename
+	}
+
+	externalCalendar, err := h.calendarService.UploadExternalCalendar(c.Request.Context(), calendar.UploadExternalCalendarInput{
+		ProfessionalID: professionalID,
+		Name:           name,
+		Content:        file,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ExternalCalendarResponse{ExternalCalendar: mapExternalCalendar(externalCalendar)})
+}
+
+// SyncExternalCalendar handles POST /api/professionals/{id}/external_calendars/{calendar_id}/sync
+func (h *CalendarHandler) SyncExternalCalendar(c *gin.Context) {
+	professionalID, calendarID, ok := parseExternalCalendarParams(c)
+	if !ok {
+		return
+	}
+
+	externalCalendar, err := h.calendarService.SyncExternalCalendar(c.Request.Context(), professionalID, calendarID)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ExternalCalendarResponse{ExternalCalendar: mapExternalCalendar(externalCalendar)})
+}
+
+// DeleteExternalCalendar handles DELETE /api/professionals/{id}/external_calendars/{calendar_id}
+func (h *CalendarHandler) DeleteExternalCalendar(c *gin.Context) {
+	professionalID, calendarID, ok := parseExternalCalendarParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarService.DeleteExternalCalendar(c.Request.Context(), professionalID, calendarID); err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseExternalCalendarParams parses the professional and external calendar IDs from the path
+func parseExternalCalendarParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	calendarID, ok := common.ParseUUID(c, c.Param("calendar_id"), common.ErrorCodeInvalidCalendarID, common.ErrorMsgInvalidCalendarID)
+	if !ok {
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	return professionalID, calendarID, true
+}
+
+// feedURL builds the subscription URL of a feed, relative when no public base URL is configured
+func (h *CalendarHandler) feedURL(owner string, ownerID uuid.UUID, token string) string {
+	return fmt.Sprintf("%s/ical/%s/%s%s?token=%s", strings.TrimSuffix(h.publicBaseURL, "/"), owner, ownerID, icsExtension, token)
+}
+
+// parseFeedFile parses the owner ID from a "{id}.ics" path segment
+func parseFeedFile(c *gin.Context, code, errorMsg string) (uuid.UUID, bool) {
+	file := c.Param("file")
+	if !strings.HasSuffix(file, icsExtension) {
+		common.HandleErrorResponse(c, http.StatusNotFound, common.ErrorTypeNotFound, common.ErrorCodeFeedNotFound, common.ErrorMsgCalendarNotFound, nil)
+		return uuid.UUID{}, false
+	}
+	return common.ParseUUID(c, strings.TrimSuffix(file, icsExtension), code, errorMsg)
+}
+
+// writeCalendar writes the rendered feed with calendar headers
+func writeCalendar(c *gin.Context, ownerID uuid.UUID, body string) {
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", ownerID.String()+icsExtension))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(body))
+}
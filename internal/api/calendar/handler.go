@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/calendar"
+)
+
+// CalendarHandler handles HTTP requests for iCalendar feeds and external calendars
+type CalendarHandler struct {
+	calendarService calendar.Service
+	publicBaseURL   string
+}
+
+// NewCalendarHandler creates a new handler with dependency injection
+func NewCalendarHandler(service calendar.Service, publicBaseURL string) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: service,
+		publicBaseURL:   publicBaseURL,
+	}
+}
+
+// CalendarHandlerParams defines the parameters for the CalendarHandler
+type CalendarHandlerParams struct {
+	Router          *gin.RouterGroup // JWT protected routes
+	FeedRouter      *gin.RouterGroup // Token protected feed routes, reachable by calendar apps
+	CalendarService calendar.Service
+	PublicBaseURL   string
+}
+
+// CalendarRegister registers the CalendarHandler with the routers
+func CalendarRegister(p CalendarHandlerParams) error {
+	if p.Router == nil || p.FeedRouter == nil {
+		return errors.New("missing router")
+	}
+
+	if p.CalendarService == nil {
+		return errors.New("missing calendar service")
+	}
+
+	h := NewCalendarHandler(p.CalendarService, p.PublicBaseURL)
+
+	p.Router.POST("/professionals/:id/calendar_token", h.RegenerateProfessionalToken)
+	p.Router.POST("/clients/:id/calendar_token", h.RegenerateClientToken)
+
+	external := p.Router.Group("/professionals/:id/external_calendars")
+	{
+		external.GET("", h.GetExternalCalendars)
+		external.POST("", h.RegisterExternalCalendar)
+		external.POST("/upload", h.UploadExternalCalendar)
+		external.POST("/:calendar_id/sync", h.SyncExternalCalendar)
+		external.DELETE("/:calendar_id", h.DeleteExternalCalendar)
+	}
+
+	feeds := p.FeedRouter.Group("/ical")
+	{
+		feeds.GET("/professionals/:file", h.GetProfessionalFeed)
+		feeds.GET("/clients/:file", h.GetClientFeed)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icsProductID      = "-//Booking API//Appointments//EN"
+	icsUIDDomain      = "booking-api"
+	icsDateTimeFormat = "20060102T150405"
+	icsMaxLineOctets  = 75
+	icsBerlinTZID     = "Europe/Berlin"
+)
+
+// icsBerlinTimezone is the VTIMEZONE definition of Europe/Berlin (EU daylight saving rules)
+const icsBerlinTimezone = `BEGIN:VTIMEZONE
+TZID:Europe/Berlin
+X-LIC-LOCATION:Europe/Berlin
+BEGIN:DAYLIGHT
+TZOFFSETFROM:+0100
+TZOFFSETTO:+0200
+TZNAME:CEST
+DTSTART:19700329T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+TZNAME:CET
+DTSTART:19701025T030000
+RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// icsEvent represents a single VEVENT in a feed
+type icsEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	Status      string // CONFIRMED or CANCELLED
+	Sequence    int32
+}
+
+// icsWriter builds an RFC 5545 document with CRLF line endings and folded lines
+type icsWriter struct {
+	b   strings.Builder
+	loc *time.Location
+}
+
+// renderCalendar renders the events as an iCalendar document in the given timezone
+func renderCalendar(name string, events []icsEvent, loc *time.Location) string {
+	w := &icsWriter{loc: loc}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icsProductID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + escapeText(name))
+	if w.useBerlinTZID() {
+		w.line("X-WR-TIMEZONE:" + icsBerlinTZID)
+		for _, l := range strings.Split(icsBerlinTimezone, "\n") {
+			w.line(l)
+		}
+	}
+
+	for _, event := range events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + event.UID)
+		w.line("DTSTAMP:" + event.Stamp.UTC().Format(icsDateTimeFormat) + "Z")
+		w.dateTime("DTSTART", event.Start)
+		w.dateTime("DTEND", event.End)
+		w.line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		w.line("STATUS:" + event.Status)
+		w.line("SEQUENCE:" + strconv.Itoa(int(event.Sequence)))
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return w.b.String()
+}
+
+// useBerlinTZID reports whether local times can reference the embedded VTIMEZONE
+func (w *icsWriter) useBerlinTZID() bool {
+	return w.loc != nil && w.loc.String() == icsBerlinTZID
+}
+
+// dateTime writes a date-time property, either with TZID or in UTC
+func (w *icsWriter) dateTime(name string, t time.Time) {
+	if w.useBerlinTZID() {
+		w.line(name + ";TZID=" + icsBerlinTZID + ":" + t.In(w.loc).Format(icsDateTimeFormat))
+		return
+	}
+	w.line(name + ":" + t.UTC().Format(icsDateTimeFormat) + "Z")
+}
+
+// line writes a content line folded at 75 octets without splitting UTF-8 sequences
+func (w *icsWriter) line(content string) {
+	limit := icsMaxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.b.WriteString(content[:cut])
+		w.b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icsMaxLineOctets - 1
+	}
+	w.b.WriteString(content)
+	w.b.WriteString("\r\n")
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// isRuneStart reports whether the byte starts a UTF-8 sequence
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/i18n"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// mapProfessionalAppointmentsToICSEvents maps a professional's appointments and unavailable blocks to VEVENTs
+func mapProfessionalAppointmentsToICSEvents(appointments []*db.GetProfessionalCalendarAppointmentsRow, locale i18n.Locale) []icsEvent {
+	events := make([]icsEvent, len(appointments))
+	for i, appt := range appointments {
+		summary := appt.Description.String
+		if appt.Type == db.AppointmentTypeAppointment && appt.ClientFirstName.Valid {
+			summary = fmt.Sprintf("%s %s", appt.ClientFirstName.String, appt.ClientLastName.String)
+		} else if summary == "" {
+			summary = i18n.Translate(locale, "calendar.unavailable", "")
+		}
+
+		var details []string
+		if appt.Type == db.AppointmentTypeAppointment && appt.Description.Valid {
+			details = append(details, appt.Description.String)
+		}
+		if appt.ClientPhoneNumber.Valid {
+			details = append(details, i18n.Translate(locale, "calendar.phone", "", "phone", appt.ClientPhoneNumber.String))
+		}
+		if appt.CancellationReason.Valid {
+			details = append(details, i18n.Translate(locale, "calendar.cancellation_reason", "", "reason", appt.CancellationReason.String))
+		}
+
+		events[i] = icsEvent{
+			UID:         appointmentUID(appt.ID.String()),
+			Summary:     summary,
+			Description: strings.Join(details, "\n"),
+			Start:       appt.StartTime,
+			End:         appt.EndTime,
+			Stamp:       appt.UpdatedAt,
+			Status:      mapStatusToICSStatus(appt.Status),
+			Sequence:    appt.Sequence,
+		}
+	}
+	return events
+}
+
+// mapClientAppointmentsToICSEvents maps a client's appointments to VEVENTs
+func mapClientAppointmentsToICSEvents(appointments []*db.GetClientCalendarAppointmentsRow, locale i18n.Locale) []icsEvent {
+	events := make([]icsEvent, len(appointments))
+	for i, appt := range appointments {
+		var details []string
+		if appt.Description.Valid {
+			details = append(details, appt.Description.String)
+		}
+		if appt.ProfessionalPhoneNumber.Valid {
+			details = append(details, i18n.Translate(locale, "calendar.phone", "", "phone", appt.ProfessionalPhoneNumber.String))
+		}
+		if appt.CancellationReason.Valid {
+			details = append(details, i18n.Translate(locale, "calendar.cancellation_reason", "", "reason", appt.CancellationReason.String))
+		}
+
+		professional := fmt.Sprintf("%s %s", appt.ProfessionalFirstName.String, appt.ProfessionalLastName.String)
+		events[i] = icsEvent{
+			UID:         appointmentUID(appt.ID.String()),
+			Summary:     i18n.Translate(locale, "calendar.appointment_with", "", "name", professional),
+			Description: strings.Join(details, "\n"),
+			Start:       appt.StartTime,
+			End:         appt.EndTime,
+			Stamp:       appt.UpdatedAt,
+			Status:      mapStatusToICSStatus(appt.Status),
+			Sequence:    appt.Sequence,
+		}
+	}
+	return events
+}
+
+// mapCalendarFeedToRegenerateCalendarTokenResponse maps a calendar feed to a RegenerateCalendarTokenResponse
+func mapCalendarFeedToRegenerateCalendarTokenResponse(feed *db.CalendarFeed, feedURL string) RegenerateCalendarTokenResponse {
+	return RegenerateCalendarTokenResponse{
+		Token:     feed.Token,
+		FeedURL:   feedURL,
+		UpdatedAt: common.FormatTimeRFC3339(feed.UpdatedAt),
+	}
+}
+
+// mapExternalCalendar maps an external calendar to its API representation
+func mapExternalCalendar(calendar *db.ExternalCalendar) ExternalCalendar {
+	return ExternalCalendar{
+		ID:             calendar.ID.String(),
+		ProfessionalID: calendar.ProfessionalID.String(),
+		Name:           calendar.Name,
+		URL:            common.FromNullString(calendar.Url),
+		LastSyncedAt:   common.FromNullTimeRFC3339(calendar.LastSyncedAt),
+		LastSyncError:  common.FromNullString(calendar.LastSyncError),
+		CreatedAt:      common.FormatTimeRFC3339(calendar.CreatedAt),
+	}
+}
+
+// mapExternalCalendarsToGetExternalCalendarsResponse maps external calendars to a GetExternalCalendarsResponse
+func mapExternalCalendarsToGetExternalCalendarsResponse(calendars []*db.ExternalCalendar) GetExternalCalendarsResponse {
+	response := GetExternalCalendarsResponse{
+		ExternalCalendars: make([]ExternalCalendar, len(calendars)),
+	}
+	for i, calendar := range calendars {
+		response.ExternalCalendars[i] = mapExternalCalendar(calendar)
+	}
+	return response
+}
+
+// mapStatusToICSStatus maps an appointment status to a VEVENT STATUS value
+func mapStatusToICSStatus(status db.NullAppointmentStatus) string {
+	if status.AppointmentStatus == db.AppointmentStatusCancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+// appointmentUID returns the stable VEVENT UID of an appointment
+func appointmentUID(appointmentID string) string {
+	return appointmentID + "@" + icsUIDDomain
+}
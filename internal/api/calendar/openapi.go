@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// CalendarOperations describes the routes registered by CalendarRegister on the JWT protected router
+// for the OpenAPI document
+func CalendarOperations() []openapi.Operation {
+	tags := []string{"calendar"}
+	syncResponses := []openapi.Response{
+		{Status: http.StatusOK, Body: ExternalCalendarResponse{}},
+		{Status: http.StatusNotFound},
+	}
+
+	return []openapi.Operation{
+		{
+			Method:      http.MethodPost,
+			Path:        "/professionals/:id/calendar_token",
+			Summary:     "Regenerate the calendar feed token of a professional",
+			Description: "The previous feed URL stops working.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: RegenerateCalendarTokenResponse{}},
+				{Status: http.StatusNotFound, Description: "Professional not found"},
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/clients/:id/calendar_token",
+			Summary:     "Regenerate the calendar feed token of a client",
+			Description: "The previous feed URL stops working.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: RegenerateCalendarTokenResponse{}},
+				{Status: http.StatusNotFound, Description: "Client not found"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals/:id/external_calendars",
+			Summary: "List the external calendars of a professional",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetExternalCalendarsResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/professionals/:id/external_calendars",
+			Summary: "Subscribe to an external iCalendar URL",
+			Tags:    tags,
+			Request: RegisterExternalCalendarRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: ExternalCalendarResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/professionals/:id/external_calendars/upload",
+			Summary: "Upload an iCalendar file",
+			Tags:    tags,
+			Form: []openapi.Param{
+				{Name: "file", Type: openapi.TypeFile, Required: true},
+				{Name: "name", Description: "Defaults to the file name"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: ExternalCalendarResponse{}},
+			},
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/professionals/:id/external_calendars/:calendar_id/sync",
+			Summary:   "Re-import a subscribed external calendar",
+			Tags:      tags,
+			Responses: syncResponses,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/professionals/:id/external_calendars/:calendar_id",
+			Summary: "Remove an external calendar",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusNoContent},
+				{Status: http.StatusNotFound},
+			},
+		},
+	}
+}
+
+// CalendarFeedOperations describes the feed routes registered by CalendarRegister on the feed router
+// for the OpenAPI document
+func CalendarFeedOperations() []openapi.Operation {
+	tags := []string{"calendar"}
+	params := []openapi.Param{
+		{Name: "file", In: openapi.InPath, Description: "Owner ID followed by .ics"},
+		{Name: "token", In: openapi.InQuery, Required: true, Description: "Feed token returned when regenerating the calendar token"},
+	}
+	responses := []openapi.Response{
+		{Status: http.StatusOK, ContentTypes: []string{"text/calendar"}},
+		{Status: http.StatusNotFound},
+	}
+
+	return []openapi.Operation{
+		{
+			Method:    http.MethodGet,
+			Path:      "/ical/professionals/:file",
+			Summary:   "iCalendar feed of a professional",
+			Tags:      tags,
+			Params:    params,
+			Responses: responses,
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/ical/clients/:file",
+			Summary:   "iCalendar feed of a client",
+			Tags:      tags,
+			Params:    params,
+			Responses: responses,
+		},
+	}
+}
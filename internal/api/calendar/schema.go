@@ -0,0 +1,35 @@
+package api
+
+// RegenerateCalendarTokenResponse represents the response after regenerating a calendar feed token
+type RegenerateCalendarTokenResponse struct {
+	Token     string `json:"token"`
+	FeedURL   string `json:"feed_url"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// RegisterExternalCalendarRequest represents the request for subscribing to an external calendar URL
+type RegisterExternalCalendarRequest struct {
+	Name string `json:"name" binding:"required"`
+	URL  string `json:"url" binding:"required"` // http(s):// or webcal:// iCalendar URL
+}
+
+// ExternalCalendarResponse represents an external calendar whose events block availability
+type ExternalCalendarResponse struct {
+	ExternalCalendar ExternalCalendar `json:"external_calendar"`
+}
+
+// GetExternalCalendarsResponse represents the response for listing external calendars
+type GetExternalCalendarsResponse struct {
+	ExternalCalendars []ExternalCalendar `json:"external_calendars"`
+}
+
+// ExternalCalendar represents an imported external calendar
+type ExternalCalendar struct {
+	ID             string  `json:"id"`
+	ProfessionalID string  `json:"professional_id"`
+	Name           string  `json:"name"`
+	URL            *string `json:"url"` // NULL for uploaded files
+	LastSyncedAt   *string `json:"last_synced_at"`
+	LastSyncError  *string `json:"last_sync_error"`
+	CreatedAt      string  `json:"created_at"`
+}
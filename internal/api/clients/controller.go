@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/events"
+	db "github.com/vention/booking_api/internal/repository"
 	"github.com/vention/booking_api/internal/services/clients"
 )
 
@@ -20,18 +23,20 @@ func (h *ClientsHandler) RegisterClient(c *gin.Context) {
 		phoneNumber = *req.PhoneNumber
 	}
 
+	language := ""
+	if req.Language != nil {
+		language = *req.Language
+	}
+
 	client, err := h.clientsService.RegisterClient(c.Request.Context(), clients.RegisterClientInput{
 		FirstName:   req.FirstName,
 		LastName:    req.LastName,
 		PhoneNumber: phoneNumber,
 		ChatID:      req.ChatID,
+		Language:    language,
 	})
 	if err != nil {
-		if common.IsUniqueConstraintError(err) {
-			common.HandleErrorResponse(c, http.StatusConflict, common.ErrorTypeConflict, common.ErrorMsgUsernameAlreadyExists, nil)
-			return
-		}
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToCreateClient, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeCreateClientFailed, common.ErrorMsgFailedToCreateClient)
 		return
 	}
 
@@ -53,7 +58,7 @@ func (h *ClientsHandler) GetClientAppointments(c *gin.Context) {
 
 	appointments, err := h.clientsService.GetClientAppointments(c.Request.Context(), clientID, statusFilter)
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToRetrieveAppointments, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
 		return
 	}
 
@@ -73,6 +78,11 @@ func (h *ClientsHandler) CancelClientAppointment(c *gin.Context) {
 		return
 	}
 
+	expectedUpdatedAt, ok := common.ParseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	req, ok := common.BindAndValidate[CancelClientAppointmentRequest](c)
 	if !ok {
 		return
@@ -82,6 +92,7 @@ func (h *ClientsHandler) CancelClientAppointment(c *gin.Context) {
 		ClientID:           clientID,
 		AppointmentID:      appointmentID,
 		CancellationReason: req.CancellationReason,
+		ExpectedUpdatedAt:  expectedUpdatedAt,
 	})
 	if err != nil {
 		common.HandleServiceError(c, err)
@@ -89,5 +100,28 @@ func (h *ClientsHandler) CancelClientAppointment(c *gin.Context) {
 	}
 
 	response := mapAppointmentToCancelClientAppointmentResponse(result)
+	common.SetAppointmentETag(c, result.UpdatedAt)
 	c.JSON(http.StatusOK, response)
 }
+
+// StreamClientEvents handles GET /api/clients/{id}/events
+func (h *ClientsHandler) StreamClientEvents(c *gin.Context) {
+	clientID, ok := common.ParseClientID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	lastEventID, ok := common.ParseLastEventID(c)
+	if !ok {
+		return
+	}
+
+	// Subscribe before reading the backlog so no event falls in between
+	sub := h.eventsBroker.Subscribe(events.ForClient(clientID))
+	defer sub.Close()
+
+	loadBacklog := func(ctx context.Context, lastEventID int64) ([]*db.AppointmentEvent, error) {
+		return h.clientsService.GetEventsAfter(ctx, clientID, lastEventID)
+	}
+	common.StreamEvents(c, lastEventID, loadBacklog, sub, h.heartbeatInterval)
+}
@@ -2,27 +2,39 @@ package api
 
 import (
 	"errors"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/events"
 	"github.com/vention/booking_api/internal/services/clients"
 )
 
 // ClientsHandler handles HTTP requests for clients
 type ClientsHandler struct {
-	clientsService clients.Service
+	clientsService    clients.Service
+	eventsBroker      *events.Broker
+	heartbeatInterval time.Duration
+	requireIfMatch    bool
 }
 
 // NewClientsHandler creates a new handler with dependency injection
-func NewClientsHandler(service clients.Service) *ClientsHandler {
+func NewClientsHandler(service clients.Service, eventsBroker *events.Broker, heartbeatInterval time.Duration, requireIfMatch bool) *ClientsHandler {
 	return &ClientsHandler{
-		clientsService: service,
+		clientsService:    service,
+		eventsBroker:      eventsBroker,
+		heartbeatInterval: heartbeatInterval,
+		requireIfMatch:    requireIfMatch,
 	}
 }
 
 // ClientsHandlerParams defines the parameters for the ClientsHandler
 type ClientsHandlerParams struct {
-	Router         *gin.RouterGroup
-	ClientsService clients.Service
+	Router            *gin.RouterGroup
+	ClientsService    clients.Service
+	EventsBroker      *events.Broker
+	HeartbeatInterval time.Duration
+	Idempotency       gin.HandlerFunc // Makes retries with an Idempotency-Key header safe
+	RequireIfMatch    bool            // Reject cancellations without If-Match
 }
 
 // ClientsRegister registers the ClientsHandler with the router
@@ -35,13 +47,26 @@ func ClientsRegister(p ClientsHandlerParams) error {
 		return errors.New("missing clients service")
 	}
 
-	h := NewClientsHandler(p.ClientsService)
+	if p.EventsBroker == nil {
+		return errors.New("missing events broker")
+	}
+
+	if p.HeartbeatInterval <= 0 {
+		return errors.New("invalid heartbeat interval")
+	}
+
+	if p.Idempotency == nil {
+		return errors.New("missing idempotency middleware")
+	}
+
+	h := NewClientsHandler(p.ClientsService, p.EventsBroker, p.HeartbeatInterval, p.RequireIfMatch)
 
 	clients := p.Router.Group("/clients")
 	{
-		clients.POST("/register", h.RegisterClient)
+		clients.POST("/register", p.Idempotency, h.RegisterClient)
 		clients.GET("/:id/appointments", h.GetClientAppointments)
-		clients.PATCH("/:id/appointments/:appointment_id/cancel", h.CancelClientAppointment)
+		clients.PATCH("/:id/appointments/:appointment_id/cancel", p.Idempotency, h.CancelClientAppointment)
+		clients.GET("/:id/events", h.StreamClientEvents)
 	}
 
 	return nil
@@ -14,6 +14,7 @@ func mapClientToClientRegisterResponse(client *db.Client) ClientRegisterResponse
 		Role:        common.RoleClient,
 		PhoneNumber: common.FromNullString(client.PhoneNumber),
 		ChatID:      common.FromNullInt64(client.ChatID),
+		Language:    common.FromNullString(client.Language),
 		CreatedAt:   common.FormatTimeWithTimezone(client.CreatedAt),
 		UpdatedAt:   common.FormatTimeWithTimezone(client.UpdatedAt),
 	}
@@ -24,14 +25,16 @@ func mapAppointmentToGetClientAppointmentsResponse(appointments []*db.GetAppoint
 	var responseAppointments []ClientAppointment
 	for _, appt := range appointments {
 		appointment := ClientAppointment{
-			ID:          appt.ID.String(),
-			Type:        string(appt.Type),
-			StartTime:   common.FormatTimeRFC3339(appt.StartTime),
-			EndTime:     common.FormatTimeRFC3339(appt.EndTime),
-			Description: appt.Description.String,
-			Status:      string(appt.Status.AppointmentStatus),
-			CreatedAt:   common.FormatTimeRFC3339(appt.CreatedAt),
-			UpdatedAt:   common.FormatTimeRFC3339(appt.UpdatedAt),
+			ID:               appt.ID.String(),
+			Type:             string(appt.Type),
+			StartTime:        common.FormatTimeRFC3339(appt.StartTime),
+			EndTime:          common.FormatTimeRFC3339(appt.EndTime),
+			Description:      appt.Description.String,
+			Status:           string(appt.Status.AppointmentStatus),
+			CreatedAt:        common.FormatTimeRFC3339(appt.CreatedAt),
+			UpdatedAt:        common.FormatTimeRFC3339(appt.UpdatedAt),
+			ETag:             common.AppointmentETag(appt.UpdatedAt),
+			LateCancellation: appt.LateCancellation,
 		}
 		professional := &ClientAppointmentProfessional{
 			ID:        appt.ProfessionalIDFull.String(),
@@ -62,6 +65,7 @@ func mapAppointmentToCancelClientAppointmentResponse(appointment *db.CancelAppoi
 			Status:             string(appointment.Status.AppointmentStatus),
 			CancellationReason: appointment.CancellationReason.String,
 			CancelledBy:        common.CancelledByClient,
+			LateCancellation:   appointment.LateCancellation,
 			CreatedAt:          common.FormatTimeRFC3339(appointment.CreatedAt),
 			UpdatedAt:          common.FormatTimeRFC3339(appointment.UpdatedAt),
 		},
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// ClientsOperations describes the routes registered by ClientsRegister for the OpenAPI document
+func ClientsOperations() []openapi.Operation {
+	tags := []string{"clients"}
+
+	return []openapi.Operation{
+		{
+			Method:  http.MethodPost,
+			Path:    "/clients/register",
+			Summary: "Register a client",
+			Tags:    tags,
+			Params:  []openapi.Param{common.IdempotencyKeyParam},
+			Request: ClientRegisterRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: ClientRegisterResponse{}},
+				{Status: http.StatusConflict, Description: "Client already registered"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/clients/:id/appointments",
+			Summary: "List the appointments of a client",
+			Tags:    tags,
+			Params:  []openapi.Param{common.AppointmentStatusParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetClientAppointmentsResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/clients/:id/appointments/:appointment_id/cancel",
+			Summary: "Cancel an appointment as the client",
+			Tags:    tags,
+			Params:  []openapi.Param{common.IdempotencyKeyParam, common.IfMatchParam},
+			Request: CancelClientAppointmentRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: CancelClientAppointmentResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+				{Status: http.StatusForbidden},
+				{Status: http.StatusBadRequest, Description: "The appointment is not cancellable, or the notice is shorter than the professional's policy allows"},
+				{Status: http.StatusNotFound, Description: "Appointment not found"},
+				{Status: http.StatusPreconditionFailed, Description: "The appointment was modified since the If-Match version"},
+				{Status: http.StatusPreconditionRequired, Description: "If-Match is required"},
+			},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/clients/:id/events",
+			Summary:   "Stream appointment events of a client",
+			Tags:      tags,
+			Params:    common.EventStreamParams,
+			Responses: []openapi.Response{common.EventStreamResponse},
+		},
+	}
+}
@@ -6,6 +6,7 @@ type ClientRegisterRequest struct {
 	LastName    string  `json:"last_name" binding:"required"`
 	ChatID      int64   `json:"chat_id" binding:"required"`
 	PhoneNumber *string `json:"phone_number,omitempty"`
+	Language    *string `json:"language,omitempty" binding:"omitempty,oneof=en ru uk de"` // Preferred language of messages
 }
 
 // ClientRegisterResponse represents the response for client registration
@@ -15,6 +16,7 @@ type ClientRegisterResponse struct {
 	FirstName   string  `json:"first_name"`
 	LastName    string  `json:"last_name"`
 	PhoneNumber *string `json:"phone_number,omitempty"`
+	Language    *string `json:"language,omitempty"`
 	CreatedAt   string  `json:"created_at"`
 	UpdatedAt   string  `json:"updated_at"`
 	Role        string  `json:"role"`
@@ -27,15 +29,17 @@ type GetClientAppointmentsResponse struct {
 
 // ClientAppointment represents an appointment with professional details in client context
 type ClientAppointment struct {
-	ID           string                         `json:"id"`
-	Type         string                         `json:"type"`
-	StartTime    string                         `json:"start_time"`
-	EndTime      string                         `json:"end_time"`
-	Status       string                         `json:"status"`
-	Description  string                         `json:"description,omitempty"`
-	CreatedAt    string                         `json:"created_at"`
-	UpdatedAt    string                         `json:"updated_at"`
-	Professional *ClientAppointmentProfessional `json:"professional,omitempty"`
+	ID               string                         `json:"id"`
+	Type             string                         `json:"type"`
+	StartTime        string                         `json:"start_time"`
+	EndTime          string                         `json:"end_time"`
+	Status           string                         `json:"status"`
+	Description      string                         `json:"description,omitempty"`
+	CreatedAt        string                         `json:"created_at"`
+	UpdatedAt        string                         `json:"updated_at"`
+	ETag             string                         `json:"etag"`                        // Send as If-Match when cancelling
+	LateCancellation bool                           `json:"late_cancellation,omitempty"` // Cancelled by the client with less notice than the policy requires
+	Professional     *ClientAppointmentProfessional `json:"professional,omitempty"`
 }
 
 // ClientAppointmentProfessional represents professional details in appointment context
@@ -79,6 +83,7 @@ type CancelledAppointment struct {
 	Description        string `json:"description,omitempty"`
 	CancellationReason string `json:"cancellation_reason"`
 	CancelledBy        string `json:"cancelled_by"`
+	LateCancellation   bool   `json:"late_cancellation"` // Less notice than the cancellation policy requires
 	CreatedAt          string `json:"created_at"`
 	UpdatedAt          string `json:"updated_at"`
 }
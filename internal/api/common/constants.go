@@ -2,13 +2,15 @@ package common
 
 // Error types
 const (
-	ErrorTypeValidation = "validation_error"
-	ErrorTypeDatabase   = "database_error"
-	ErrorTypeNotFound   = "not_found"
-	ErrorTypeForbidden  = "forbidden"
-	ErrorTypeConflict   = "conflict"
-	ErrorTypeInternal   = "internal_error"
-	ErrorTypeAuth       = "authentication_error"
+	ErrorTypeValidation   = "validation_error"
+	ErrorTypeDatabase     = "database_error"
+	ErrorTypeNotFound     = "not_found"
+	ErrorTypeForbidden    = "forbidden"
+	ErrorTypeConflict     = "conflict"
+	ErrorTypeInternal     = "internal_error"
+	ErrorTypeAuth         = "authentication_error"
+	ErrorTypePrecondition = "precondition_failed"
+	ErrorTypeRateLimited  = "rate_limited"
 )
 
 // Error messages
@@ -19,13 +21,39 @@ const (
 	ErrorMsgInvalidProfessionalID            = "Invalid professional_id format"
 	ErrorMsgInvalidClientID                  = "Invalid client_id format"
 	ErrorMsgInvalidDate                      = "Invalid date format. Use YYYY-MM-DD format (e.g., 2024-01-15)"
+	ErrorMsgInvalidMonth                     = "Invalid month format. Use YYYY-MM"
 	ErrorMsgInvalidStatus                    = "Invalid status. Must be one of: pending, confirmed, cancelled, completed"
 	ErrorMsgInvalidTime                      = "Invalid time format"
 	ErrorMsgInvalidCredentials               = "Invalid username or password"
+	ErrorMsgInvalidLastEventID               = "Invalid Last-Event-ID format"
+	ErrorMsgInvalidCalendarID                = "Invalid calendar_id format"
+	ErrorMsgInvalidWaitlistEntryID           = "Invalid entry_id format"
+	ErrorMsgInvalidHoldID                    = "Invalid hold_id format"
+	ErrorMsgInvalidCalendarURL               = "Invalid calendar URL. Must be an http, https or webcal URL"
+	ErrorMsgInvalidCalendarFile              = "Invalid iCalendar file"
+	ErrorMsgCalendarNotSyncable              = "Uploaded calendars cannot be synced, upload the file again instead"
+	ErrorMsgInvalidImportFile                = "Invalid import file"
+	ErrorMsgUnsupportedImportFormat          = "Unsupported import format. Must be one of: csv, xlsx"
+	ErrorMsgInvalidImportMapping             = "Invalid mapping. Must be a JSON object of field names to column headers"
+	ErrorMsgInvalidDryRun                    = "Invalid dry_run. Must be true or false"
+	ErrorMsgInvalidExportFormat              = "Invalid format. Must be one of: csv, xlsx"
+	ErrorMsgInvalidDateRange                 = "Invalid date range. 'to' must not be before 'from'"
+	ErrorMsgInvalidGranularity               = "Invalid granularity. Must be one of: day, week, month"
+	ErrorMsgInvalidLimit                     = "Invalid limit. Must be between 1 and 100"
+	ErrorMsgInvalidReportFormat              = "Invalid format. Must be one of: json, csv"
+	ErrorMsgInvalidIdempotencyKey            = "Invalid Idempotency-Key header. Must be 1 to 255 characters"
+	ErrorMsgIdempotencyKeyReused             = "Idempotency-Key was already used for a different request"
+	ErrorMsgInvalidIfMatch                   = "Invalid If-Match header. Must be a single ETag of the appointment or *"
 	ErrorMsgMissingRequiredField             = "Missing required field"
 	ErrorMsgFutureTimeRequired               = "Appointment time must be in the future"
 	ErrorMsgAppointmentNotPending            = "Appointment is not pending"
 	ErrorMsgAppointmentNotPendingOrConfirmed = "Appointment is not pending or confirmed. Please check the status of the appointment."
+	ErrorMsgLateCancellationNotAllowed       = "Cancellation is too late. The professional does not allow cancellations with less notice than their policy requires"
+	ErrorMsgInvalidNoticeHours               = "Invalid min_notice_hours. Must be between 0 and 720"
+	ErrorMsgBookingNoticeTooShort            = "Appointment starts too soon. The professional requires more notice for bookings"
+	ErrorMsgBookingBeyondHorizon             = "Appointment starts too far ahead. The professional does not accept bookings that far in advance"
+	ErrorMsgInvalidBookingLimit              = "Invalid booking rule. max_advance_days must be between 1 and 365 and longer than min_notice_hours, client limits at least 1"
+	ErrorMsgSlotHoldMismatch                 = "The slot hold was made for another client, professional or time"
 
 	// Authentication errors
 	ErrorMsgMissingAuthToken    = "Authorization header is required"
@@ -43,20 +71,159 @@ const (
 	ErrorMsgFailedToRetrieveAppointments  = "Failed to retrieve appointments"
 	ErrorMsgFailedToRetrieveProfessionals = "Failed to retrieve professionals"
 	ErrorMsgFailedToGetTimetable          = "Failed to get professional timetable"
+	ErrorMsgFailedToRetrieveEvents        = "Failed to retrieve events"
+	ErrorMsgFailedToRegenerateToken       = "Failed to regenerate calendar token"
+	ErrorMsgFailedToRetrieveCalendars     = "Failed to retrieve external calendars"
+	ErrorMsgFailedToExportAppointments    = "Failed to export appointments"
+	ErrorMsgFailedToGetProfessionalStats  = "Failed to get professional statistics"
+	ErrorMsgFailedToUpdateLanguage        = "Failed to update language"
+	ErrorMsgFailedToGetUser               = "Failed to get user"
+	ErrorMsgFailedToGetCancellationPolicy = "Failed to get cancellation policy"
+	ErrorMsgFailedToGetBookingRule        = "Failed to get booking rules"
+	ErrorMsgFailedToRetrieveWaitlist      = "Failed to retrieve waitlist"
 
 	// Not found errors
-	ErrorMsgUserNotFound = "User not found"
+	ErrorMsgUserNotFound          = "User not found"
+	ErrorMsgCalendarNotFound      = "Calendar not found"
+	ErrorMsgAppointmentNotFound   = "Appointment not found"
+	ErrorMsgProfessionalNotFound  = "Professional not found"
+	ErrorMsgClientNotFound        = "Client not found"
+	ErrorMsgWaitlistEntryNotFound = "Waitlist entry not found"
+	ErrorMsgSlotHoldNotFound      = "Slot hold not found or expired"
 
 	// Forbidden errors
 	ErrorMsgNotAllowedToAccessResource = "You are not allowed to access this resource"
 
 	// Conflict errors
 	ErrorMsgUsernameAlreadyExists = "Username already exists"
+	ErrorMsgAlreadyExists         = "Resource already exists"
+	ErrorMsgIdempotencyKeyBusy    = "A request with this Idempotency-Key is still being processed"
+	ErrorMsgDailyBookingLimit     = "The client already has the maximum number of appointments with this professional on that day"
+	ErrorMsgPendingBookingLimit   = "The client already has the maximum number of pending requests with this professional"
+	ErrorMsgWaitlistEntryInactive = "Waitlist entry was already booked, expired or cancelled"
+	ErrorMsgNoWaitlistOffer       = "Waitlist entry has no open offer"
+	ErrorMsgSlotOffered           = "The slot is held for a client from the waitlist"
+	ErrorMsgSlotHeld              = "The slot is held by another client"
+	ErrorMsgSlotTaken             = "The slot is already booked or blocked"
+
+	// Precondition errors
+	ErrorMsgIfMatchRequired     = "If-Match header with the appointment ETag is required"
+	ErrorMsgAppointmentModified = "Appointment was modified by another request. Reload it and try again"
+
+	// Rate limit errors
+	ErrorMsgRateLimited = "Too many requests. Retry after the number of seconds in the Retry-After header"
 
 	// Internal errors
 	ErrorMsgInternalServerError = "Internal server error"
 )
 
+// Error codes identify errors for clients and key the message catalogs. Unlike messages
+// they never change, clients should branch on them.
+const (
+	// Validation errors
+	ErrorCodeInvalidRequestBody         = "invalid_request_body"
+	ErrorCodeMissingRequiredField       = "missing_required_field"
+	ErrorCodeInvalidAppointmentID       = "invalid_appointment_id"
+	ErrorCodeInvalidProfessionalID      = "invalid_professional_id"
+	ErrorCodeInvalidClientID            = "invalid_client_id"
+	ErrorCodeInvalidCalendarID          = "invalid_calendar_id"
+	ErrorCodeInvalidWaitlistEntryID     = "invalid_waitlist_entry_id"
+	ErrorCodeInvalidHoldID              = "invalid_hold_id"
+	ErrorCodeInvalidDate                = "invalid_date"
+	ErrorCodeInvalidMonth               = "invalid_month"
+	ErrorCodeInvalidTime                = "invalid_time"
+	ErrorCodeInvalidStatus              = "invalid_status"
+	ErrorCodeInvalidLastEventID         = "invalid_last_event_id"
+	ErrorCodeInvalidDateRange           = "invalid_date_range"
+	ErrorCodeInvalidTimeRange           = "invalid_time_range"
+	ErrorCodePastTime                   = "past_time"
+	ErrorCodeInvalidCalendarURL         = "invalid_calendar_url"
+	ErrorCodeInvalidCalendarData        = "invalid_calendar_data"
+	ErrorCodeCalendarNotSyncable        = "calendar_not_syncable"
+	ErrorCodeInvalidImportFile          = "invalid_import_file"
+	ErrorCodeUnsupportedImportFormat    = "unsupported_import_format"
+	ErrorCodeInvalidImportMapping       = "invalid_import_mapping"
+	ErrorCodeInvalidDryRun              = "invalid_dry_run"
+	ErrorCodeInvalidExportFormat        = "invalid_export_format"
+	ErrorCodeInvalidReportFormat        = "invalid_report_format"
+	ErrorCodeInvalidGranularity         = "invalid_granularity"
+	ErrorCodeInvalidLimit               = "invalid_limit"
+	ErrorCodeInvalidIdempotencyKey      = "invalid_idempotency_key"
+	ErrorCodeIdempotencyKeyReused       = "idempotency_key_reused"
+	ErrorCodeInvalidIfMatch             = "invalid_if_match"
+	ErrorCodeAppointmentNotPending      = "appointment_not_pending"
+	ErrorCodeAppointmentNotCancellable  = "appointment_not_pending_or_confirmed"
+	ErrorCodeLateCancellationNotAllowed = "late_cancellation_not_allowed"
+	ErrorCodeInvalidNoticeHours         = "invalid_notice_hours"
+	ErrorCodeBookingNoticeTooShort      = "booking_notice_too_short"
+	ErrorCodeBookingBeyondHorizon       = "booking_beyond_horizon"
+	ErrorCodeInvalidBookingLimit        = "invalid_booking_limit"
+	ErrorCodeSlotHoldMismatch           = "slot_hold_mismatch"
+
+	// Authentication errors
+	ErrorCodeInvalidCredentials  = "invalid_credentials"
+	ErrorCodeMissingAuthToken    = "missing_auth_token"
+	ErrorCodeInvalidAuthHeader   = "invalid_auth_header"
+	ErrorCodeUnsupportedAuthType = "unsupported_auth_type"
+	ErrorCodeInvalidToken        = "invalid_token"
+
+	// Database errors
+	ErrorCodeCreateAppointmentFailed     = "create_appointment_failed"
+	ErrorCodeGetAppointmentFailed        = "get_appointment_failed"
+	ErrorCodeUpdateAppointmentFailed     = "update_appointment_failed"
+	ErrorCodeCreateClientFailed          = "create_client_failed"
+	ErrorCodeCreateProfessionalFailed    = "create_professional_failed"
+	ErrorCodeUpdateProfessionalFailed    = "update_professional_failed"
+	ErrorCodeRetrieveAppointmentsFailed  = "retrieve_appointments_failed"
+	ErrorCodeRetrieveProfessionalsFailed = "retrieve_professionals_failed"
+	ErrorCodeGetTimetableFailed          = "get_timetable_failed"
+	ErrorCodeRetrieveEventsFailed        = "retrieve_events_failed"
+	ErrorCodeRegenerateTokenFailed       = "regenerate_token_failed"
+	ErrorCodeRetrieveCalendarsFailed     = "retrieve_calendars_failed"
+	ErrorCodeExportAppointmentsFailed    = "export_appointments_failed"
+	ErrorCodeGetProfessionalStatsFailed  = "get_professional_stats_failed"
+	ErrorCodeUpdateLanguageFailed        = "update_language_failed"
+	ErrorCodeGetUserFailed               = "get_user_failed"
+	ErrorCodeGetCancellationPolicyFailed = "get_cancellation_policy_failed"
+	ErrorCodeGetBookingRuleFailed        = "get_booking_rules_failed"
+	ErrorCodeRetrieveWaitlistFailed      = "retrieve_waitlist_failed"
+
+	// Not found errors
+	ErrorCodeUserNotFound          = "user_not_found"
+	ErrorCodeCalendarNotFound      = "external_calendar_not_found"
+	ErrorCodeFeedNotFound          = "calendar_feed_not_found"
+	ErrorCodeAppointmentNotFound   = "appointment_not_found"
+	ErrorCodeProfessionalNotFound  = "professional_not_found"
+	ErrorCodeClientNotFound        = "client_not_found"
+	ErrorCodeWaitlistEntryNotFound = "waitlist_entry_not_found"
+	ErrorCodeSlotHoldNotFound      = "slot_hold_not_found"
+
+	// Forbidden errors
+	ErrorCodeForbidden = "forbidden"
+
+	// Conflict errors
+	ErrorCodeUsernameAlreadyExists    = "username_already_exists"
+	ErrorCodeAlreadyExists            = "already_exists"
+	ErrorCodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+	ErrorCodeDailyBookingLimit        = "daily_booking_limit_reached"
+	ErrorCodePendingBookingLimit      = "pending_booking_limit_reached"
+	ErrorCodeWaitlistEntryInactive    = "waitlist_entry_inactive"
+	ErrorCodeNoWaitlistOffer          = "no_waitlist_offer"
+	ErrorCodeSlotOffered              = "slot_offered_to_waitlist"
+	ErrorCodeSlotHeld                 = "slot_held"
+	ErrorCodeSlotTaken                = "slot_taken"
+
+	// Precondition errors
+	ErrorCodeIfMatchRequired     = "if_match_required"
+	ErrorCodeAppointmentModified = "appointment_modified"
+
+	// Rate limit errors
+	ErrorCodeRateLimited = "rate_limited"
+
+	// Internal errors
+	ErrorCodeInternal = "internal_error"
+)
+
 // User roles
 const (
 	RoleProfessional = "professional"
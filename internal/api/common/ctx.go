@@ -3,11 +3,14 @@ package common
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
+	"github.com/vention/booking_api/internal/i18n"
 )
 
 const (
-	RequestIDKey string = "request_id"
-	LoggerKey    string = "logger"
+	RequestIDKey   string = "request_id"
+	LoggerKey      string = "logger"
+	AuthPayloadKey string = "auth_payload"
+	LocaleKey      string = "locale"
 )
 
 func GetRequestID(c *gin.Context) string {
@@ -20,3 +23,11 @@ func GetLogger(c *gin.Context) zerolog.Logger {
 	}
 	return zerolog.Nop()
 }
+
+// GetLocale returns the locale negotiated for the request, the default locale when none was
+func GetLocale(c *gin.Context) i18n.Locale {
+	if locale, exists := c.Get(LocaleKey); exists {
+		return locale.(i18n.Locale)
+	}
+	return i18n.Default
+}
@@ -2,11 +2,19 @@ package common
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/i18n"
 	. "github.com/vention/booking_api/internal/op"
 )
 
-// HandleErrorResponse creates a standardized error response
-func HandleErrorResponse(c *gin.Context, statusCode int, errorType, message string, err error) {
+// HandleErrorResponse creates a standardized error response with a specific error code
+func HandleErrorResponse(c *gin.Context, statusCode int, errorType, code, message string, err error) {
+	HandleErrorResponseWithDetails(c, statusCode, errorType, code, message, nil, err)
+}
+
+// HandleErrorResponseWithDetails creates a standardized error response with a specific error code
+// and the fields that failed validation. Messages are logged in English and translated to the
+// locale of the request by their code.
+func HandleErrorResponseWithDetails(c *gin.Context, statusCode int, errorType, code, message string, details []ErrorDetail, err error) {
 	logger := GetLogger(c)
 	requestID := GetRequestID(c)
 
@@ -22,9 +30,16 @@ func HandleErrorResponse(c *gin.Context, statusCode int, errorType, message stri
 			Msg(message)
 	}
 
+	locale := GetLocale(c)
+	for i, detail := range details {
+		details[i].Message = i18n.Translate(locale, detail.key, detail.Message, detail.args...)
+	}
+
 	errorResp := ErrorResponse{
 		Error:     errorType,
-		Message:   message,
+		Code:      code,
+		Message:   i18n.Translate(locale, code, message),
+		Details:   details,
 		RequestID: requestID,
 	}
 
@@ -33,7 +48,30 @@ func HandleErrorResponse(c *gin.Context, statusCode int, errorType, message stri
 
 // ErrorResponse represents error responses
 type ErrorResponse struct {
-	Error     string `json:"error"`
-	Message   string `json:"message"`
-	RequestID string `json:"request_id"`
+	Error     string        `json:"error"`             // Error type
+	Code      string        `json:"code"`              // Stable code of the error, the error type when there is no specific code
+	Message   string        `json:"message"`           // Translated to the negotiated locale
+	Details   []ErrorDetail `json:"details,omitempty"` // Fields that failed validation
+	RequestID string        `json:"request_id"`
+}
+
+// ErrorDetail describes a request field that failed validation
+type ErrorDetail struct {
+	Field   string `json:"field"` // JSON field, query parameter or header name
+	Rule    string `json:"rule"`  // Validation rule, e.g. required or oneof
+	Message string `json:"message"`
+
+	key  string   // Message key in the i18n catalogs
+	args []string // Placeholder names and values of the message
+}
+
+// newErrorDetail creates a detail with the English message of a catalog key
+func newErrorDetail(field, rule, key string, args ...string) ErrorDetail {
+	return ErrorDetail{
+		Field:   field,
+		Rule:    rule,
+		Message: i18n.Translate(i18n.English, key, key, args...),
+		key:     key,
+		args:    args,
+	}
 }
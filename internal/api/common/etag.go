@@ -0,0 +1,56 @@
+package common
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// AppointmentETag returns the strong entity tag of an appointment version, derived from updated_at
+func AppointmentETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 36) + `"`
+}
+
+// SetAppointmentETag sets the ETag header of a response containing a single appointment
+func SetAppointmentETag(c *gin.Context, updatedAt time.Time) {
+	c.Header(ETagHeader, AppointmentETag(updatedAt))
+}
+
+// ParseIfMatch reads the appointment version the client expects from the If-Match header.
+// Returns nil when the header is absent or "*", or rejects the request when required is set
+// and the header is missing.
+func ParseIfMatch(c *gin.Context, required bool) (*time.Time, bool) {
+	value := strings.TrimSpace(c.GetHeader(IfMatchHeader))
+	if value == "" {
+		if required {
+			HandleErrorResponse(c, http.StatusPreconditionRequired, ErrorTypePrecondition, ErrorCodeIfMatchRequired, ErrorMsgIfMatchRequired, nil)
+			return nil, false
+		}
+		return nil, true
+	}
+	if value == "*" {
+		return nil, true
+	}
+
+	// Weak tags never match under If-Match, and only one version can be expected
+	tag, ok := strings.CutPrefix(value, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	micros, err := strconv.ParseInt(tag, 36, 64)
+	if !ok || err != nil {
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidIfMatch, ErrorMsgInvalidIfMatch, err)
+		return nil, false
+	}
+
+	updatedAt := time.UnixMicro(micros)
+	return &updatedAt, true
+}
@@ -59,6 +59,23 @@ func FromNullInt64(ni sql.NullInt64) *int64 {
 	return &ni.Int64
 }
 
+// FromNullInt32 converts sql.NullInt32 to int32 pointer
+func FromNullInt32(ni sql.NullInt32) *int32 {
+	if !ni.Valid {
+		return nil
+	}
+	return &ni.Int32
+}
+
+// FromNullTimeRFC3339 converts sql.NullTime to an RFC3339 string pointer
+func FromNullTimeRFC3339(nt sql.NullTime) *string {
+	if !nt.Valid {
+		return nil
+	}
+	formatted := FormatTimeRFC3339(nt.Time)
+	return &formatted
+}
+
 // Time formatting constants
 const (
 	TimeFormatRFC3339      = time.RFC3339
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// Parameters and headers shared by the OpenAPI operations of the handler packages
+var (
+	// IdempotencyKeyParam documents the header of routes wrapped in the idempotency middleware
+	IdempotencyKeyParam = openapi.Param{
+		Name:        "Idempotency-Key",
+		In:          openapi.InHeader,
+		Description: "Makes retries safe, the first response is replayed for 24 hours by default",
+	}
+
+	// IfMatchParam documents the appointment version expected by confirmations and cancellations
+	IfMatchParam = openapi.Param{
+		Name:        IfMatchHeader,
+		In:          openapi.InHeader,
+		Description: "ETag of the appointment, required when REQUIRE_IF_MATCH is enabled",
+	}
+
+	// ETagResponseHeader documents the version of a returned appointment
+	ETagResponseHeader = openapi.Param{
+		Name:        ETagHeader,
+		Description: "Version of the appointment, send it as If-Match to confirm or cancel",
+	}
+
+	// AppointmentStatusParam documents the optional status filter of appointment lists
+	AppointmentStatusParam = openapi.Param{
+		Name: "status",
+		In:   openapi.InQuery,
+		Enum: []string{"pending", "confirmed", "cancelled", "completed"},
+	}
+)
+
+// DateRangeParams documents the required, inclusive from and to query parameters
+var DateRangeParams = []openapi.Param{
+	{Name: "from", In: openapi.InQuery, Required: true, Format: "date"},
+	{Name: "to", In: openapi.InQuery, Required: true, Format: "date"},
+}
+
+// EventStreamParams documents the resume position of Server-Sent Events streams
+var EventStreamParams = []openapi.Param{
+	{Name: LastEventIDHeader, In: openapi.InHeader, Type: "integer", Description: "Resume after this event, sent by EventSource on reconnect"},
+	{Name: "last_event_id", In: openapi.InQuery, Type: "integer", Description: "Resume after this event when the header cannot be set"},
+}
+
+// EventStreamResponse documents a stream of EventResponse objects as Server-Sent Events
+var EventStreamResponse = openapi.Response{
+	Status:       http.StatusOK,
+	Description:  "Server-Sent Events stream, each data line is an event object",
+	ContentTypes: []string{"text/event-stream"},
+}
@@ -1,19 +1,115 @@
 package common
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 )
 
+func init() {
+	// Report validation errors with the JSON names of request fields
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
 // BindAndValidate validates JSON request body and handles errors automatically
 // Returns the validated request and a boolean indicating success
 // If validation fails, error response is sent automatically and false is returned
 func BindAndValidate[T any](c *gin.Context) (T, bool) {
 	var req T
 	if err := c.ShouldBindJSON(&req); err != nil {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgInvalidRequestBody, err)
+		HandleErrorResponseWithDetails(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidRequestBody, ErrorMsgInvalidRequestBody, RequestErrorDetails(err), err)
 		return req, false
 	}
 	return req, true
 }
+
+// RequestErrorDetails describes the fields of a request body that failed decoding or validation
+func RequestErrorDetails(err error) []ErrorDetail {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		details := make([]ErrorDetail, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			details = append(details, validationDetail(fieldErr))
+		}
+		return details
+
+	case errors.As(err, &typeErr):
+		return []ErrorDetail{newErrorDetail(typeErr.Field, "type", "type."+jsonTypeName(typeErr.Type))}
+
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return []ErrorDetail{newErrorDetail("", "json", "rule.json")}
+
+	case errors.Is(err, io.EOF):
+		return []ErrorDetail{newErrorDetail("", "required", "rule.body_required")}
+	}
+
+	return nil
+}
+
+// fieldPath returns the dotted JSON path of a field, without the request struct name
+func fieldPath(fieldErr validator.FieldError) string {
+	_, path, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+// validationDetail describes a failed validation rule
+func validationDetail(fieldErr validator.FieldError) ErrorDetail {
+	field, rule := fieldPath(fieldErr), fieldErr.Tag()
+	switch rule {
+	case "required", "email", "url":
+		return newErrorDetail(field, rule, "rule."+rule)
+	case "oneof":
+		return newErrorDetail(field, rule, "rule.oneof", "values", strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "min", "max", "len":
+		return newErrorDetail(field, rule, "rule."+rule, "param", fieldErr.Param())
+	case "uuid", "uuid4":
+		return newErrorDetail(field, rule, "rule.uuid")
+	default:
+		return newErrorDetail(field, rule, "rule.other", "rule", rule)
+	}
+}
+
+// jsonTypeName names the JSON type expected for a Go type, as used by the type.* message keys
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/i18n"
 	svcCommon "github.com/vention/booking_api/internal/services/common"
 )
 
@@ -12,25 +13,173 @@ import (
 func HandleServiceError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, svcCommon.ErrInvalidTimeRange):
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgInvalidTime, err)
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidTimeRange, ErrorMsgInvalidTime, err)
 
 	case errors.Is(err, svcCommon.ErrPastTime):
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgFutureTimeRequired, err)
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodePastTime, ErrorMsgFutureTimeRequired, err)
 
 	case errors.Is(err, svcCommon.ErrInvalidCredentials):
-		HandleErrorResponse(c, http.StatusUnauthorized, ErrorTypeValidation, ErrorMsgInvalidCredentials, err)
+		handleServiceError(c, http.StatusUnauthorized, ErrorTypeValidation, ErrorCodeInvalidCredentials, ErrorMsgInvalidCredentials, err)
 
 	case errors.Is(err, svcCommon.ErrForbidden):
-		HandleErrorResponse(c, http.StatusForbidden, ErrorTypeForbidden, ErrorMsgNotAllowedToAccessResource, err)
+		handleServiceError(c, http.StatusForbidden, ErrorTypeForbidden, ErrorCodeForbidden, ErrorMsgNotAllowedToAccessResource, err)
+
+	case errors.Is(err, svcCommon.ErrAppointmentNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeAppointmentNotFound, ErrorMsgAppointmentNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrProfessionalNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeProfessionalNotFound, ErrorMsgProfessionalNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrClientNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeClientNotFound, ErrorMsgClientNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrAlreadyExists):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeAlreadyExists, ErrorMsgAlreadyExists, err)
 
 	case errors.Is(err, svcCommon.ErrAppointmentNotPending):
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgAppointmentNotPending, err)
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeAppointmentNotPending, ErrorMsgAppointmentNotPending, err)
 
 	case errors.Is(err, svcCommon.ErrAppointmentNotPendingOrConfirmed):
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgAppointmentNotPendingOrConfirmed, err)
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeAppointmentNotCancellable, ErrorMsgAppointmentNotPendingOrConfirmed, err)
+
+	case errors.Is(err, svcCommon.ErrLateCancellationNotAllowed):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeLateCancellationNotAllowed, ErrorMsgLateCancellationNotAllowed, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidNoticeHours):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidNoticeHours, ErrorMsgInvalidNoticeHours, err)
+
+	case errors.Is(err, svcCommon.ErrBookingNoticeTooShort):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeBookingNoticeTooShort, ErrorMsgBookingNoticeTooShort, err)
+
+	case errors.Is(err, svcCommon.ErrBookingBeyondHorizon):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeBookingBeyondHorizon, ErrorMsgBookingBeyondHorizon, err)
+
+	case errors.Is(err, svcCommon.ErrDailyBookingLimitReached):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeDailyBookingLimit, ErrorMsgDailyBookingLimit, err)
+
+	case errors.Is(err, svcCommon.ErrPendingBookingLimitReached):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodePendingBookingLimit, ErrorMsgPendingBookingLimit, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidBookingLimit):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidBookingLimit, ErrorMsgInvalidBookingLimit, err)
+
+	case errors.Is(err, svcCommon.ErrWaitlistEntryNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeWaitlistEntryNotFound, ErrorMsgWaitlistEntryNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrWaitlistEntryInactive):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeWaitlistEntryInactive, ErrorMsgWaitlistEntryInactive, err)
+
+	case errors.Is(err, svcCommon.ErrNoWaitlistOffer):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeNoWaitlistOffer, ErrorMsgNoWaitlistOffer, err)
+
+	case errors.Is(err, svcCommon.ErrSlotOffered):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeSlotOffered, ErrorMsgSlotOffered, err)
+
+	case errors.Is(err, svcCommon.ErrSlotHoldNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeSlotHoldNotFound, ErrorMsgSlotHoldNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrSlotHoldMismatch):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeSlotHoldMismatch, ErrorMsgSlotHoldMismatch, err)
+
+	case errors.Is(err, svcCommon.ErrSlotHeld):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeSlotHeld, ErrorMsgSlotHeld, err)
+
+	case errors.Is(err, svcCommon.ErrSlotTaken):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeSlotTaken, ErrorMsgSlotTaken, err)
+
+	case errors.Is(err, svcCommon.ErrAppointmentModified):
+		handleServiceError(c, http.StatusPreconditionFailed, ErrorTypePrecondition, ErrorCodeAppointmentModified, ErrorMsgAppointmentModified, err)
+
+	case errors.Is(err, svcCommon.ErrExternalCalendarNotFound):
+		handleServiceError(c, http.StatusNotFound, ErrorTypeNotFound, ErrorCodeCalendarNotFound, ErrorMsgCalendarNotFound, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidCalendarURL):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidCalendarURL, ErrorMsgInvalidCalendarURL, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidCalendarData):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidCalendarData, ErrorMsgInvalidCalendarFile, err)
+
+	case errors.Is(err, svcCommon.ErrCalendarNotSyncable):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeCalendarNotSyncable, ErrorMsgCalendarNotSyncable, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidImportFile):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidImportFile, ErrorMsgInvalidImportFile, err)
+
+	case errors.Is(err, svcCommon.ErrUnsupportedImportFormat):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeUnsupportedImportFormat, ErrorMsgUnsupportedImportFormat, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidGranularity):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidGranularity, ErrorMsgInvalidGranularity, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidLimit):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidLimit, ErrorMsgInvalidLimit, err)
+
+	case errors.Is(err, svcCommon.ErrInvalidIdempotencyKey):
+		handleServiceError(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidIdempotencyKey, ErrorMsgInvalidIdempotencyKey, err)
+
+	case errors.Is(err, svcCommon.ErrIdempotencyKeyReused):
+		handleServiceError(c, http.StatusUnprocessableEntity, ErrorTypeValidation, ErrorCodeIdempotencyKeyReused, ErrorMsgIdempotencyKeyReused, err)
+
+	case errors.Is(err, svcCommon.ErrIdempotencyKeyInProgress):
+		handleServiceError(c, http.StatusConflict, ErrorTypeConflict, ErrorCodeIdempotencyKeyInProgress, ErrorMsgIdempotencyKeyBusy, err)
 
 	default:
 		// For unknown errors, return internal server error
-		HandleErrorResponse(c, http.StatusInternalServerError, ErrorTypeInternal, ErrorMsgInternalServerError, err)
+		HandleErrorResponseWithDetails(c, http.StatusInternalServerError, ErrorTypeInternal, ErrorCodeInternal, ErrorMsgInternalServerError, nil, err)
+	}
+}
+
+// ItemError describes why one item of a bulk operation was skipped
+type ItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"` // Translated to the negotiated locale
+}
+
+// NewItemError describes the service error that skipped one item of a bulk operation
+func NewItemError(c *gin.Context, err error) *ItemError {
+	code, message := ErrorCodeInternal, ErrorMsgInternalServerError
+	switch {
+	case errors.Is(err, svcCommon.ErrForbidden):
+		code, message = ErrorCodeForbidden, ErrorMsgNotAllowedToAccessResource
+	case errors.Is(err, svcCommon.ErrAppointmentNotFound):
+		code, message = ErrorCodeAppointmentNotFound, ErrorMsgAppointmentNotFound
+	case errors.Is(err, svcCommon.ErrAppointmentNotPending):
+		code, message = ErrorCodeAppointmentNotPending, ErrorMsgAppointmentNotPending
+	case errors.Is(err, svcCommon.ErrAppointmentNotPendingOrConfirmed):
+		code, message = ErrorCodeAppointmentNotCancellable, ErrorMsgAppointmentNotPendingOrConfirmed
+	case errors.Is(err, svcCommon.ErrSlotTaken):
+		code, message = ErrorCodeSlotTaken, ErrorMsgSlotTaken
+	}
+	return &ItemError{Code: code, Message: i18n.Translate(GetLocale(c), code, message)}
+}
+
+// HandleDatabaseError responds to an error of a service call that reads or writes the database:
+// not found and conflict errors as HandleServiceError does, other errors as a database error
+// with the message of the failed operation
+func HandleDatabaseError(c *gin.Context, err error, code, message string) {
+	if errors.Is(err, svcCommon.ErrNotFound) || errors.Is(err, svcCommon.ErrAlreadyExists) {
+		HandleServiceError(c, err)
+		return
+	}
+	HandleErrorResponse(c, http.StatusInternalServerError, ErrorTypeDatabase, code, message, err)
+}
+
+// handleServiceError writes the response of a domain error, with the field that failed
+// validation when the service reported one
+func handleServiceError(c *gin.Context, statusCode int, errorType, code, message string, err error) {
+	var details []ErrorDetail
+	var fieldErr *svcCommon.FieldError
+	if errors.As(err, &fieldErr) {
+		details = []ErrorDetail{fieldErrorDetail(fieldErr)}
+	}
+	HandleErrorResponseWithDetails(c, statusCode, errorType, code, message, details, err)
+}
+
+// fieldErrorDetail describes the rule a field failed in the service layer
+func fieldErrorDetail(fieldErr *svcCommon.FieldError) ErrorDetail {
+	key := "rule." + fieldErr.Rule
+	if _, ok := i18n.Message(i18n.Default, key); !ok {
+		return newErrorDetail(fieldErr.Field, fieldErr.Rule, "rule.other", "rule", fieldErr.Rule)
 	}
+	return newErrorDetail(fieldErr.Field, fieldErr.Rule, key, fieldErr.Args...)
 }
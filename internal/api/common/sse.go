@@ -0,0 +1,170 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/i18n"
+	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/util"
+)
+
+// LastEventIDHeader is the header browsers send when an EventSource reconnects
+const LastEventIDHeader = "Last-Event-ID"
+
+// EventResponse represents an appointment event in Server-Sent Events streams
+type EventResponse struct {
+	ID             int64           `json:"id"`
+	Type           string          `json:"type"`
+	AppointmentID  string          `json:"appointment_id"`
+	ProfessionalID string          `json:"professional_id"`
+	ClientID       *string         `json:"client_id,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	Message        string          `json:"message,omitempty"` // Notification text in the locale of the stream
+	CreatedAt      string          `json:"created_at"`
+}
+
+// ParseLastEventID reads the resume position from the Last-Event-ID header or last_event_id query parameter
+// Returns 0 when the stream should start with live events only
+func ParseLastEventID(c *gin.Context) (int64, bool) {
+	value := c.GetHeader(LastEventIDHeader)
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, true
+	}
+
+	lastEventID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || lastEventID < 0 {
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidLastEventID, ErrorMsgInvalidLastEventID, err)
+		return 0, false
+	}
+	return lastEventID, true
+}
+
+// BacklogLoader returns the next page of events recorded after the given event ID, empty once caught up
+type BacklogLoader func(ctx context.Context, lastEventID int64) ([]*db.AppointmentEvent, error)
+
+// StreamEvents writes the events recorded after lastEventID followed by live events from the subscription
+// as Server-Sent Events. The backlog is loaded page by page until it is caught up; no backlog is replayed
+// when lastEventID is 0. It blocks until the client disconnects or the subscription is closed, either
+// because the subscriber was dropped or because the server is shutting down.
+func StreamEvents(c *gin.Context, lastEventID int64, loadBacklog BacklogLoader, sub *events.Subscription, heartbeatInterval time.Duration) {
+	logger := GetLogger(c)
+	ctx := c.Request.Context()
+
+	// Load the first page before the response is committed so that failures are reported as errors
+	var backlog []*db.AppointmentEvent
+	if lastEventID > 0 {
+		var err error
+		backlog, err = loadBacklog(ctx, lastEventID)
+		if err != nil {
+			HandleDatabaseError(c, err, ErrorCodeRetrieveEventsFailed, ErrorMsgFailedToRetrieveEvents)
+			return
+		}
+	}
+
+	// Streams outlive the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Warn().Err(err).Msg("Failed to clear write deadline for event stream")
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for len(backlog) > 0 {
+		for _, event := range backlog {
+			if err := writeEvent(c, event); err != nil {
+				return
+			}
+			lastEventID = event.ID
+		}
+
+		var err error
+		backlog, err = loadBacklog(ctx, lastEventID)
+		if err != nil {
+			// The client resumes from the last delivered event when it reconnects
+			logger.Error().Err(err).Int64("last_event_id", lastEventID).Msg("Failed to load event backlog")
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if _, err := fmt.Fprintf(c.Writer, "event: heartbeat\ndata: {\"time\":%q}\n\n", FormatTimeRFC3339(time.Now())); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case event, ok := <-sub.C:
+			if !ok {
+				logger.Info().Msg("Event stream subscription closed")
+				return
+			}
+			// Skip events already delivered from the backlog. IDs are assigned in commit order
+			// by the recorder, no event with a lower ID can be committed later.
+			if event.ID <= lastEventID {
+				continue
+			}
+			if err := writeEvent(c, event); err != nil {
+				return
+			}
+			lastEventID = event.ID
+		}
+	}
+}
+
+// writeEvent writes a single event frame and flushes it to the client
+func writeEvent(c *gin.Context, event *db.AppointmentEvent) error {
+	response := EventResponse{
+		ID:             event.ID,
+		Type:           event.EventType,
+		AppointmentID:  event.AppointmentID.String(),
+		ProfessionalID: event.ProfessionalID.String(),
+		Payload:        event.Payload,
+		Message:        eventMessage(GetLocale(c), event),
+		CreatedAt:      FormatTimeRFC3339(event.CreatedAt),
+	}
+	if event.ClientID.Valid {
+		response.ClientID = StringPtr(event.ClientID.UUID.String())
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// eventMessage describes an event for people, with the appointment time in the application timezone
+func eventMessage(locale i18n.Locale, event *db.AppointmentEvent) string {
+	var payload events.AppointmentPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil || payload.StartTime.IsZero() {
+		return ""
+	}
+	date := i18n.FormatDateTime(locale, payload.StartTime.In(util.GetAppTimezone()))
+	return i18n.Translate(locale, "event."+event.EventType, "", "date", date)
+}
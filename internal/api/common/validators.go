@@ -10,10 +10,10 @@ import (
 
 // ParseUUID parses UUID from string and handles error response automatically
 // Returns parsed UUID and boolean indicating success
-func ParseUUID(c *gin.Context, idStr string, errorMsg string) (uuid.UUID, bool) {
+func ParseUUID(c *gin.Context, idStr string, code, errorMsg string) (uuid.UUID, bool) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, errorMsg, err)
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, code, errorMsg, err)
 		return uuid.UUID{}, false
 	}
 	return id, true
@@ -21,34 +21,34 @@ func ParseUUID(c *gin.Context, idStr string, errorMsg string) (uuid.UUID, bool)
 
 // ParseClientID is a convenience wrapper for parsing client IDs
 func ParseClientID(c *gin.Context, idStr string) (uuid.UUID, bool) {
-	return ParseUUID(c, idStr, ErrorMsgInvalidClientID)
+	return ParseUUID(c, idStr, ErrorCodeInvalidClientID, ErrorMsgInvalidClientID)
 }
 
 // ParseProfessionalID is a convenience wrapper for parsing professional IDs
 func ParseProfessionalID(c *gin.Context, idStr string) (uuid.UUID, bool) {
-	return ParseUUID(c, idStr, ErrorMsgInvalidProfessionalID)
+	return ParseUUID(c, idStr, ErrorCodeInvalidProfessionalID, ErrorMsgInvalidProfessionalID)
 }
 
 // ParseAppointmentID is a convenience wrapper for parsing appointment IDs
 func ParseAppointmentID(c *gin.Context, idStr string) (uuid.UUID, bool) {
-	return ParseUUID(c, idStr, ErrorMsgInvalidAppointmentID)
+	return ParseUUID(c, idStr, ErrorCodeInvalidAppointmentID, ErrorMsgInvalidAppointmentID)
 }
 
 // ParseTime parses RFC3339 time string and handles error response automatically
-func ParseTime(c *gin.Context, timeStr string, errorMsg string) (time.Time, bool) {
+func ParseTime(c *gin.Context, timeStr string, code, errorMsg string) (time.Time, bool) {
 	t, err := time.Parse(time.RFC3339, timeStr)
 	if err != nil {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, errorMsg, err)
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, code, errorMsg, err)
 		return time.Time{}, false
 	}
 	return t, true
 }
 
 // ParseDate parses date string (YYYY-MM-DD format) and handles error response automatically
-func ParseDate(c *gin.Context, dateStr string, errorMsg string) (time.Time, bool) {
+func ParseDate(c *gin.Context, dateStr string, code, errorMsg string) (time.Time, bool) {
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, errorMsg, err)
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, code, errorMsg, err)
 		return time.Time{}, false
 	}
 	return date, true
@@ -58,7 +58,7 @@ func ParseDate(c *gin.Context, dateStr string, errorMsg string) (time.Time, bool
 func ParseMonth(c *gin.Context, monthStr string) (time.Time, bool) {
 	month, err := time.Parse("2006-01", monthStr)
 	if err != nil {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, "Invalid month format. Use YYYY-MM", err)
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidMonth, ErrorMsgInvalidMonth, err)
 		return time.Time{}, false
 	}
 	return month, true
@@ -79,7 +79,7 @@ func ValidateAppointmentStatus(c *gin.Context, status string) bool {
 	}
 
 	if !ValidAppointmentStatuses[status] {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgInvalidStatus, nil)
+		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeInvalidStatus, ErrorMsgInvalidStatus, nil)
 		return false
 	}
 	return true
@@ -89,7 +89,8 @@ func ValidateAppointmentStatus(c *gin.Context, status string) bool {
 func RequireQueryParam(c *gin.Context, paramName string) (string, bool) {
 	value := c.Query(paramName)
 	if value == "" {
-		HandleErrorResponse(c, http.StatusBadRequest, ErrorTypeValidation, ErrorMsgMissingRequiredField, nil)
+		details := []ErrorDetail{newErrorDetail(paramName, "required", "rule.required")}
+		HandleErrorResponseWithDetails(c, http.StatusBadRequest, ErrorTypeValidation, ErrorCodeMissingRequiredField, ErrorMsgMissingRequiredField, details, nil)
 		return "", false
 	}
 	return value, true
@@ -2,26 +2,81 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
 	adminAPI "github.com/vention/booking_api/internal/api/admin"
 	appointmentsAPI "github.com/vention/booking_api/internal/api/appointments"
+	calendarAPI "github.com/vention/booking_api/internal/api/calendar"
 	clientsAPI "github.com/vention/booking_api/internal/api/clients"
+	common "github.com/vention/booking_api/internal/api/common"
+	holdsAPI "github.com/vention/booking_api/internal/api/holds"
+	importsAPI "github.com/vention/booking_api/internal/api/imports"
+	"github.com/vention/booking_api/internal/api/middleware"
 	professionalsAPI "github.com/vention/booking_api/internal/api/professionals"
+	reportsAPI "github.com/vention/booking_api/internal/api/reports"
+	statsAPI "github.com/vention/booking_api/internal/api/stats"
 	usersAPI "github.com/vention/booking_api/internal/api/users"
+	waitlistAPI "github.com/vention/booking_api/internal/api/waitlist"
 	"github.com/vention/booking_api/internal/config"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/health"
+	"github.com/vention/booking_api/internal/openapi"
 	db "github.com/vention/booking_api/internal/repository"
 	adminService "github.com/vention/booking_api/internal/services/admin"
 	appointmentsService "github.com/vention/booking_api/internal/services/appointments"
+	calendarService "github.com/vention/booking_api/internal/services/calendar"
 	clientsService "github.com/vention/booking_api/internal/services/clients"
+	holdsService "github.com/vention/booking_api/internal/services/holds"
+	idempotencyService "github.com/vention/booking_api/internal/services/idempotency"
+	importsService "github.com/vention/booking_api/internal/services/imports"
 	professionalsService "github.com/vention/booking_api/internal/services/professionals"
+	reportsService "github.com/vention/booking_api/internal/services/reports"
+	statsService "github.com/vention/booking_api/internal/services/stats"
+	waitlistService "github.com/vention/booking_api/internal/services/waitlist"
+	"github.com/vention/booking_api/internal/util"
 )
 
-func Register(ctx context.Context, cfg *config.Config, router *gin.RouterGroup, queries *db.Queries) error {
+// RegisterParams defines the dependencies needed to register all API routes
+type RegisterParams struct {
+	Config         *config.Config
+	Router         *gin.RouterGroup // JWT protected /api routes
+	PublicRouter   *gin.RouterGroup // Routes protected by their own tokens, outside /api
+	Queries        *db.Queries
+	Store          *db.Store // Queries with transaction support
+	EventsBroker   *events.Broker
+	EventsRecorder events.Recorder
+	Probe          *health.Probe // Tracks background workers for the readiness probe
+	Logger         zerolog.Logger
+}
+
+func Register(ctx context.Context, p RegisterParams) error {
+	if p.Config == nil {
+		return errors.New("missing config")
+	}
+	if p.Probe == nil {
+		return errors.New("missing probe")
+	}
+
+	cfg, router, queries := p.Config, p.Router, p.Queries
+
+	// Idempotency keys for endpoints that create or change appointments and clients
+	idempotencySvc := idempotencyService.NewService(queries, idempotencyService.Config{
+		TTL: cfg.IdempotencyKeyTTL,
+	})
+	idempotency := middleware.Idempotency(idempotencySvc)
+
 	// Register clients API
 	if err := clientsAPI.ClientsRegister(clientsAPI.ClientsHandlerParams{
-		Router:         router,
-		ClientsService: clientsService.NewService(queries),
+		Router:            router,
+		ClientsService:    clientsService.NewService(p.Store, p.EventsRecorder),
+		EventsBroker:      p.EventsBroker,
+		HeartbeatInterval: cfg.SSEHeartbeatInterval,
+		Idempotency:       idempotency,
+		RequireIfMatch:    cfg.RequireIfMatch,
 	}); err != nil {
 		return err
 	}
@@ -29,7 +84,11 @@ func Register(ctx context.Context, cfg *config.Config, router *gin.RouterGroup,
 	// Register professionals API
 	if err := professionalsAPI.ProfessionalsRegister(professionalsAPI.ProfessionalsHandlerParams{
 		Router:               router,
-		ProfessionalsService: professionalsService.NewService(queries),
+		ProfessionalsService: professionalsService.NewService(p.Store, p.EventsRecorder),
+		EventsBroker:         p.EventsBroker,
+		HeartbeatInterval:    cfg.SSEHeartbeatInterval,
+		Idempotency:          idempotency,
+		RequireIfMatch:       cfg.RequireIfMatch,
 	}); err != nil {
 		return err
 	}
@@ -45,7 +104,17 @@ func Register(ctx context.Context, cfg *config.Config, router *gin.RouterGroup,
 	// Register appointments API
 	if err := appointmentsAPI.AppointmentsRegister(appointmentsAPI.AppointmentsHandlerParams{
 		Router:              router,
-		AppointmentsService: appointmentsService.NewService(queries),
+		AppointmentsService: appointmentsService.NewService(p.Store, p.EventsRecorder),
+		Idempotency:         idempotency,
+	}); err != nil {
+		return err
+	}
+
+	// Register slot holds API
+	holdsSvc := holdsService.NewService(p.Store, holdsService.Config{TTL: cfg.SlotHoldTTL})
+	if err := holdsAPI.HoldsRegister(holdsAPI.HoldsHandlerParams{
+		Router:       router,
+		HoldsService: holdsSvc,
 	}); err != nil {
 		return err
 	}
@@ -58,5 +127,104 @@ func Register(ctx context.Context, cfg *config.Config, router *gin.RouterGroup,
 		return err
 	}
 
+	// Register calendar feeds and external calendars API
+	allowedNetworks, err := calendarService.ParseAllowedNetworks(cfg.ExternalCalendarAllowedNetworks)
+	if err != nil {
+		return fmt.Errorf("invalid EXTERNAL_CALENDAR_ALLOWED_NETWORKS: %w", err)
+	}
+	calendarSvc := calendarService.NewService(queries, calendarService.NewHTTPClient(cfg.ExternalCalendarFetchTimeout, allowedNetworks))
+	if err := calendarAPI.CalendarRegister(calendarAPI.CalendarHandlerParams{
+		Router:          router,
+		FeedRouter:      p.PublicRouter.Group("", middleware.UserLocale(queries)), // Feeds in the language of their owner
+		CalendarService: calendarSvc,
+		PublicBaseURL:   cfg.PublicBaseURL,
+	}); err != nil {
+		return err
+	}
+
+	// Register spreadsheet imports API
+	if err := importsAPI.ImportsRegister(importsAPI.ImportsHandlerParams{
+		Router:         router,
+		ImportsService: importsService.NewService(p.Store),
+	}); err != nil {
+		return err
+	}
+
+	// Register statistics API
+	if err := statsAPI.StatsRegister(statsAPI.StatsHandlerParams{
+		Router: router,
+		StatsService: statsService.NewService(queries, statsService.Config{
+			WorkingHoursStart: common.WorkingHoursStart,
+			WorkingHoursEnd:   common.WorkingHoursEnd,
+			AppTimezone:       util.GetAppTimezone(),
+		}),
+	}); err != nil {
+		return err
+	}
+
+	// Register admin reports API
+	if err := reportsAPI.ReportsRegister(reportsAPI.ReportsHandlerParams{
+		Router: router,
+		ReportsService: reportsService.NewService(queries, reportsService.Config{
+			CacheTTL:    cfg.AdminReportsCacheTTL,
+			AppTimezone: util.GetAppTimezone(),
+		}),
+		CacheTTL: cfg.AdminReportsCacheTTL,
+	}); err != nil {
+		return err
+	}
+
+	// Register waitlist API
+	waitlistSvc := waitlistService.NewService(p.Store, p.EventsRecorder, waitlistService.Config{
+		OfferTTL: cfg.WaitlistOfferTTL,
+	})
+	if err := waitlistAPI.WaitlistRegister(waitlistAPI.WaitlistHandlerParams{
+		Router:          router,
+		WaitlistService: waitlistSvc,
+		Idempotency:     idempotency,
+	}); err != nil {
+		return err
+	}
+
+	// Periodically re-import subscribed external calendars
+	p.Probe.Go(ctx, "calendar_sync", func() {
+		calendarService.RunSync(ctx, calendarSvc, cfg.ExternalCalendarSyncInterval, p.Logger)
+	})
+
+	// Periodically purge expired idempotency keys
+	p.Probe.Go(ctx, "idempotency_cleanup", func() {
+		idempotencyService.RunCleanup(ctx, idempotencySvc, cfg.IdempotencyKeyCleanupInterval, p.Logger)
+	})
+
+	// Periodically purge expired slot holds
+	p.Probe.Go(ctx, "slot_hold_cleanup", func() {
+		holdsService.RunCleanup(ctx, holdsSvc, cfg.SlotHoldCleanupInterval, p.Logger)
+	})
+
+	// Offer slots freed by cancellations to waiting clients and expire unanswered offers
+	p.Probe.Go(ctx, "waitlist_offers", func() {
+		waitlistService.RunOffers(ctx, waitlistSvc, p.EventsBroker, cfg.WaitlistSweepInterval, p.Logger)
+	})
+
 	return nil
 }
+
+// Operations describes the routes registered by Register for the OpenAPI document
+func Operations(router, publicRouter *gin.RouterGroup) []openapi.Operation {
+	return slices.Concat(
+		openapi.Mount(router.BasePath(), true, slices.Concat(
+			clientsAPI.ClientsOperations(),
+			professionalsAPI.ProfessionalsOperations(),
+			adminAPI.AdminsOperations(),
+			appointmentsAPI.AppointmentsOperations(),
+			holdsAPI.HoldsOperations(),
+			usersAPI.UsersOperations(),
+			calendarAPI.CalendarOperations(),
+			importsAPI.ImportsOperations(),
+			statsAPI.StatsOperations(),
+			reportsAPI.ReportsOperations(),
+			waitlistAPI.WaitlistOperations(),
+		)),
+		openapi.Mount(publicRouter.BasePath(), false, calendarAPI.CalendarFeedOperations()),
+	)
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/holds"
+)
+
+// CreateHold handles POST /api/professionals/{id}/holds
+func (h *HoldsHandler) CreateHold(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[CreateHoldRequest](c)
+	if !ok {
+		return
+	}
+
+	clientID, ok := common.ParseClientID(c, req.ClientID)
+	if !ok {
+		return
+	}
+
+	startTime, ok := common.ParseTime(c, req.StartTime, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
+	if !ok {
+		return
+	}
+
+	endTime, ok := common.ParseTime(c, req.EndTime, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
+	if !ok {
+		return
+	}
+
+	hold, err := h.holdsService.CreateHold(c.Request.Context(), holds.CreateHoldInput{
+		ProfessionalID: professionalID,
+		ClientID:       clientID,
+		StartTime:      startTime,
+		EndTime:        endTime,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapSlotHoldToResponse(hold))
+}
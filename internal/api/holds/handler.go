@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/holds"
+)
+
+// HoldsHandler handles HTTP requests for temporary holds of professionals' slots
+type HoldsHandler struct {
+	holdsService holds.Service
+}
+
+// NewHoldsHandler creates a new handler with dependency injection
+func NewHoldsHandler(service holds.Service) *HoldsHandler {
+	return &HoldsHandler{
+		holdsService: service,
+	}
+}
+
+// HoldsHandlerParams defines the parameters for the HoldsHandler
+type HoldsHandlerParams struct {
+	Router       *gin.RouterGroup
+	HoldsService holds.Service
+}
+
+// HoldsRegister registers the HoldsHandler with the router
+func HoldsRegister(p HoldsHandlerParams) error {
+	if p.Router == nil {
+		return errors.New("missing router")
+	}
+
+	if p.HoldsService == nil {
+		return errors.New("missing holds service")
+	}
+
+	h := NewHoldsHandler(p.HoldsService)
+
+	p.Router.POST("/professionals/:id/holds", h.CreateHold)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	common "github.com/vention/booking_api/internal/api/common"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// mapSlotHoldToResponse maps a slot hold to a HoldResponse
+func mapSlotHoldToResponse(hold *db.SlotHold) HoldResponse {
+	return HoldResponse{
+		ID:             hold.ID.String(),
+		ProfessionalID: hold.ProfessionalID.String(),
+		ClientID:       hold.ClientID.String(),
+		StartTime:      common.FormatTimeRFC3339(hold.StartTime),
+		EndTime:        common.FormatTimeRFC3339(hold.EndTime),
+		ExpiresAt:      common.FormatTimeRFC3339(hold.ExpiresAt),
+	}
+}
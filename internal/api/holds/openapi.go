@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// HoldsOperations describes the routes registered by HoldsRegister for the OpenAPI document
+func HoldsOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      http.MethodPost,
+			Path:        "/professionals/:id/holds",
+			Summary:     "Hold a slot of a professional while the client books it",
+			Description: "Other clients cannot hold or book the slot until the hold expires or is consumed by creating the appointment with its hold_id. A client holds one slot of a professional at a time.",
+			Tags:        []string{"holds"},
+			Request:     CreateHoldRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: HoldResponse{}},
+				{Status: http.StatusNotFound, Description: "Professional or client not found"},
+				{Status: http.StatusConflict, Description: "Slot is booked, blocked, offered to the waitlist or held for another client"},
+			},
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package api
+
+// CreateHoldRequest represents the request to hold a slot of a professional for a client
+type CreateHoldRequest struct {
+	ClientID  string `json:"client_id" binding:"required,uuid"`
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// HoldResponse represents a held slot. Pass the ID as hold_id when creating the appointment.
+type HoldResponse struct {
+	ID             string `json:"id"`
+	ProfessionalID string `json:"professional_id"`
+	ClientID       string `json:"client_id"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+	ExpiresAt      string `json:"expires_at"`
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/imports"
+)
+
+// ImportClients handles POST /api/professionals/{id}/imports/clients
+func (h *ImportsHandler) ImportClients(c *gin.Context) {
+	h.handleImport(c, h.importsService.ImportClients)
+}
+
+// ImportAppointments handles POST /api/professionals/{id}/imports/appointments
+func (h *ImportsHandler) ImportAppointments(c *gin.Context) {
+	h.handleImport(c, h.importsService.ImportAppointments)
+}
+
+// handleImport parses the multipart upload ("file", optional "format" and "mapping" fields,
+// "dry_run" query parameter) and runs the import
+func (h *ImportsHandler) handleImport(c *gin.Context, run func(context.Context, imports.ImportInput) (*imports.Report, error)) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	dryRun := false
+	if value := c.Query("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidDryRun, common.ErrorMsgInvalidDryRun, err)
+			return
+		}
+		dryRun = parsed
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeMissingRequiredField, common.ErrorMsgMissingRequiredField, err)
+		return
+	}
+
+	var mapping map[string]string
+	if value := c.PostForm("mapping"); value != "" {
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidImportMapping, common.ErrorMsgInvalidImportMapping, err)
+			return
+		}
+	}
+
+	// The format defaults to the file extension
+	format := c.PostForm("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidImportFile, common.ErrorMsgInvalidImportFile, err)
+		return
+	}
+	defer file.Close()
+
+	report, err := run(c.Request.Context(), imports.ImportInput{
+		ProfessionalID: professionalID,
+		Format:         format,
+		Content:        file,
+		Mapping:        mapping,
+		DryRun:         dryRun,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapReportToImportReportResponse(report))
+}
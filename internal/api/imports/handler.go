@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/imports"
+)
+
+// ImportsHandler handles HTTP requests for spreadsheet imports
+type ImportsHandler struct {
+	importsService imports.Service
+}
+
+// NewImportsHandler creates a new handler with dependency injection
+func NewImportsHandler(service imports.Service) *ImportsHandler {
+	return &ImportsHandler{
+		importsService: service,
+	}
+}
+
+// ImportsHandlerParams defines the parameters for the ImportsHandler
+type ImportsHandlerParams struct {
+	Router         *gin.RouterGroup
+	ImportsService imports.Service
+}
+
+// ImportsRegister registers the ImportsHandler with the router
+func ImportsRegister(p ImportsHandlerParams) error {
+	if p.Router == nil {
+		return errors.New("missing router")
+	}
+
+	if p.ImportsService == nil {
+		return errors.New("missing imports service")
+	}
+
+	h := NewImportsHandler(p.ImportsService)
+
+	imports := p.Router.Group("/professionals/:id/imports")
+	{
+		imports.POST("/clients", h.ImportClients)
+		imports.POST("/appointments", h.ImportAppointments)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package api
+
+import "github.com/vention/booking_api/internal/services/imports"
+
+// mapReportToImportReportResponse maps an import report to an ImportReportResponse
+func mapReportToImportReportResponse(report *imports.Report) ImportReportResponse {
+	response := ImportReportResponse{
+		DryRun:         report.DryRun,
+		TotalRows:      report.TotalRows,
+		ValidRows:      report.ValidRows,
+		ImportedRows:   report.ImportedRows,
+		CreatedClients: report.CreatedClients,
+		Errors:         make([]ImportRowError, len(report.Errors)),
+	}
+	for i, rowErr := range report.Errors {
+		response.Errors[i] = ImportRowError{
+			Row:     rowErr.Row,
+			Field:   rowErr.Field,
+			Message: rowErr.Message,
+		}
+	}
+	return response
+}
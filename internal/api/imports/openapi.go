@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+	"github.com/vention/booking_api/internal/services/imports"
+)
+
+// ImportsOperations describes the routes registered by ImportsRegister for the OpenAPI document
+func ImportsOperations() []openapi.Operation {
+	params := []openapi.Param{
+		{Name: "dry_run", In: openapi.InQuery, Type: "boolean", Description: "Validate the rows without importing them"},
+	}
+	form := []openapi.Param{
+		{Name: "file", Type: openapi.TypeFile, Required: true},
+		{Name: "format", Enum: []string{imports.FormatCSV, imports.FormatXLSX}, Description: "Defaults to the file extension"},
+		{Name: "mapping", Description: "JSON object of field names to column headers"},
+	}
+	responses := []openapi.Response{
+		{Status: http.StatusOK, Body: ImportReportResponse{}},
+	}
+
+	return []openapi.Operation{
+		{
+			Method:    http.MethodPost,
+			Path:      "/professionals/:id/imports/clients",
+			Summary:   "Import clients from a spreadsheet",
+			Tags:      []string{"imports"},
+			Params:    params,
+			Form:      form,
+			Responses: responses,
+		},
+		{
+			Method:    http.MethodPost,
+			Path:      "/professionals/:id/imports/appointments",
+			Summary:   "Import appointments from a spreadsheet",
+			Tags:      []string{"imports"},
+			Params:    params,
+			Form:      form,
+			Responses: responses,
+		},
+	}
+}
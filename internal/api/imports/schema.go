@@ -0,0 +1,18 @@
+package api
+
+// ImportReportResponse represents the outcome of a clients or appointments import
+type ImportReportResponse struct {
+	DryRun         bool             `json:"dry_run"`
+	TotalRows      int              `json:"total_rows"`
+	ValidRows      int              `json:"valid_rows"`
+	ImportedRows   int              `json:"imported_rows"`   // 0 in dry-run mode
+	CreatedClients int              `json:"created_clients"` // Includes clients created for imported appointments
+	Errors         []ImportRowError `json:"errors"`
+}
+
+// ImportRowError represents a rejected spreadsheet row (row 1 is the header row)
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
@@ -20,33 +20,34 @@ func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		authorizationHeader := c.GetHeader(authorizationHeaderKey)
 
 		if len(authorizationHeader) == 0 {
-			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorMsgMissingAuthToken, nil)
+			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorCodeMissingAuthToken, common.ErrorMsgMissingAuthToken, nil)
 			c.Abort()
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
-			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorMsgInvalidAuthHeader, nil)
+			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorCodeInvalidAuthHeader, common.ErrorMsgInvalidAuthHeader, nil)
 			c.Abort()
 			return
 		}
 
 		authorizationType := fields[0]
 		if authorizationType != authorizationTypeBearer {
-			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorMsgUnsupportedAuthType, nil)
+			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorCodeUnsupportedAuthType, common.ErrorMsgUnsupportedAuthType, nil)
 			c.Abort()
 			return
 		}
 
 		accessToken := fields[1]
-		_, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken)
 		if err != nil {
-			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorMsgInvalidToken, err)
+			common.HandleErrorResponse(c, http.StatusUnauthorized, common.ErrorTypeAuth, common.ErrorCodeInvalidToken, common.ErrorMsgInvalidToken, err)
 			c.Abort()
 			return
 		}
 
+		c.Set(common.AuthPayloadKey, payload)
 		c.Next()
 	}
 }
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored and replayed besides Content-Type. Other headers
+// describe the connection or the retry rather than the result of the request.
+var replayedHeaders = []string{"ETag", "Location", contentLanguageHeader}
+
+// Idempotency makes retries of a request sent with an Idempotency-Key header safe: the response
+// of the first request is stored and replayed for retries instead of running the handler again.
+// Requests failing with a server error release the key so that they can be retried.
+func Idempotency(service idempotency.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidRequestBody, common.ErrorMsgInvalidRequestBody, err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := c.Request.Method + " " + c.FullPath()
+		stored, err := service.Begin(c.Request.Context(), idempotency.BeginInput{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hashRequest(c.Request, body),
+		})
+		if err != nil {
+			common.HandleServiceError(c, err)
+			c.Abort()
+			return
+		}
+
+		if stored != nil {
+			for name, value := range stored.Headers {
+				c.Header(name, value)
+			}
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(stored.Status, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		// Store or release the key even when the client has gone away
+		ctx := context.WithoutCancel(c.Request.Context())
+		logger := common.GetLogger(c)
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			// Also runs when the handler panics
+			if completed {
+				return
+			}
+			if err := service.Release(ctx, scope, key); err != nil {
+				logger.Error().Err(err).Str("idempotency_key", key).Msg("Failed to release idempotency key")
+			}
+		}()
+
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		if err := service.Complete(ctx, idempotency.CompleteInput{
+			Scope: scope,
+			Key:   key,
+			Response: idempotency.Response{
+				Status:      recorder.Status(),
+				ContentType: recorder.Header().Get("Content-Type"),
+				Headers:     storedHeaders(recorder.Header()),
+				Body:        recorder.body.Bytes(),
+			},
+		}); err != nil {
+			logger.Error().Err(err).Str("idempotency_key", key).Msg("Failed to store idempotent response")
+			return
+		}
+		completed = true
+	}
+}
+
+// storedHeaders returns the replayed headers the response has
+func storedHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string)
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// hashRequest identifies a request by its method, URL and body
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
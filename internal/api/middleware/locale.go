@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/i18n"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+const (
+	acceptLanguageHeader  = "Accept-Language"
+	contentLanguageHeader = "Content-Language"
+)
+
+// LanguageStore reads the languages stored for clients and professionals
+type LanguageStore interface {
+	GetClientLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error)
+	GetProfessionalLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error)
+}
+
+// Locale negotiates the locale of messages from the Accept-Language header, the default
+// locale when the header accepts none of the supported ones
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", acceptLanguageHeader)
+
+		locale, ok := i18n.Negotiate(c.GetHeader(acceptLanguageHeader))
+		if !ok {
+			locale = i18n.Default
+		}
+		setLocale(c, locale)
+
+		c.Next()
+	}
+}
+
+// UserLocale uses the language stored for the client or professional of /clients/:id and
+// /professionals/:id routes, and of the /ical/clients/:file and /ical/professionals/:file feeds,
+// when the request has no usable Accept-Language header. Must run after Locale.
+func UserLocale(store LanguageStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := i18n.Negotiate(c.GetHeader(acceptLanguageHeader)); !ok {
+			if locale, ok := storedLocale(c, store); ok {
+				setLocale(c, locale)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// storedLocale looks up the language of the user the route belongs to
+func storedLocale(c *gin.Context, store LanguageStore) (i18n.Locale, bool) {
+	var getLanguage func(context.Context, uuid.UUID) (sql.NullString, error)
+	switch route := c.FullPath(); {
+	case strings.Contains(route, "/clients/:id"), strings.HasPrefix(route, "/ical/clients/:file"):
+		getLanguage = store.GetClientLanguage
+	case strings.Contains(route, "/professionals/:id"), strings.HasPrefix(route, "/ical/professionals/:file"):
+		getLanguage = store.GetProfessionalLanguage
+	default:
+		return "", false
+	}
+
+	// Feeds are named {id}.ics; invalid ids are reported by the handler
+	rawID := c.Param("id")
+	if rawID == "" {
+		rawID = strings.TrimSuffix(c.Param("file"), ".ics")
+	}
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return "", false
+	}
+
+	language, err := getLanguage(c.Request.Context(), id)
+	if err != nil {
+		if err = db.TranslateError(err, db.ErrNotFound); !errors.Is(err, db.ErrNotFound) {
+			logger := common.GetLogger(c)
+			logger.Warn().Err(err).Msg("Failed to get user language")
+		}
+		return "", false
+	}
+	if !language.Valid {
+		return "", false
+	}
+	return i18n.Parse(language.String)
+}
+
+func setLocale(c *gin.Context, locale i18n.Locale) {
+	c.Set(common.LocaleKey, locale)
+	c.Header(contentLanguageHeader, string(locale))
+}
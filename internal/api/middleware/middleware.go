@@ -7,6 +7,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/metrics"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const RequestIDKey = "X-Request-ID"
@@ -26,7 +28,7 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
-// Logger creates an adjusted logger with request context and logs HTTP requests
+// Logger creates an adjusted logger with request context, logs HTTP requests and records request metrics
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -35,11 +37,16 @@ func Logger() gin.HandlerFunc {
 		baseLogger := c.MustGet("logger").(zerolog.Logger)
 
 		// Create adjusted logger with request context
-		adjustedLogger := baseLogger.With().
+		logContext := baseLogger.With().
 			Str("request_id", common.GetRequestID(c)).
 			Str("endpoint", c.FullPath()).
-			Str("method", c.Request.Method).
-			Logger()
+			Str("method", c.Request.Method)
+
+		// Correlate logs with the trace started by the Tracing middleware
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			logContext = logContext.Str("trace_id", spanContext.TraceID().String())
+		}
+		adjustedLogger := logContext.Logger()
 
 		// Set adjusted logger in context for use in handlers
 		c.Set(common.LoggerKey, adjustedLogger)
@@ -61,5 +68,7 @@ func Logger() gin.HandlerFunc {
 			Dur("latency", latency).
 			Str("client_ip", clientIP).
 			Msg("Request completed")
+
+		metrics.ObserveHTTPRequest(c.Request.Method, c.FullPath(), statusCode, latency)
 	}
 }
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/ratelimit"
+	"github.com/vention/booking_api/internal/token"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	rateLimitPolicyHeader    = "RateLimit-Policy"
+	retryAfterHeader         = "Retry-After"
+)
+
+// RateLimit limits requests whose route policy is keyed by keyBy, ratelimit.KeyByIP or
+// ratelimit.KeyByToken; routes with policies of the other key are left to the other limiter.
+// The IP-keyed limiter runs before AuthMiddleware, so that requests failing authentication are
+// limited too, and the token-keyed one after it. Requests are let through when the store fails,
+// so that a limiter outage does not take the API down.
+func RateLimit(limiter *ratelimit.Limiter, keyBy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := limiter.Policy(c.FullPath())
+		if policy.KeyBy != keyBy {
+			c.Next()
+			return
+		}
+
+		result, err := limiter.Take(c.Request.Context(), policy, rateLimitKey(c, policy))
+		if err != nil {
+			logger := common.GetLogger(c)
+			logger.Error().Err(err).Str("policy", policy.Name).Msg("Failed to check rate limit")
+			c.Next()
+			return
+		}
+
+		c.Header(rateLimitLimitHeader, strconv.Itoa(policy.Limit))
+		c.Header(rateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(rateLimitResetHeader, formatSeconds(result.ResetAfter))
+		c.Header(rateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Period.Seconds())))
+
+		if !result.Allowed {
+			c.Header(retryAfterHeader, formatSeconds(result.RetryAfter))
+			common.HandleErrorResponse(c, http.StatusTooManyRequests, common.ErrorTypeRateLimited, common.ErrorCodeRateLimited, common.ErrorMsgRateLimited, nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitKey returns the client key of the policy, the client IP when no token was verified
+func rateLimitKey(c *gin.Context, policy ratelimit.Policy) string {
+	if policy.KeyBy == ratelimit.KeyByToken {
+		if payload, ok := c.Get(common.AuthPayloadKey); ok {
+			return "service:" + payload.(*token.Payload).Service
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// formatSeconds rounds d up to whole seconds, as used by Retry-After and RateLimit-Reset
+func formatSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
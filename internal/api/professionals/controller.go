@@ -1,11 +1,16 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/events"
+	db "github.com/vention/booking_api/internal/repository"
 	"github.com/vention/booking_api/internal/services/professionals"
 	"github.com/vention/booking_api/internal/util"
 )
@@ -14,7 +19,7 @@ import (
 func (h *ProfessionalsHandler) GetProfessionals(c *gin.Context) {
 	professionals, err := h.professionalsService.GetProfessionals(c.Request.Context())
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToRetrieveProfessionals, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveProfessionalsFailed, common.ErrorMsgFailedToRetrieveProfessionals)
 		return
 	}
 
@@ -55,9 +60,15 @@ func (h *ProfessionalsHandler) ConfirmAppointment(c *gin.Context) {
 		return
 	}
 
+	expectedUpdatedAt, ok := common.ParseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	result, err := h.professionalsService.ConfirmAppointment(c.Request.Context(), professionals.ConfirmAppointmentInput{
-		ProfessionalID: professionalID,
-		AppointmentID:  appointmentID,
+		ProfessionalID:    professionalID,
+		AppointmentID:     appointmentID,
+		ExpectedUpdatedAt: expectedUpdatedAt,
 	})
 	if err != nil {
 		common.HandleServiceError(c, err)
@@ -65,6 +76,7 @@ func (h *ProfessionalsHandler) ConfirmAppointment(c *gin.Context) {
 	}
 
 	response := mapAppointmentToConfirmAppointmentResponse(result)
+	common.SetAppointmentETag(c, result.UpdatedAt)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -82,14 +94,14 @@ func (h *ProfessionalsHandler) GetProfessionalAppointments(c *gin.Context) {
 
 	dateFilter := c.Query("date")
 	if dateFilter != "" {
-		if _, ok := common.ParseDate(c, dateFilter, common.ErrorMsgInvalidDate); !ok {
+		if _, ok := common.ParseDate(c, dateFilter, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate); !ok {
 			return
 		}
 	}
 
 	appointments, err := h.professionalsService.GetAppointments(c.Request.Context(), professionalID, statusFilter, dateFilter)
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToRetrieveAppointments, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
 		return
 	}
 
@@ -118,7 +130,7 @@ func (h *ProfessionalsHandler) GetProfessionalAppointmentDates(c *gin.Context) {
 
 	appointmentDates, err := h.professionalsService.GetAppointmentDates(c.Request.Context(), professionalID, targetMonth)
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToRetrieveAppointments, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
 		return
 	}
 
@@ -146,6 +158,11 @@ func (h *ProfessionalsHandler) CancelAppointment(c *gin.Context) {
 		return
 	}
 
+	expectedUpdatedAt, ok := common.ParseIfMatch(c, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	req, ok := common.BindAndValidate[CancelAppointmentRequest](c)
 	if !ok {
 		return
@@ -155,6 +172,7 @@ func (h *ProfessionalsHandler) CancelAppointment(c *gin.Context) {
 		ProfessionalID:     professionalID,
 		AppointmentID:      appointmentID,
 		CancellationReason: req.CancellationReason,
+		ExpectedUpdatedAt:  expectedUpdatedAt,
 	})
 	if err != nil {
 		common.HandleServiceError(c, err)
@@ -162,9 +180,95 @@ func (h *ProfessionalsHandler) CancelAppointment(c *gin.Context) {
 	}
 
 	response := mapAppointmentToCancelAppointmentResponse(result)
+	common.SetAppointmentETag(c, result.UpdatedAt)
 	c.JSON(http.StatusOK, response)
 }
 
+// BulkConfirmAppointments handles POST /api/professionals/{id}/appointments/bulk_confirm
+func (h *ProfessionalsHandler) BulkConfirmAppointments(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[BulkConfirmAppointmentsRequest](c)
+	if !ok {
+		return
+	}
+
+	appointmentIDs, date, ok := parseBulkSelection(c, req.AppointmentIDs, req.Date)
+	if !ok {
+		return
+	}
+
+	results, err := h.professionalsService.ConfirmAppointments(c.Request.Context(), professionals.BulkConfirmInput{
+		ProfessionalID: professionalID,
+		AppointmentIDs: appointmentIDs,
+		Date:           date,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapBulkResultsToResponse(c, results))
+}
+
+// BulkCancelAppointments handles POST /api/professionals/{id}/appointments/bulk_cancel
+func (h *ProfessionalsHandler) BulkCancelAppointments(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[BulkCancelAppointmentsRequest](c)
+	if !ok {
+		return
+	}
+
+	appointmentIDs, date, ok := parseBulkSelection(c, req.AppointmentIDs, req.Date)
+	if !ok {
+		return
+	}
+
+	results, err := h.professionalsService.CancelAppointments(c.Request.Context(), professionals.BulkCancelInput{
+		ProfessionalID:     professionalID,
+		AppointmentIDs:     appointmentIDs,
+		Date:               date,
+		Status:             db.AppointmentStatus(req.Status),
+		CancellationReason: req.CancellationReason,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapBulkResultsToResponse(c, results))
+}
+
+// parseBulkSelection parses the appointments selected by a bulk operation: the listed IDs, or the
+// date when none are listed
+func parseBulkSelection(c *gin.Context, ids []string, dateStr string) ([]uuid.UUID, *time.Time, bool) {
+	if dateStr != "" {
+		date, ok := common.ParseDate(c, dateStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+		if !ok {
+			return nil, nil, false
+		}
+		dateApp := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, util.GetAppTimezone())
+		return nil, &dateApp, true
+	}
+
+	appointmentIDs := make([]uuid.UUID, len(ids))
+	for i, idStr := range ids {
+		id, ok := common.ParseAppointmentID(c, idStr)
+		if !ok {
+			return nil, nil, false
+		}
+		appointmentIDs[i] = id
+	}
+	return appointmentIDs, nil, true
+}
+
 // CreateUnavailableAppointment handles POST /api/professionals/{id}/unavailable_appointments
 func (h *ProfessionalsHandler) CreateUnavailableAppointment(c *gin.Context) {
 	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
@@ -177,12 +281,12 @@ func (h *ProfessionalsHandler) CreateUnavailableAppointment(c *gin.Context) {
 		return
 	}
 
-	startTime, ok := common.ParseTime(c, req.StartAt, common.ErrorMsgInvalidTime)
+	startTime, ok := common.ParseTime(c, req.StartAt, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
 	if !ok {
 		return
 	}
 
-	endTime, ok := common.ParseTime(c, req.EndAt, common.ErrorMsgInvalidTime)
+	endTime, ok := common.ParseTime(c, req.EndAt, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
 	if !ok {
 		return
 	}
@@ -199,6 +303,7 @@ func (h *ProfessionalsHandler) CreateUnavailableAppointment(c *gin.Context) {
 	}
 
 	response := mapAppointmentToCreateUnavailableAppointmentResponse(appointment)
+	common.SetAppointmentETag(c, appointment.UpdatedAt)
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -214,24 +319,59 @@ func (h *ProfessionalsHandler) GetProfessionalAvailability(c *gin.Context) {
 		return
 	}
 
-	date, ok := common.ParseDate(c, dateStr, common.ErrorMsgInvalidDate)
+	date, ok := common.ParseDate(c, dateStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
 	if !ok {
 		return
 	}
 
+	// Holds of the viewing client do not make slots unavailable to them
+	var viewerID uuid.NullUUID
+	if clientIDStr := c.Query("client_id"); clientIDStr != "" {
+		clientID, ok := common.ParseClientID(c, clientIDStr)
+		if !ok {
+			return
+		}
+		viewerID = uuid.NullUUID{UUID: clientID, Valid: true}
+	}
+
 	dateApp := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, util.GetAppTimezone())
 
 	appointments, err := h.professionalsService.GetAvailability(c.Request.Context(), professionalID, dateApp)
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToRetrieveAppointments, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
+		return
+	}
+
+	busyBlocks, err := h.professionalsService.GetExternalBusyBlocks(c.Request.Context(), professionalID, dateApp)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
+		return
+	}
+
+	offers, err := h.professionalsService.GetWaitlistOffers(c.Request.Context(), professionalID, dateApp)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
+		return
+	}
+
+	holds, err := h.professionalsService.GetSlotHolds(c.Request.Context(), professionalID, dateApp, viewerID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveAppointmentsFailed, common.ErrorMsgFailedToRetrieveAppointments)
+		return
+	}
+
+	bookingRule, err := h.professionalsService.GetBookingRule(c.Request.Context(), professionalID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeGetBookingRuleFailed, common.ErrorMsgFailedToGetBookingRule)
 		return
 	}
 
 	// Generate availability slots using service
-	slots := h.professionalsService.GenerateAvailabilitySlots(date, appointments, professionals.AvailabilityConfig{
+	slots := h.professionalsService.GenerateAvailabilitySlots(date, appointments, busyBlocks, offers, holds, professionals.AvailabilityConfig{
 		WorkingHoursStart: common.WorkingHoursStart,
 		WorkingHoursEnd:   common.WorkingHoursEnd,
 		AppTimezone:       util.GetAppTimezone(),
+		BookingRule:       bookingRule,
 	})
 
 	// Map service slots to response slots
@@ -254,6 +394,84 @@ func (h *ProfessionalsHandler) GetProfessionalAvailability(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ExportProfessionalAppointments handles GET /api/professionals/:id/appointments/export
+func (h *ProfessionalsHandler) ExportProfessionalAppointments(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	fromStr, ok := common.RequireQueryParam(c, "from")
+	if !ok {
+		return
+	}
+
+	toStr, ok := common.RequireQueryParam(c, "to")
+	if !ok {
+		return
+	}
+
+	from, ok := common.ParseDate(c, fromStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return
+	}
+
+	to, ok := common.ParseDate(c, toStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return
+	}
+
+	if to.Before(from) {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidDateRange, common.ErrorMsgInvalidDateRange, nil)
+		return
+	}
+
+	format := c.DefaultQuery("format", exportFormatCSV)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidExportFormat, common.ErrorMsgInvalidExportFormat, nil)
+		return
+	}
+
+	exporter, err := newAppointmentExporter(format, c.Writer)
+	if err != nil {
+		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeInternal, common.ErrorCodeExportAppointmentsFailed, common.ErrorMsgFailedToExportAppointments, err)
+		return
+	}
+
+	// Large exports may outlive the server write timeout
+	logger := common.GetLogger(c)
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil {
+		logger.Warn().Err(err).Msg("Failed to extend write deadline for export")
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("appointments_%s_%s.%s", fromStr, toStr, format)))
+
+	// "to" is inclusive, both dates are interpreted in the application timezone
+	loc := util.GetAppTimezone()
+	rangeStart := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	rangeEnd := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+
+	err = h.professionalsService.ExportAppointments(c.Request.Context(), professionalID, rangeStart, rangeEnd, func(appointment *db.GetProfessionalAppointmentsForExportRow) error {
+		return exporter.WriteRow(mapAppointmentToExportRow(appointment, loc))
+	})
+	if err == nil {
+		err = exporter.Close()
+	} else {
+		exporter.Discard()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			common.HandleDatabaseError(c, err, common.ErrorCodeExportAppointmentsFailed, common.ErrorMsgFailedToExportAppointments)
+			return
+		}
+		// The response is already partially sent, so the truncated download is all we can do
+		logger.Error().Err(err).Msg(common.ErrorMsgFailedToExportAppointments)
+	}
+}
+
 // GetProfessionalTimetable handles GET /api/professionals/:id/timetable
 func (h *ProfessionalsHandler) GetProfessionalTimetable(c *gin.Context) {
 	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
@@ -266,17 +484,131 @@ func (h *ProfessionalsHandler) GetProfessionalTimetable(c *gin.Context) {
 		return
 	}
 
-	date, ok := common.ParseDate(c, dateStr, common.ErrorMsgInvalidDate)
+	date, ok := common.ParseDate(c, dateStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
 	if !ok {
 		return
 	}
 
 	appointments, err := h.professionalsService.GetTimetable(c.Request.Context(), professionalID, date)
 	if err != nil {
-		common.HandleErrorResponse(c, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorMsgFailedToGetTimetable, err)
+		common.HandleDatabaseError(c, err, common.ErrorCodeGetTimetableFailed, common.ErrorMsgFailedToGetTimetable)
 		return
 	}
 
 	response := mapTimetableAppointmentsToGetProfessionalTimetableResponse(appointments, dateStr)
 	c.JSON(http.StatusOK, response)
 }
+
+// StreamProfessionalEvents handles GET /api/professionals/:id/events
+func (h *ProfessionalsHandler) StreamProfessionalEvents(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	lastEventID, ok := common.ParseLastEventID(c)
+	if !ok {
+		return
+	}
+
+	// Subscribe before reading the backlog so no event falls in between
+	sub := h.eventsBroker.Subscribe(events.ForProfessional(professionalID))
+	defer sub.Close()
+
+	loadBacklog := func(ctx context.Context, lastEventID int64) ([]*db.AppointmentEvent, error) {
+		return h.professionalsService.GetEventsAfter(ctx, professionalID, lastEventID)
+	}
+	common.StreamEvents(c, lastEventID, loadBacklog, sub, h.heartbeatInterval)
+}
+
+// GetCancellationPolicy handles GET /api/professionals/{id}/cancellation_policy
+func (h *ProfessionalsHandler) GetCancellationPolicy(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	policy, err := h.professionalsService.GetCancellationPolicy(c.Request.Context(), professionalID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeGetCancellationPolicyFailed, common.ErrorMsgFailedToGetCancellationPolicy)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCancellationPolicyToResponse(policy))
+}
+
+// UpdateCancellationPolicy handles PUT /api/professionals/{id}/cancellation_policy
+func (h *ProfessionalsHandler) UpdateCancellationPolicy(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[UpdateCancellationPolicyRequest](c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.professionalsService.UpdateCancellationPolicy(c.Request.Context(), professionals.UpdateCancellationPolicyInput{
+		ProfessionalID:        professionalID,
+		MinNoticeHours:        *req.MinNoticeHours,
+		AllowLateCancellation: *req.AllowLateCancellation,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapCancellationPolicyToResponse(policy))
+}
+
+// GetBookingRule handles GET /api/professionals/{id}/booking_rules
+func (h *ProfessionalsHandler) GetBookingRule(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	rule, err := h.professionalsService.GetBookingRule(c.Request.Context(), professionalID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeGetBookingRuleFailed, common.ErrorMsgFailedToGetBookingRule)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapBookingRuleToResponse(rule))
+}
+
+// UpdateBookingRule handles PUT /api/professionals/{id}/booking_rules
+func (h *ProfessionalsHandler) UpdateBookingRule(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[UpdateBookingRuleRequest](c)
+	if !ok {
+		return
+	}
+
+	autoConfirm := db.AutoConfirmModeOff
+	if req.AutoConfirm != "" {
+		autoConfirm = db.AutoConfirmMode(req.AutoConfirm)
+	}
+	allowTentative := req.AllowTentativeRequests == nil || *req.AllowTentativeRequests
+
+	rule, err := h.professionalsService.UpdateBookingRule(c.Request.Context(), professionals.UpdateBookingRuleInput{
+		ProfessionalID:         professionalID,
+		MinNoticeHours:         *req.MinNoticeHours,
+		MaxAdvanceDays:         req.MaxAdvanceDays,
+		MaxDailyPerClient:      req.MaxDailyPerClient,
+		MaxPendingPerClient:    req.MaxPendingPerClient,
+		AutoConfirm:            autoConfirm,
+		AllowTentativeRequests: allowTentative,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapBookingRuleToResponse(rule))
+}
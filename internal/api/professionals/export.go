@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Export formats
+const (
+	exportFormatCSV  = "csv"
+	exportFormatXLSX = "xlsx"
+)
+
+// exportWriteTimeout replaces the server write timeout while an export is streamed
+const exportWriteTimeout = 5 * time.Minute
+
+// exportContentTypes maps export formats to their MIME types
+var exportContentTypes = map[string]string{
+	exportFormatCSV:  "text/csv; charset=utf-8",
+	exportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportColumns is the header row of appointment exports
+var exportColumns = []string{
+	"Date", "Start", "End", "Duration (min)",
+	"Client first name", "Client last name", "Client phone",
+	"Status", "Description", "Cancellation reason",
+}
+
+// neutralizeFormula prefixes text starting like a formula with an apostrophe, so that names or
+// descriptions entered by clients are not evaluated when the export is opened in a spreadsheet application
+func neutralizeFormula(value any) any {
+	text, ok := value.(string)
+	if !ok || text == "" {
+		return value
+	}
+	switch text[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + text
+	}
+	return value
+}
+
+// appointmentExporter writes appointment rows in a spreadsheet format
+type appointmentExporter interface {
+	WriteRow(values []any) error
+	Close() error // Writes any remaining output
+	Discard()     // Releases resources after a failed export
+}
+
+// newAppointmentExporter creates an exporter for the format; nothing is written before the first row
+func newAppointmentExporter(format string, w io.Writer) (appointmentExporter, error) {
+	switch format {
+	case exportFormatCSV:
+		return &csvExporter{writer: csv.NewWriter(w)}, nil
+	case exportFormatXLSX:
+		return newXLSXExporter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// csvExporter streams rows through a buffered CSV writer
+type csvExporter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvExporter) WriteRow(values []any) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = fmt.Sprint(neutralizeFormula(value))
+	}
+	return e.writer.Write(record)
+}
+
+func (e *csvExporter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExporter) Discard() {}
+
+func (e *csvExporter) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+	return e.writer.Write(exportColumns)
+}
+
+// xlsxExporter writes rows with excelize's stream writer, which spills to a temporary file
+// instead of keeping the whole sheet in memory. The workbook is written to w on Close.
+type xlsxExporter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	w      io.Writer
+	row    int
+}
+
+func newXLSXExporter(w io.Writer) (*xlsxExporter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	header := make([]any, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxExporter{file: file, stream: stream, w: w, row: 1}, nil
+}
+
+func (e *xlsxExporter) WriteRow(values []any) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	cells := make([]any, len(values))
+	for i, value := range values {
+		cells[i] = neutralizeFormula(value)
+	}
+	return e.stream.SetRow(cell, cells)
+}
+
+func (e *xlsxExporter) Close() error {
+	defer e.file.Close()
+
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.w)
+}
+
+func (e *xlsxExporter) Discard() {
+	e.file.Close()
+}
@@ -2,24 +2,36 @@ package api
 
 import (
 	"errors"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/events"
 	"github.com/vention/booking_api/internal/services/professionals"
 )
 
 type ProfessionalsHandler struct {
 	professionalsService professionals.Service
+	eventsBroker         *events.Broker
+	heartbeatInterval    time.Duration
+	requireIfMatch       bool
 }
 
-func NewProfessionalsHandler(service professionals.Service) *ProfessionalsHandler {
+func NewProfessionalsHandler(service professionals.Service, eventsBroker *events.Broker, heartbeatInterval time.Duration, requireIfMatch bool) *ProfessionalsHandler {
 	return &ProfessionalsHandler{
 		professionalsService: service,
+		eventsBroker:         eventsBroker,
+		heartbeatInterval:    heartbeatInterval,
+		requireIfMatch:       requireIfMatch,
 	}
 }
 
 type ProfessionalsHandlerParams struct {
 	Router               *gin.RouterGroup
 	ProfessionalsService professionals.Service
+	EventsBroker         *events.Broker
+	HeartbeatInterval    time.Duration
+	Idempotency          gin.HandlerFunc // Makes retries with an Idempotency-Key header safe
+	RequireIfMatch       bool            // Reject confirmations and cancellations without If-Match
 }
 
 func ProfessionalsRegister(p ProfessionalsHandlerParams) error {
@@ -31,19 +43,39 @@ func ProfessionalsRegister(p ProfessionalsHandlerParams) error {
 		return errors.New("missing professionals service")
 	}
 
-	h := NewProfessionalsHandler(p.ProfessionalsService)
+	if p.EventsBroker == nil {
+		return errors.New("missing events broker")
+	}
+
+	if p.HeartbeatInterval <= 0 {
+		return errors.New("invalid heartbeat interval")
+	}
+
+	if p.Idempotency == nil {
+		return errors.New("missing idempotency middleware")
+	}
+
+	h := NewProfessionalsHandler(p.ProfessionalsService, p.EventsBroker, p.HeartbeatInterval, p.RequireIfMatch)
 
 	professionals := p.Router.Group("/professionals")
 	{
 		professionals.GET("", h.GetProfessionals)
 		professionals.POST("/sign_in", h.SignInProfessional)
 		professionals.GET("/:id/appointments", h.GetProfessionalAppointments)
+		professionals.GET("/:id/appointments/export", h.ExportProfessionalAppointments)
 		professionals.GET("/:id/appointment_dates", h.GetProfessionalAppointmentDates)
-		professionals.PATCH("/:id/appointments/:appointment_id/confirm", h.ConfirmAppointment)
-		professionals.PATCH("/:id/appointments/:appointment_id/cancel", h.CancelAppointment)
-		professionals.POST("/:id/unavailable_appointments", h.CreateUnavailableAppointment)
+		professionals.PATCH("/:id/appointments/:appointment_id/confirm", p.Idempotency, h.ConfirmAppointment)
+		professionals.PATCH("/:id/appointments/:appointment_id/cancel", p.Idempotency, h.CancelAppointment)
+		professionals.POST("/:id/appointments/bulk_confirm", p.Idempotency, h.BulkConfirmAppointments)
+		professionals.POST("/:id/appointments/bulk_cancel", p.Idempotency, h.BulkCancelAppointments)
+		professionals.POST("/:id/unavailable_appointments", p.Idempotency, h.CreateUnavailableAppointment)
 		professionals.GET("/:id/availability", h.GetProfessionalAvailability)
 		professionals.GET("/:id/timetable", h.GetProfessionalTimetable)
+		professionals.GET("/:id/events", h.StreamProfessionalEvents)
+		professionals.GET("/:id/cancellation_policy", h.GetCancellationPolicy)
+		professionals.PUT("/:id/cancellation_policy", h.UpdateCancellationPolicy)
+		professionals.GET("/:id/booking_rules", h.GetBookingRule)
+		professionals.PUT("/:id/booking_rules", h.UpdateBookingRule)
 	}
 
 	return nil
@@ -2,9 +2,12 @@ package api
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	common "github.com/vention/booking_api/internal/api/common"
 	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/services/professionals"
 )
 
 func mapProfessionalsToGetProfessionalsResponse(professionals []*db.Professional) GetProfessionalsResponse {
@@ -79,20 +82,23 @@ func mapAppointmentsToGetProfessionalAppointmentsResponse(appointments []*db.Get
 	responseAppointments := make([]ProfessionalAppointment, len(appointments))
 	for i, appt := range appointments {
 		appointment := ProfessionalAppointment{
-			ID:          appt.ID.String(),
-			Type:        string(appt.Type),
-			StartTime:   common.FormatTimeRFC3339(appt.StartTime),
-			EndTime:     common.FormatTimeRFC3339(appt.EndTime),
-			Description: appt.Description.String,
-			Status:      string(appt.Status.AppointmentStatus),
-			CreatedAt:   common.FormatTimeRFC3339(appt.CreatedAt),
-			UpdatedAt:   common.FormatTimeRFC3339(appt.UpdatedAt),
+			ID:                appt.ID.String(),
+			Type:              string(appt.Type),
+			StartTime:         common.FormatTimeRFC3339(appt.StartTime),
+			EndTime:           common.FormatTimeRFC3339(appt.EndTime),
+			Description:       appt.Description.String,
+			Status:            string(appt.Status.AppointmentStatus),
+			CreatedAt:         common.FormatTimeRFC3339(appt.CreatedAt),
+			UpdatedAt:         common.FormatTimeRFC3339(appt.UpdatedAt),
+			ETag:              common.AppointmentETag(appt.UpdatedAt),
+			CompetingRequests: appt.CompetingRequests,
 		}
 		appointment.Client = &ProfessionalAppointmentClient{
-			ID:          appt.ClientID.UUID.String(),
-			FirstName:   appt.ClientFirstName.String,
-			LastName:    appt.ClientLastName.String,
-			PhoneNumber: &appt.ClientPhoneNumber.String,
+			ID:                appt.ClientID.UUID.String(),
+			FirstName:         appt.ClientFirstName.String,
+			LastName:          appt.ClientLastName.String,
+			PhoneNumber:       &appt.ClientPhoneNumber.String,
+			LateCancellations: &appt.ClientLateCancellations,
 		}
 		responseAppointments[i] = appointment
 	}
@@ -104,6 +110,25 @@ func mapAppointmentsToGetProfessionalAppointmentsResponse(appointments []*db.Get
 	return response
 }
 
+// mapBulkResultsToResponse maps the results of a bulk operation to a BulkAppointmentsResponse,
+// with the errors of skipped appointments translated to the locale of the request
+func mapBulkResultsToResponse(c *gin.Context, results []*professionals.BulkResult) BulkAppointmentsResponse {
+	response := BulkAppointmentsResponse{Results: make([]BulkAppointmentResult, len(results))}
+	for i, result := range results {
+		item := BulkAppointmentResult{AppointmentID: result.AppointmentID.String()}
+		if result.Err != nil {
+			item.Error = common.NewItemError(c, result.Err)
+			response.Failed++
+		} else {
+			item.Status = string(result.Status)
+			item.ETag = common.AppointmentETag(result.UpdatedAt)
+			response.Succeeded++
+		}
+		response.Results[i] = item
+	}
+	return response
+}
+
 func mapAppointmentToCancelAppointmentResponse(appointment *db.CancelAppointmentByProfessionalWithDetailsRow) CancelAppointmentResponse {
 	return CancelAppointmentResponse{
 		Appointment: CancelledAppointment{
@@ -174,3 +199,42 @@ func mapTimetableAppointmentsToGetProfessionalTimetableResponse(appointments []*
 
 	return response
 }
+
+// mapAppointmentToExportRow maps an appointment to an export row in the application timezone
+func mapAppointmentToExportRow(appointment *db.GetProfessionalAppointmentsForExportRow, loc *time.Location) []any {
+	start := appointment.StartTime.In(loc)
+	end := appointment.EndTime.In(loc)
+
+	return []any{
+		common.FormatDate(start),
+		start.Format("15:04"),
+		end.Format("15:04"),
+		int(end.Sub(start).Minutes()),
+		appointment.ClientFirstName.String,
+		appointment.ClientLastName.String,
+		appointment.ClientPhoneNumber.String,
+		string(appointment.Status.AppointmentStatus),
+		appointment.Description.String,
+		appointment.CancellationReason.String,
+	}
+}
+
+// mapCancellationPolicyToResponse maps a cancellation policy to a CancellationPolicyResponse
+func mapCancellationPolicyToResponse(policy *db.CancellationPolicy) CancellationPolicyResponse {
+	return CancellationPolicyResponse{
+		MinNoticeHours:        policy.MinNoticeHours,
+		AllowLateCancellation: policy.AllowLateCancellation,
+	}
+}
+
+// mapBookingRuleToResponse maps a booking rule to a BookingRuleResponse
+func mapBookingRuleToResponse(rule *db.BookingRule) BookingRuleResponse {
+	return BookingRuleResponse{
+		MinNoticeHours:         rule.MinNoticeHours,
+		MaxAdvanceDays:         common.FromNullInt32(rule.MaxAdvanceDays),
+		MaxDailyPerClient:      common.FromNullInt32(rule.MaxDailyPerClient),
+		MaxPendingPerClient:    common.FromNullInt32(rule.MaxPendingPerClient),
+		AutoConfirm:            string(rule.AutoConfirm),
+		AllowTentativeRequests: rule.AllowTentativeRequests,
+	}
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"net/http"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// ProfessionalsOperations describes the routes registered by ProfessionalsRegister for the OpenAPI document
+func ProfessionalsOperations() []openapi.Operation {
+	tags := []string{"professionals"}
+	ifMatchErrors := []openapi.Response{
+		{Status: http.StatusForbidden},
+		{Status: http.StatusNotFound, Description: "Appointment not found"},
+		{Status: http.StatusPreconditionFailed, Description: "The appointment was modified since the If-Match version"},
+		{Status: http.StatusPreconditionRequired, Description: "If-Match is required"},
+	}
+
+	return []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals",
+			Summary: "List professionals",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetProfessionalsResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/professionals/sign_in",
+			Summary: "Sign in a professional and link their chat",
+			Tags:    tags,
+			Request: ProfessionalSignInRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: ProfessionalSignInResponse{}},
+				{Status: http.StatusUnauthorized, Description: "Invalid username or password"},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/professionals/:id/appointments",
+			Summary:     "List the appointments of a professional",
+			Description: "Each appointment carries the number of other pending requests overlapping it as competing_requests.",
+			Tags:        tags,
+			Params: []openapi.Param{
+				common.AppointmentStatusParam,
+				{Name: "date", In: openapi.InQuery, Format: "date"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetProfessionalAppointmentsResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals/:id/appointments/export",
+			Summary: "Export the appointments of a professional as a spreadsheet",
+			Tags:    tags,
+			Params: append([]openapi.Param{
+				{Name: "format", In: openapi.InQuery, Enum: []string{exportFormatCSV, exportFormatXLSX}, Default: exportFormatCSV},
+			}, common.DateRangeParams...),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, ContentTypes: []string{"text/csv", exportContentTypes[exportFormatXLSX]}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals/:id/appointment_dates",
+			Summary: "List the dates with appointments in a month",
+			Tags:    tags,
+			Params: []openapi.Param{
+				{Name: "month", In: openapi.InQuery, Description: "YYYY-MM, defaults to the current month"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetProfessionalAppointmentDatesResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/professionals/:id/appointments/:appointment_id/confirm",
+			Summary: "Confirm a pending appointment",
+			Tags:    tags,
+			Params:  []openapi.Param{common.IdempotencyKeyParam, common.IfMatchParam},
+			Responses: append([]openapi.Response{
+				{Status: http.StatusOK, Body: ConfirmAppointmentResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+				{Status: http.StatusConflict, Description: "The slot overlaps a confirmed appointment"},
+			}, ifMatchErrors...),
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/professionals/:id/appointments/:appointment_id/cancel",
+			Summary: "Cancel an appointment as the professional",
+			Tags:    tags,
+			Params:  []openapi.Param{common.IdempotencyKeyParam, common.IfMatchParam},
+			Request: CancelAppointmentRequest{},
+			Responses: append([]openapi.Response{
+				{Status: http.StatusOK, Body: CancelAppointmentResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+			}, ifMatchErrors...),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/professionals/:id/appointments/bulk_confirm",
+			Summary:     "Confirm several appointments at once",
+			Description: "Confirms up to 100 appointments listed in appointment_ids, or all upcoming pending appointments on date, in one transaction. Appointments that are not found, belong to another professional or are not pending are skipped with an error in their result. Versions are not checked.",
+			Tags:        tags,
+			Params:      []openapi.Param{common.IdempotencyKeyParam},
+			Request:     BulkConfirmAppointmentsRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: BulkAppointmentsResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/professionals/:id/appointments/bulk_cancel",
+			Summary:     "Cancel several appointments at once",
+			Description: "Cancels up to 100 appointments listed in appointment_ids, or all upcoming pending and confirmed appointments on date (only those with status when given), in one transaction with a shared cancellation_reason. Appointments that are not found, belong to another professional or are no longer active are skipped with an error in their result. Versions are not checked.",
+			Tags:        tags,
+			Params:      []openapi.Param{common.IdempotencyKeyParam},
+			Request:     BulkCancelAppointmentsRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: BulkAppointmentsResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/professionals/:id/unavailable_appointments",
+			Summary: "Block a time range as unavailable",
+			Tags:    tags,
+			Params:  []openapi.Param{common.IdempotencyKeyParam},
+			Request: CreateUnavailableAppointmentRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: CreateUnavailableAppointmentResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+				{Status: http.StatusNotFound, Description: "Professional not found"},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/professionals/:id/availability",
+			Summary:     "Get the hourly availability of a professional on a date",
+			Description: "Slots with a pending request are tentative: available unless the professional's booking rules make pending requests block them.",
+			Tags:        tags,
+			Params: []openapi.Param{
+				{Name: "date", In: openapi.InQuery, Required: true, Format: "date"},
+				{Name: "client_id", In: openapi.InQuery, Format: "uuid", Description: "Client viewing the availability, slots they hold are shown as available"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetProfessionalAvailabilityResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals/:id/timetable",
+			Summary: "Get the timetable of a professional on a date",
+			Tags:    tags,
+			Params: []openapi.Param{
+				{Name: "date", In: openapi.InQuery, Required: true, Format: "date"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetProfessionalTimetableResponse{}},
+			},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/professionals/:id/events",
+			Summary:   "Stream appointment events of a professional",
+			Tags:      tags,
+			Params:    common.EventStreamParams,
+			Responses: []openapi.Response{common.EventStreamResponse},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/professionals/:id/cancellation_policy",
+			Summary:     "Get the cancellation policy of a professional",
+			Description: "Professionals who have not set a policy get the default one: no minimum notice, late cancellations allowed.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: CancellationPolicyResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/professionals/:id/cancellation_policy",
+			Summary:     "Set the cancellation policy of a professional",
+			Description: "Client cancellations of confirmed appointments with less notice than min_notice_hours are flagged as late, or rejected when allow_late_cancellation is false.",
+			Tags:        tags,
+			Request:     UpdateCancellationPolicyRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: CancellationPolicyResponse{}},
+				{Status: http.StatusNotFound, Description: "Professional not found"},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/professionals/:id/booking_rules",
+			Summary:     "Get the booking rules of a professional",
+			Description: "Professionals who have not set rules get the default ones: no minimum notice, no horizon, no limits per client and no auto-confirmation.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: BookingRuleResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/professionals/:id/booking_rules",
+			Summary:     "Set the booking rules of a professional",
+			Description: "Appointments must start at least min_notice_hours and at most max_advance_days ahead. A client may have up to max_daily_per_client appointments on one day and max_pending_per_client unconfirmed requests with the professional. Availability marks slots outside the booking window as outside_booking_window. With auto_confirm set to all, every booking is confirmed on creation; with returning_clients, only bookings of clients with a past confirmed or completed appointment with the professional are. Availability marks slots with a pending request as tentative. They may still be requested while allow_tentative_requests is true and auto-confirmation is off; otherwise they are unavailable and bookings overlapping any appointment are rejected with 409 slot_taken.",
+			Tags:        tags,
+			Request:     UpdateBookingRuleRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: BookingRuleResponse{}},
+				{Status: http.StatusNotFound, Description: "Professional not found"},
+			},
+		},
+	}
+}
@@ -1,5 +1,7 @@
 package api
 
+import common "github.com/vention/booking_api/internal/api/common"
+
 // ProfessionalSignInRequest represents the request body for professional sign in
 type ProfessionalSignInRequest struct {
 	Username string `json:"username" binding:"required"`
@@ -72,24 +74,27 @@ type GetProfessionalAppointmentsResponse struct {
 
 // ProfessionalAppointment represents an appointment with client details in professional context
 type ProfessionalAppointment struct {
-	ID          string                         `json:"id"`
-	Type        string                         `json:"type"`
-	StartTime   string                         `json:"start_time"`
-	EndTime     string                         `json:"end_time"`
-	Status      string                         `json:"status"`
-	Description string                         `json:"description,omitempty"`
-	CreatedAt   string                         `json:"created_at"`
-	UpdatedAt   string                         `json:"updated_at"`
-	Client      *ProfessionalAppointmentClient `json:"client,omitempty"`
+	ID                string                         `json:"id"`
+	Type              string                         `json:"type"`
+	StartTime         string                         `json:"start_time"`
+	EndTime           string                         `json:"end_time"`
+	Status            string                         `json:"status"`
+	Description       string                         `json:"description,omitempty"`
+	CreatedAt         string                         `json:"created_at"`
+	UpdatedAt         string                         `json:"updated_at"`
+	ETag              string                         `json:"etag"` // Send as If-Match when confirming or cancelling
+	Client            *ProfessionalAppointmentClient `json:"client,omitempty"`
+	CompetingRequests int64                          `json:"competing_requests"` // Other pending requests overlapping the appointment
 }
 
 // ProfessionalAppointmentClient represents client details in appointment context
 type ProfessionalAppointmentClient struct {
-	ID          string  `json:"id"`
-	FirstName   string  `json:"first_name"`
-	LastName    string  `json:"last_name"`
-	PhoneNumber *string `json:"phone_number,omitempty"`
-	ChatID      *int64  `json:"chat_id,omitempty"`
+	ID                string  `json:"id"`
+	FirstName         string  `json:"first_name"`
+	LastName          string  `json:"last_name"`
+	PhoneNumber       *string `json:"phone_number,omitempty"`
+	ChatID            *int64  `json:"chat_id,omitempty"`
+	LateCancellations *int64  `json:"late_cancellations,omitempty"` // Appointments the client cancelled with less notice than required
 }
 
 // CancelAppointmentRequest represents the request to cancel an appointment
@@ -118,6 +123,37 @@ type CancelledAppointment struct {
 	UpdatedAt          string `json:"updated_at"`
 }
 
+// BulkConfirmAppointmentsRequest represents the request to confirm several appointments at once,
+// listed by ID or all upcoming pending ones on a date
+type BulkConfirmAppointmentsRequest struct {
+	AppointmentIDs []string `json:"appointment_ids,omitempty" binding:"required_without=Date,excluded_with=Date,max=100,dive,uuid"`
+	Date           string   `json:"date,omitempty"` // YYYY-MM-DD
+}
+
+// BulkCancelAppointmentsRequest represents the request to cancel several appointments at once with
+// a shared reason, listed by ID or all upcoming ones on a date
+type BulkCancelAppointmentsRequest struct {
+	AppointmentIDs     []string `json:"appointment_ids,omitempty" binding:"required_without=Date,excluded_with=Date,max=100,dive,uuid"`
+	Date               string   `json:"date,omitempty"`                                                                     // YYYY-MM-DD
+	Status             string   `json:"status,omitempty" binding:"omitempty,excluded_without=Date,oneof=pending confirmed"` // Restricts the date selection, both when omitted
+	CancellationReason string   `json:"cancellation_reason" binding:"required"`
+}
+
+// BulkAppointmentsResponse represents the outcome of a bulk confirmation or cancellation
+type BulkAppointmentsResponse struct {
+	Results   []BulkAppointmentResult `json:"results"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+}
+
+// BulkAppointmentResult represents the outcome of a bulk operation for one appointment
+type BulkAppointmentResult struct {
+	AppointmentID string            `json:"appointment_id"`
+	Status        string            `json:"status,omitempty"` // Status after the operation when it succeeded
+	ETag          string            `json:"etag,omitempty"`
+	Error         *common.ItemError `json:"error,omitempty"` // Why the appointment was skipped
+}
+
 // ProfessionalInfo represents professional details in appointment context
 type ProfessionalInfo struct {
 	ID          string  `json:"id"`
@@ -163,7 +199,7 @@ type TimeSlot struct {
 	StartTime   string `json:"start_time"`
 	EndTime     string `json:"end_time"`
 	Available   bool   `json:"available"`
-	Type        string `json:"type,omitempty"`        // "appointment", "unavailable", or empty if available
+	Type        string `json:"type,omitempty"`        // "appointment", "unavailable", "external_busy", "waitlist_offer", "held", "tentative", "outside_booking_window", or empty if free
 	Description string `json:"description,omitempty"` // Description with client info if available
 }
 
@@ -186,3 +222,37 @@ type GetProfessionalTimetableResponse struct {
 	Date         string                 `json:"date"`
 	Appointments []TimetableAppointment `json:"appointments"`
 }
+
+// CancellationPolicyResponse represents the cancellation policy of a professional
+type CancellationPolicyResponse struct {
+	MinNoticeHours        int32 `json:"min_notice_hours"`        // Hours before the start a client may cancel without it counting as late
+	AllowLateCancellation bool  `json:"allow_late_cancellation"` // Whether clients may cancel with less notice at all
+}
+
+// UpdateCancellationPolicyRequest represents the request to set the cancellation policy of a professional
+type UpdateCancellationPolicyRequest struct {
+	MinNoticeHours        *int32 `json:"min_notice_hours" binding:"required,min=0,max=720"`
+	AllowLateCancellation *bool  `json:"allow_late_cancellation" binding:"required"`
+}
+
+// BookingRuleResponse represents the booking rules of a professional. Limits are null when not enforced.
+type BookingRuleResponse struct {
+	MinNoticeHours         int32  `json:"min_notice_hours"`         // Hours ahead of the start an appointment must be booked
+	MaxAdvanceDays         *int32 `json:"max_advance_days"`         // Days ahead an appointment may be booked
+	MaxDailyPerClient      *int32 `json:"max_daily_per_client"`     // Appointments a client may have on one day
+	MaxPendingPerClient    *int32 `json:"max_pending_per_client"`   // Unconfirmed requests a client may have
+	AutoConfirm            string `json:"auto_confirm"`             // Bookings confirmed without the professional: off, all or returning_clients
+	AllowTentativeRequests bool   `json:"allow_tentative_requests"` // Whether slots with a pending request may still be requested
+}
+
+// UpdateBookingRuleRequest represents the request to set the booking rules of a professional.
+// Omitted or null limits are not enforced, an omitted auto_confirm turns auto-confirmation off and
+// an omitted allow_tentative_requests allows them.
+type UpdateBookingRuleRequest struct {
+	MinNoticeHours         *int32 `json:"min_notice_hours" binding:"required,min=0,max=720"`
+	MaxAdvanceDays         *int32 `json:"max_advance_days" binding:"omitempty,min=1,max=365"`
+	MaxDailyPerClient      *int32 `json:"max_daily_per_client" binding:"omitempty,min=1"`
+	MaxPendingPerClient    *int32 `json:"max_pending_per_client" binding:"omitempty,min=1"`
+	AutoConfirm            string `json:"auto_confirm,omitempty" binding:"omitempty,oneof=off all returning_clients"`
+	AllowTentativeRequests *bool  `json:"allow_tentative_requests,omitempty"`
+}
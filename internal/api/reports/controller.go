@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/reports"
+)
+
+// defaultReportLimit is the number of entries of ranking reports without a limit parameter
+const defaultReportLimit = 10
+
+// GetBookingTotals handles GET /api/admins/reports/bookings
+func (h *ReportsHandler) GetBookingTotals(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+
+	reportRange, ok := parseReportRange(c)
+	if !ok {
+		return
+	}
+
+	input := reports.BookingTotalsInput{
+		ReportRange: reportRange,
+		Granularity: c.DefaultQuery("granularity", reports.GranularityDay),
+	}
+	report, err := h.reportsService.GetBookingTotals(c.Request.Context(), input)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	h.writeReport(c, "bookings", format, mapBookingTotalsToResponse(input, report))
+}
+
+// GetConfirmationLatency handles GET /api/admins/reports/confirmation_latency
+func (h *ReportsHandler) GetConfirmationLatency(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+
+	reportRange, ok := parseReportRange(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportsService.GetConfirmationLatency(c.Request.Context(), reportRange)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	h.writeReport(c, "confirmation_latency", format, mapConfirmationLatencyToResponse(reportRange, report))
+}
+
+// GetTopProfessionals handles GET /api/admins/reports/top_professionals
+func (h *ReportsHandler) GetTopProfessionals(c *gin.Context) {
+	input, format, ok := parseRankingRequest(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportsService.GetTopProfessionals(c.Request.Context(), input)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	h.writeReport(c, "top_professionals", format, mapTopProfessionalsToResponse(input, report))
+}
+
+// GetClientGrowth handles GET /api/admins/reports/client_growth
+func (h *ReportsHandler) GetClientGrowth(c *gin.Context) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return
+	}
+
+	reportRange, ok := parseReportRange(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportsService.GetClientGrowth(c.Request.Context(), reportRange)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	h.writeReport(c, "client_growth", format, mapClientGrowthToResponse(reportRange, report))
+}
+
+// GetCancellationReasons handles GET /api/admins/reports/cancellation_reasons
+func (h *ReportsHandler) GetCancellationReasons(c *gin.Context) {
+	input, format, ok := parseRankingRequest(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportsService.GetCancellationReasons(c.Request.Context(), input)
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	h.writeReport(c, "cancellation_reasons", format, mapCancellationReasonsToResponse(input, report))
+}
+
+// parseReportFormat parses the optional format query parameter
+func parseReportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", reportFormatJSON)
+	if format != reportFormatJSON && format != reportFormatCSV {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidReportFormat, common.ErrorMsgInvalidReportFormat, nil)
+		return "", false
+	}
+	return format, true
+}
+
+// parseReportRange parses the required from and to dates, both inclusive
+func parseReportRange(c *gin.Context) (reports.ReportRange, bool) {
+	fromStr, ok := common.RequireQueryParam(c, "from")
+	if !ok {
+		return reports.ReportRange{}, false
+	}
+
+	toStr, ok := common.RequireQueryParam(c, "to")
+	if !ok {
+		return reports.ReportRange{}, false
+	}
+
+	from, ok := common.ParseDate(c, fromStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return reports.ReportRange{}, false
+	}
+
+	to, ok := common.ParseDate(c, toStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return reports.ReportRange{}, false
+	}
+
+	if to.Before(from) {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidDateRange, common.ErrorMsgInvalidDateRange, nil)
+		return reports.ReportRange{}, false
+	}
+
+	return reports.ReportRange{From: from, To: to}, true
+}
+
+// parseRankingRequest parses the format, date range and optional limit of ranking reports
+func parseRankingRequest(c *gin.Context) (reports.RankingInput, string, bool) {
+	format, ok := parseReportFormat(c)
+	if !ok {
+		return reports.RankingInput{}, "", false
+	}
+
+	reportRange, ok := parseReportRange(c)
+	if !ok {
+		return reports.RankingInput{}, "", false
+	}
+
+	limit := defaultReportLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidLimit, common.ErrorMsgInvalidLimit, err)
+			return reports.RankingInput{}, "", false
+		}
+		limit = parsed
+	}
+
+	return reports.RankingInput{ReportRange: reportRange, Limit: limit}, format, true
+}
+
+// writeReport writes the report as JSON or as a CSV download, with caching headers
+// matching the server-side cache
+func (h *ReportsHandler) writeReport(c *gin.Context, name, format string, response csvReport) {
+	if h.cacheTTL > 0 {
+		c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.cacheTTL.Seconds())))
+	} else {
+		c.Header("Cache-Control", "no-cache")
+	}
+
+	if format == reportFormatJSON {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	from, to := c.Query("from"), c.Query("to")
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s_%s_%s.csv", name, from, to)))
+	c.Status(http.StatusOK)
+
+	if err := writeCSVReport(csv.NewWriter(c.Writer), response); err != nil {
+		logger := common.GetLogger(c)
+		logger.Error().Err(err).Str("report", name).Msg("Failed to write CSV report")
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/csv"
+	"strconv"
+
+	common "github.com/vention/booking_api/internal/api/common"
+)
+
+// Report formats
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
+// csvReport is implemented by report responses that can be downloaded as CSV
+type csvReport interface {
+	csvHeader() []string
+	csvRows() [][]string
+}
+
+// writeCSVReport writes the header and all rows of a report
+func writeCSVReport(w *csv.Writer, report csvReport) error {
+	if err := w.Write(report.csvHeader()); err != nil {
+		return err
+	}
+	if err := w.WriteAll(report.csvRows()); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (r BookingTotalsResponse) csvHeader() []string {
+	return []string{"start", "total", "pending", "confirmed", "cancelled", "completed"}
+}
+
+func (r BookingTotalsResponse) csvRows() [][]string {
+	rows := make([][]string, 0, len(r.Buckets))
+	for _, bucket := range r.Buckets {
+		rows = append(rows, []string{
+			bucket.Start,
+			strconv.Itoa(bucket.Total),
+			strconv.Itoa(bucket.Pending),
+			strconv.Itoa(bucket.Confirmed),
+			strconv.Itoa(bucket.Cancelled),
+			strconv.Itoa(bucket.Completed),
+		})
+	}
+	return rows
+}
+
+func (r ConfirmationLatencyResponse) csvHeader() []string {
+	return []string{"confirmed_count", "avg_minutes", "median_minutes", "p90_minutes", "max_minutes"}
+}
+
+func (r ConfirmationLatencyResponse) csvRows() [][]string {
+	return [][]string{{
+		strconv.Itoa(r.ConfirmedCount),
+		formatFloat(r.AvgMinutes),
+		formatFloat(r.MedianMinutes),
+		formatFloat(r.P90Minutes),
+		formatFloat(r.MaxMinutes),
+	}}
+}
+
+func (r TopProfessionalsResponse) csvHeader() []string {
+	return []string{"rank", "id", "first_name", "last_name", "appointment_count", "booked_hours"}
+}
+
+func (r TopProfessionalsResponse) csvRows() [][]string {
+	rows := make([][]string, 0, len(r.Professionals))
+	for i, professional := range r.Professionals {
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			professional.ID,
+			professional.FirstName,
+			professional.LastName,
+			strconv.Itoa(professional.AppointmentCount),
+			formatFloat(professional.BookedHours),
+		})
+	}
+	return rows
+}
+
+func (r ClientGrowthResponse) csvHeader() []string {
+	return []string{"month", "new_clients", "total_clients"}
+}
+
+func (r ClientGrowthResponse) csvRows() [][]string {
+	rows := make([][]string, 0, len(r.Months))
+	for _, month := range r.Months {
+		rows = append(rows, []string{
+			month.Month,
+			strconv.Itoa(month.NewClients),
+			strconv.Itoa(month.TotalClients),
+		})
+	}
+	return rows
+}
+
+func (r CancellationReasonsResponse) csvHeader() []string {
+	return []string{"reason", "cancellation_count", "by_client_count", "by_professional_count"}
+}
+
+func (r CancellationReasonsResponse) csvRows() [][]string {
+	rows := make([][]string, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		rows = append(rows, []string{
+			common.StringValue(reason.Reason),
+			strconv.Itoa(reason.CancellationCount),
+			strconv.Itoa(reason.ByClientCount),
+			strconv.Itoa(reason.ByProfessionalCount),
+		})
+	}
+	return rows
+}
+
+// formatFloat formats a float without trailing zeros
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
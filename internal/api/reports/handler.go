@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/reports"
+)
+
+// ReportsHandler handles HTTP requests for admin reports
+type ReportsHandler struct {
+	reportsService reports.Service
+	cacheTTL       time.Duration
+}
+
+// NewReportsHandler creates a new handler with dependency injection
+func NewReportsHandler(service reports.Service, cacheTTL time.Duration) *ReportsHandler {
+	return &ReportsHandler{
+		reportsService: service,
+		cacheTTL:       cacheTTL,
+	}
+}
+
+// ReportsHandlerParams defines the parameters for the ReportsHandler
+type ReportsHandlerParams struct {
+	Router         *gin.RouterGroup
+	ReportsService reports.Service
+	CacheTTL       time.Duration // Advertised to clients with Cache-Control
+}
+
+// ReportsRegister registers the ReportsHandler with the router
+func ReportsRegister(p ReportsHandlerParams) error {
+	if p.Router == nil {
+		return errors.New("missing router")
+	}
+
+	if p.ReportsService == nil {
+		return errors.New("missing reports service")
+	}
+
+	h := NewReportsHandler(p.ReportsService, p.CacheTTL)
+
+	reports := p.Router.Group("/admins/reports")
+	{
+		reports.GET("/bookings", h.GetBookingTotals)
+		reports.GET("/confirmation_latency", h.GetConfirmationLatency)
+		reports.GET("/top_professionals", h.GetTopProfessionals)
+		reports.GET("/client_growth", h.GetClientGrowth)
+		reports.GET("/cancellation_reasons", h.GetCancellationReasons)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"math"
+	"time"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/reports"
+)
+
+// mapReportMeta builds the common report fields
+func mapReportMeta(r reports.ReportRange, generatedAt time.Time) ReportMeta {
+	return ReportMeta{
+		From:        common.FormatDate(r.From),
+		To:          common.FormatDate(r.To),
+		GeneratedAt: common.FormatTimeRFC3339(generatedAt),
+	}
+}
+
+// mapBookingCounts maps per-status counts to BookingCounts
+func mapBookingCounts(counts reports.BookingCounts) BookingCounts {
+	return BookingCounts{
+		Total:     counts.Total,
+		Pending:   counts.Pending,
+		Confirmed: counts.Confirmed,
+		Cancelled: counts.Cancelled,
+		Completed: counts.Completed,
+	}
+}
+
+// mapBookingTotalsToResponse maps the booking totals report to a BookingTotalsResponse
+func mapBookingTotalsToResponse(input reports.BookingTotalsInput, report *reports.BookingTotals) BookingTotalsResponse {
+	response := BookingTotalsResponse{
+		ReportMeta:  mapReportMeta(input.ReportRange, report.GeneratedAt),
+		Granularity: input.Granularity,
+		Total:       mapBookingCounts(report.Total),
+		Buckets:     make([]BookingBucket, len(report.Buckets)),
+	}
+	for i, bucket := range report.Buckets {
+		response.Buckets[i] = BookingBucket{
+			Start:         common.FormatDate(bucket.Start),
+			BookingCounts: mapBookingCounts(bucket.BookingCounts),
+		}
+	}
+	return response
+}
+
+// mapConfirmationLatencyToResponse maps the confirmation latency report to a ConfirmationLatencyResponse
+func mapConfirmationLatencyToResponse(input reports.ReportRange, report *reports.ConfirmationLatency) ConfirmationLatencyResponse {
+	return ConfirmationLatencyResponse{
+		ReportMeta:     mapReportMeta(input, report.GeneratedAt),
+		ConfirmedCount: report.ConfirmedCount,
+		AvgMinutes:     round(report.AvgMinutes),
+		MedianMinutes:  round(report.MedianMinutes),
+		P90Minutes:     round(report.P90Minutes),
+		MaxMinutes:     round(report.MaxMinutes),
+	}
+}
+
+// mapTopProfessionalsToResponse maps the top professionals report to a TopProfessionalsResponse
+func mapTopProfessionalsToResponse(input reports.RankingInput, report *reports.TopProfessionals) TopProfessionalsResponse {
+	response := TopProfessionalsResponse{
+		ReportMeta:    mapReportMeta(input.ReportRange, report.GeneratedAt),
+		Professionals: make([]TopProfessional, len(report.Professionals)),
+	}
+	for i, professional := range report.Professionals {
+		response.Professionals[i] = TopProfessional{
+			ID:               professional.ID.String(),
+			FirstName:        professional.FirstName,
+			LastName:         professional.LastName,
+			AppointmentCount: professional.AppointmentCount,
+			BookedHours:      round(float64(professional.BookedMinutes) / 60),
+		}
+	}
+	return response
+}
+
+// mapClientGrowthToResponse maps the client growth report to a ClientGrowthResponse
+func mapClientGrowthToResponse(input reports.ReportRange, report *reports.ClientGrowth) ClientGrowthResponse {
+	response := ClientGrowthResponse{
+		ReportMeta: mapReportMeta(input, report.GeneratedAt),
+		Months:     make([]ClientGrowthMonth, len(report.Months)),
+	}
+	for i, month := range report.Months {
+		response.Months[i] = ClientGrowthMonth{
+			Month:        common.FormatMonth(month.Month),
+			NewClients:   month.NewClients,
+			TotalClients: month.TotalClients,
+		}
+	}
+	return response
+}
+
+// mapCancellationReasonsToResponse maps the cancellation reasons report to a CancellationReasonsResponse
+func mapCancellationReasonsToResponse(input reports.RankingInput, report *reports.CancellationReasons) CancellationReasonsResponse {
+	response := CancellationReasonsResponse{
+		ReportMeta: mapReportMeta(input.ReportRange, report.GeneratedAt),
+		Reasons:    make([]CancellationReason, len(report.Reasons)),
+	}
+	for i, reason := range report.Reasons {
+		response.Reasons[i] = CancellationReason{
+			CancellationCount:   reason.CancellationCount,
+			ByClientCount:       reason.ByClientCount,
+			ByProfessionalCount: reason.ByProfessionalCount,
+		}
+		if reason.Reason != "" {
+			response.Reasons[i].Reason = common.StringPtr(reason.Reason)
+		}
+	}
+	return response
+}
+
+// round rounds to two decimals
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
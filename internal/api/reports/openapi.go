@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+	"github.com/vention/booking_api/internal/services/reports"
+)
+
+// ReportsOperations describes the routes registered by ReportsRegister for the OpenAPI document
+func ReportsOperations() []openapi.Operation {
+	tags := []string{"reports"}
+	params := append([]openapi.Param{
+		{Name: "format", In: openapi.InQuery, Enum: []string{reportFormatJSON, reportFormatCSV}, Default: reportFormatJSON},
+	}, common.DateRangeParams...)
+	rankingParams := slices.Concat(params, []openapi.Param{
+		{Name: "limit", In: openapi.InQuery, Type: "integer", Default: strconv.Itoa(defaultReportLimit)},
+	})
+	report := func(body any) []openapi.Response {
+		return []openapi.Response{
+			{Status: http.StatusOK, Body: body, ContentTypes: []string{"text/csv"}},
+		}
+	}
+
+	return []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/admins/reports/bookings",
+			Summary: "Report booking totals per day, week or month",
+			Tags:    tags,
+			Params: slices.Concat(params, []openapi.Param{
+				{Name: "granularity", In: openapi.InQuery, Enum: []string{reports.GranularityDay, reports.GranularityWeek, reports.GranularityMonth}, Default: reports.GranularityDay},
+			}),
+			Responses: report(BookingTotalsResponse{}),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/admins/reports/confirmation_latency",
+			Summary:   "Report the time from booking to confirmation",
+			Tags:      tags,
+			Params:    params,
+			Responses: report(ConfirmationLatencyResponse{}),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/admins/reports/top_professionals",
+			Summary:   "Rank professionals by booked hours",
+			Tags:      tags,
+			Params:    rankingParams,
+			Responses: report(TopProfessionalsResponse{}),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/admins/reports/client_growth",
+			Summary:   "Report new and total clients per month",
+			Tags:      tags,
+			Params:    params,
+			Responses: report(ClientGrowthResponse{}),
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/admins/reports/cancellation_reasons",
+			Summary:   "Rank cancellation reasons",
+			Tags:      tags,
+			Params:    rankingParams,
+			Responses: report(CancellationReasonsResponse{}),
+		},
+	}
+}
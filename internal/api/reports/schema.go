@@ -0,0 +1,84 @@
+package api
+
+// ReportMeta describes the range of a report and when it was computed.
+// Reports are cached, so generated_at may lag behind the latest changes.
+type ReportMeta struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	GeneratedAt string `json:"generated_at"`
+}
+
+// BookingCounts represents appointment counts per status
+type BookingCounts struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Confirmed int `json:"confirmed"`
+	Cancelled int `json:"cancelled"`
+	Completed int `json:"completed"`
+}
+
+// BookingBucket represents the bookings of one day, week or month
+type BookingBucket struct {
+	Start string `json:"start"` // YYYY-MM-DD, weeks start on Monday
+	BookingCounts
+}
+
+// BookingTotalsResponse represents the booking totals report
+type BookingTotalsResponse struct {
+	ReportMeta
+	Granularity string          `json:"granularity"`
+	Total       BookingCounts   `json:"total"`
+	Buckets     []BookingBucket `json:"buckets"` // Buckets without bookings are omitted
+}
+
+// ConfirmationLatencyResponse represents the time from booking to confirmation
+type ConfirmationLatencyResponse struct {
+	ReportMeta
+	ConfirmedCount int     `json:"confirmed_count"`
+	AvgMinutes     float64 `json:"avg_minutes"`
+	MedianMinutes  float64 `json:"median_minutes"`
+	P90Minutes     float64 `json:"p90_minutes"`
+	MaxMinutes     float64 `json:"max_minutes"`
+}
+
+// TopProfessional represents a professional ranked by booked hours
+type TopProfessional struct {
+	ID               string  `json:"id"`
+	FirstName        string  `json:"first_name"`
+	LastName         string  `json:"last_name"`
+	AppointmentCount int     `json:"appointment_count"`
+	BookedHours      float64 `json:"booked_hours"`
+}
+
+// TopProfessionalsResponse represents the top professionals report
+type TopProfessionalsResponse struct {
+	ReportMeta
+	Professionals []TopProfessional `json:"professionals"`
+}
+
+// ClientGrowthMonth represents the clients registered in a month
+type ClientGrowthMonth struct {
+	Month        string `json:"month"` // YYYY-MM
+	NewClients   int    `json:"new_clients"`
+	TotalClients int    `json:"total_clients"`
+}
+
+// ClientGrowthResponse represents the client growth report
+type ClientGrowthResponse struct {
+	ReportMeta
+	Months []ClientGrowthMonth `json:"months"`
+}
+
+// CancellationReason represents how often a cancellation reason was given
+type CancellationReason struct {
+	Reason              *string `json:"reason"` // null when no reason was given
+	CancellationCount   int     `json:"cancellation_count"`
+	ByClientCount       int     `json:"by_client_count"`
+	ByProfessionalCount int     `json:"by_professional_count"`
+}
+
+// CancellationReasonsResponse represents the cancellation reasons report
+type CancellationReasonsResponse struct {
+	ReportMeta
+	Reasons []CancellationReason `json:"reasons"`
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/stats"
+)
+
+// GetProfessionalStats handles GET /api/professionals/:id/stats
+func (h *StatsHandler) GetProfessionalStats(c *gin.Context) {
+	professionalID, ok := common.ParseProfessionalID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	fromStr, ok := common.RequireQueryParam(c, "from")
+	if !ok {
+		return
+	}
+
+	toStr, ok := common.RequireQueryParam(c, "to")
+	if !ok {
+		return
+	}
+
+	from, ok := common.ParseDate(c, fromStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return
+	}
+
+	to, ok := common.ParseDate(c, toStr, common.ErrorCodeInvalidDate, common.ErrorMsgInvalidDate)
+	if !ok {
+		return
+	}
+
+	if to.Before(from) {
+		common.HandleErrorResponse(c, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidDateRange, common.ErrorMsgInvalidDateRange, nil)
+		return
+	}
+
+	result, err := h.statsService.GetProfessionalStats(c.Request.Context(), stats.ProfessionalStatsInput{
+		ProfessionalID: professionalID,
+		From:           from,
+		To:             to,
+		Granularity:    c.DefaultQuery("granularity", stats.GranularityDay),
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapProfessionalStatsToResponse(result))
+}
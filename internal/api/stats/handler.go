@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/stats"
+)
+
+// StatsHandler handles HTTP requests for statistics
+type StatsHandler struct {
+	statsService stats.Service
+}
+
+// NewStatsHandler creates a new handler with dependency injection
+func NewStatsHandler(service stats.Service) *StatsHandler {
+	return &StatsHandler{
+		statsService: service,
+	}
+}
+
+// StatsHandlerParams defines the parameters for the StatsHandler
+type StatsHandlerParams struct {
+	Router       *gin.RouterGroup
+	StatsService stats.Service
+}
+
+// StatsRegister registers the StatsHandler with the router
+func StatsRegister(p StatsHandlerParams) error {
+	if p.Router == nil {
+		return errors.New("missing router")
+	}
+
+	if p.StatsService == nil {
+		return errors.New("missing stats service")
+	}
+
+	h := NewStatsHandler(p.StatsService)
+
+	p.Router.GET("/professionals/:id/stats", h.GetProfessionalStats)
+
+	return nil
+}
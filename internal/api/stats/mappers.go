@@ -0,0 +1,77 @@
+package api
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/stats"
+)
+
+// mapProfessionalStatsToResponse maps professional statistics to a ProfessionalStatsResponse
+func mapProfessionalStatsToResponse(result *stats.ProfessionalStats) ProfessionalStatsResponse {
+	response := ProfessionalStatsResponse{
+		From:             common.FormatDate(result.From),
+		To:               common.FormatDate(result.To),
+		Granularity:      result.Granularity,
+		Summary:          mapTotalsToStatsTotals(result.Summary),
+		Buckets:          make([]StatsBucket, len(result.Buckets)),
+		BusiestWeekdays:  make([]WeekdayStatResponse, len(result.BusiestWeekdays)),
+		BusiestHours:     make([]HourStatResponse, len(result.BusiestHours)),
+		NewClients:       result.NewClients,
+		ReturningClients: result.ReturningClients,
+	}
+	for i, bucket := range result.Buckets {
+		response.Buckets[i] = StatsBucket{
+			Start:       common.FormatDate(bucket.Start),
+			StatsTotals: mapTotalsToStatsTotals(bucket.Totals),
+		}
+	}
+	for i, weekday := range result.BusiestWeekdays {
+		response.BusiestWeekdays[i] = WeekdayStatResponse{
+			Weekday:          weekday.Weekday,
+			Name:             strings.ToLower(time.Weekday(weekday.Weekday % 7).String()),
+			AppointmentCount: weekday.AppointmentCount,
+			BookedHours:      minutesToHours(weekday.BookedMinutes),
+		}
+	}
+	for i, hour := range result.BusiestHours {
+		response.BusiestHours[i] = HourStatResponse{
+			Hour:             hour.Hour,
+			AppointmentCount: hour.AppointmentCount,
+			BookedHours:      minutesToHours(hour.BookedMinutes),
+		}
+	}
+	return response
+}
+
+// mapTotalsToStatsTotals maps period aggregates to StatsTotals
+func mapTotalsToStatsTotals(totals stats.Totals) StatsTotals {
+	return StatsTotals{
+		AppointmentsByStatus: AppointmentsByStatus{
+			Pending:   totals.PendingAppointments,
+			Confirmed: totals.ConfirmedAppointments,
+			Cancelled: totals.CancelledAppointments,
+			Completed: totals.CompletedAppointments,
+		},
+		TotalAppointments:            totals.TotalAppointments,
+		BookedHours:                  minutesToHours(totals.BookedMinutes),
+		AvailableHours:               minutesToHours(totals.AvailableMinutes),
+		Utilization:                  round(totals.Utilization()),
+		AvgLeadTimeHours:             round(totals.AvgLeadTimeHours),
+		CancellationRate:             round(totals.CancellationRate()),
+		ClientCancellationRate:       round(totals.ClientCancellationRate()),
+		ProfessionalCancellationRate: round(totals.ProfessionalCancellationRate()),
+	}
+}
+
+// minutesToHours converts minutes to rounded hours
+func minutesToHours(minutes int) float64 {
+	return round(float64(minutes) / 60)
+}
+
+// round rounds to four decimals so that rates and hours stay readable
+func round(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
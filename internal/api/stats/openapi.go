@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+	"github.com/vention/booking_api/internal/services/stats"
+)
+
+// StatsOperations describes the routes registered by StatsRegister for the OpenAPI document
+func StatsOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/professionals/:id/stats",
+			Summary: "Get the statistics of a professional for a date range",
+			Tags:    []string{"stats"},
+			Params: append([]openapi.Param{
+				{Name: "granularity", In: openapi.InQuery, Enum: []string{stats.GranularityDay, stats.GranularityWeek, stats.GranularityMonth}, Default: stats.GranularityDay},
+			}, common.DateRangeParams...),
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: ProfessionalStatsResponse{}},
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+// ProfessionalStatsResponse represents the statistics of a professional for a date range
+type ProfessionalStatsResponse struct {
+	From             string                `json:"from"`
+	To               string                `json:"to"`
+	Granularity      string                `json:"granularity"`
+	Summary          StatsTotals           `json:"summary"`
+	Buckets          []StatsBucket         `json:"buckets"`
+	BusiestWeekdays  []WeekdayStatResponse `json:"busiest_weekdays"` // Busiest first
+	BusiestHours     []HourStatResponse    `json:"busiest_hours"`    // Busiest first
+	NewClients       int                   `json:"new_clients"`
+	ReturningClients int                   `json:"returning_clients"`
+}
+
+// StatsTotals represents the appointment aggregates of the whole range or of a bucket
+type StatsTotals struct {
+	AppointmentsByStatus AppointmentsByStatus `json:"appointments_by_status"`
+	TotalAppointments    int                  `json:"total_appointments"`
+
+	BookedHours      float64 `json:"booked_hours"`
+	AvailableHours   float64 `json:"available_hours"` // Working hours minus unavailable blocks
+	Utilization      float64 `json:"utilization"`     // booked_hours / available_hours
+	AvgLeadTimeHours float64 `json:"avg_lead_time_hours"`
+
+	CancellationRate             float64 `json:"cancellation_rate"`
+	ClientCancellationRate       float64 `json:"client_cancellation_rate"`
+	ProfessionalCancellationRate float64 `json:"professional_cancellation_rate"`
+}
+
+// AppointmentsByStatus represents appointment counts per status
+type AppointmentsByStatus struct {
+	Pending   int `json:"pending"`
+	Confirmed int `json:"confirmed"`
+	Cancelled int `json:"cancelled"`
+	Completed int `json:"completed"`
+}
+
+// StatsBucket represents the aggregates of one day, week or month
+type StatsBucket struct {
+	Start string `json:"start"` // YYYY-MM-DD, weeks start on Monday
+	StatsTotals
+}
+
+// WeekdayStatResponse represents booked appointments per weekday
+type WeekdayStatResponse struct {
+	Weekday          int     `json:"weekday"` // ISO weekday, 1 = Monday
+	Name             string  `json:"name"`
+	AppointmentCount int     `json:"appointment_count"`
+	BookedHours      float64 `json:"booked_hours"`
+}
+
+// HourStatResponse represents booked appointments per starting hour
+type HourStatResponse struct {
+	Hour             int     `json:"hour"`
+	AppointmentCount int     `json:"appointment_count"`
+	BookedHours      float64 `json:"booked_hours"`
+}
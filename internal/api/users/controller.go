@@ -2,11 +2,13 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/vention/booking_api/internal/api/common"
+	db "github.com/vention/booking_api/internal/repository"
 )
 
 // UsersController handles user-related HTTP requests
@@ -27,28 +29,92 @@ func (c *UsersController) GetUserByChatID(ctx *gin.Context) {
 	chatIDStr := ctx.Param("chat_id")
 	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
 	if err != nil {
-		common.HandleErrorResponse(ctx, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorMsgInvalidClientID, err)
+		common.HandleErrorResponse(ctx, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidClientID, common.ErrorMsgInvalidClientID, err)
 		return
 	}
 
 	// Get user from repository
 	user, err := c.usersRepo.GetUserByChatID(ctx.Request.Context(), sql.NullInt64{Int64: chatID, Valid: true})
 	if err != nil {
-		common.HandleErrorResponse(ctx, http.StatusNotFound, common.ErrorTypeNotFound, common.ErrorMsgUserNotFound, err)
+		err = db.TranslateError(err, db.ErrNotFound)
+		if errors.Is(err, db.ErrNotFound) {
+			common.HandleErrorResponse(ctx, http.StatusNotFound, common.ErrorTypeNotFound, common.ErrorCodeUserNotFound, common.ErrorMsgUserNotFound, err)
+			return
+		}
+		common.HandleErrorResponse(ctx, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorCodeGetUserFailed, common.ErrorMsgFailedToGetUser, err)
 		return
 	}
 
 	// Return success response
 	ctx.JSON(http.StatusOK, GetUserByChatIDResponse{
-		User: User{
-			ID:          user.ID.String(),
-			ChatID:      common.FromNullInt64(user.ChatID),
-			FirstName:   user.FirstName,
-			LastName:    user.LastName,
-			Role:        user.Role,
-			PhoneNumber: common.FromNullString(user.PhoneNumber),
-			CreatedAt:   common.FormatTimeWithTimezone(user.CreatedAt),
-			UpdatedAt:   common.FormatTimeWithTimezone(user.UpdatedAt),
-		},
+		User: mapUserRowToUser(user),
 	})
 }
+
+// UpdateUserLanguage handles PATCH /api/users/{chat_id}/language
+func (c *UsersController) UpdateUserLanguage(ctx *gin.Context) {
+	chatIDStr := ctx.Param("chat_id")
+	chatID, err := strconv.ParseInt(chatIDStr, 10, 64)
+	if err != nil {
+		common.HandleErrorResponse(ctx, http.StatusBadRequest, common.ErrorTypeValidation, common.ErrorCodeInvalidClientID, common.ErrorMsgInvalidClientID, err)
+		return
+	}
+
+	req, ok := common.BindAndValidate[UpdateUserLanguageRequest](ctx)
+	if !ok {
+		return
+	}
+
+	// A chat may belong to a client and a professional, both are updated
+	chatIDParam := sql.NullInt64{Int64: chatID, Valid: true}
+	language := sql.NullString{String: req.Language, Valid: true}
+	clients, err := c.usersRepo.UpdateClientLanguageByChatID(ctx.Request.Context(), &db.UpdateClientLanguageByChatIDParams{
+		ChatID:   chatIDParam,
+		Language: language,
+	})
+	if err != nil {
+		common.HandleErrorResponse(ctx, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorCodeUpdateLanguageFailed, common.ErrorMsgFailedToUpdateLanguage, err)
+		return
+	}
+	professionals, err := c.usersRepo.UpdateProfessionalLanguageByChatID(ctx.Request.Context(), &db.UpdateProfessionalLanguageByChatIDParams{
+		ChatID:   chatIDParam,
+		Language: language,
+	})
+	if err != nil {
+		common.HandleErrorResponse(ctx, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorCodeUpdateLanguageFailed, common.ErrorMsgFailedToUpdateLanguage, err)
+		return
+	}
+	if clients+professionals == 0 {
+		common.HandleErrorResponse(ctx, http.StatusNotFound, common.ErrorTypeNotFound, common.ErrorCodeUserNotFound, common.ErrorMsgUserNotFound, nil)
+		return
+	}
+
+	user, err := c.usersRepo.GetUserByChatID(ctx.Request.Context(), chatIDParam)
+	if err != nil {
+		common.HandleErrorResponse(ctx, http.StatusInternalServerError, common.ErrorTypeDatabase, common.ErrorCodeUpdateLanguageFailed, common.ErrorMsgFailedToUpdateLanguage, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, GetUserByChatIDResponse{
+		User: mapUserRowToUser(user),
+	})
+}
+
+// mapUserRowToUser maps a client or professional found by chat ID to a User
+func mapUserRowToUser(user *db.GetUserByChatIDRow) User {
+	response := User{
+		ID:          user.ID.String(),
+		ChatID:      common.FromNullInt64(user.ChatID),
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		Role:        user.Role,
+		PhoneNumber: common.FromNullString(user.PhoneNumber),
+		Language:    common.FromNullString(user.Language),
+		CreatedAt:   common.FormatTimeWithTimezone(user.CreatedAt),
+		UpdatedAt:   common.FormatTimeWithTimezone(user.UpdatedAt),
+	}
+	if user.Role == common.RoleClient {
+		response.LateCancellations = &user.LateCancellations
+	}
+	return response
+}
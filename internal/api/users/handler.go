@@ -29,6 +29,7 @@ func UsersRegister(params UsersHandlerParams) error {
 	users := params.Router.Group("/users")
 	{
 		users.GET("/:chat_id", controller.GetUserByChatID)
+		users.PATCH("/:chat_id/language", controller.UpdateUserLanguage)
 	}
 
 	return nil
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// UsersOperations describes the routes registered by UsersRegister for the OpenAPI document
+func UsersOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/users/:chat_id",
+			Summary: "Find the client or professional linked to a chat",
+			Tags:    []string{"users"},
+			Params: []openapi.Param{
+				{Name: "chat_id", In: openapi.InPath, Type: "integer", Format: "int64"},
+			},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetUserByChatIDResponse{}},
+				{Status: http.StatusNotFound},
+			},
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/users/:chat_id/language",
+			Summary:     "Set the language of messages for the client or professional linked to a chat",
+			Description: "Used for error messages and notification texts of the user's routes when requests have no Accept-Language header.",
+			Tags:        []string{"users"},
+			Params: []openapi.Param{
+				{Name: "chat_id", In: openapi.InPath, Type: "integer", Format: "int64"},
+			},
+			Request: UpdateUserLanguageRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetUserByChatIDResponse{}},
+				{Status: http.StatusBadRequest},
+				{Status: http.StatusNotFound},
+			},
+		},
+	}
+}
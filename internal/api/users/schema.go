@@ -7,13 +7,20 @@ type GetUserByChatIDResponse struct {
 
 // User represents a user in API responses (unified for both clients and professionals)
 type User struct {
-	ID          string  `json:"id"`
-	ChatID      *int64  `json:"chat_id,omitempty"`
-	Username    string  `json:"username"`
-	FirstName   string  `json:"first_name"`
-	LastName    string  `json:"last_name"`
-	Role        string  `json:"role"` // "client" or "professional"
-	PhoneNumber *string `json:"phone_number,omitempty"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID                string  `json:"id"`
+	ChatID            *int64  `json:"chat_id,omitempty"`
+	Username          string  `json:"username"`
+	FirstName         string  `json:"first_name"`
+	LastName          string  `json:"last_name"`
+	Role              string  `json:"role"` // "client" or "professional"
+	PhoneNumber       *string `json:"phone_number,omitempty"`
+	Language          *string `json:"language,omitempty"`           // Preferred language of messages, negotiated from Accept-Language when unset
+	LateCancellations *int64  `json:"late_cancellations,omitempty"` // Appointments a client cancelled with less notice than required, omitted for professionals
+	CreatedAt         string  `json:"created_at"`
+	UpdatedAt         string  `json:"updated_at"`
+}
+
+// UpdateUserLanguageRequest represents the request body for setting the language of a user
+type UpdateUserLanguageRequest struct {
+	Language string `json:"language" binding:"required,oneof=en ru uk de"`
 }
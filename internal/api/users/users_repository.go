@@ -10,4 +10,6 @@ import (
 // UsersRepository interface defines the contract for user-related database operations
 type UsersRepository interface {
 	GetUserByChatID(context.Context, sql.NullInt64) (*db.GetUserByChatIDRow, error)
+	UpdateClientLanguageByChatID(context.Context, *db.UpdateClientLanguageByChatIDParams) (int64, error)
+	UpdateProfessionalLanguageByChatID(context.Context, *db.UpdateProfessionalLanguageByChatIDParams) (int64, error)
 }
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/services/waitlist"
+)
+
+// JoinWaitlist handles POST /api/clients/{id}/waitlist
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	clientID, ok := common.ParseClientID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	req, ok := common.BindAndValidate[JoinWaitlistRequest](c)
+	if !ok {
+		return
+	}
+
+	professionalID, ok := common.ParseProfessionalID(c, req.ProfessionalID)
+	if !ok {
+		return
+	}
+
+	windowStart, ok := common.ParseTime(c, req.WindowStart, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
+	if !ok {
+		return
+	}
+
+	windowEnd, ok := common.ParseTime(c, req.WindowEnd, common.ErrorCodeInvalidTime, common.ErrorMsgInvalidTime)
+	if !ok {
+		return
+	}
+
+	entry, err := h.waitlistService.JoinWaitlist(c.Request.Context(), waitlist.JoinWaitlistInput{
+		ClientID:       clientID,
+		ProfessionalID: professionalID,
+		WindowStart:    windowStart,
+		WindowEnd:      windowEnd,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapWaitlistEntryToResponse(entry))
+}
+
+// GetWaitlist handles GET /api/clients/{id}/waitlist
+func (h *WaitlistHandler) GetWaitlist(c *gin.Context) {
+	clientID, ok := common.ParseClientID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	entries, err := h.waitlistService.GetWaitlist(c.Request.Context(), clientID)
+	if err != nil {
+		common.HandleDatabaseError(c, err, common.ErrorCodeRetrieveWaitlistFailed, common.ErrorMsgFailedToRetrieveWaitlist)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapWaitlistEntriesToResponse(entries))
+}
+
+// LeaveWaitlist handles DELETE /api/clients/{id}/waitlist/{entry_id}
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	clientID, entryID, ok := parseWaitlistEntryParams(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.waitlistService.LeaveWaitlist(c.Request.Context(), waitlist.LeaveWaitlistInput{
+		ClientID: clientID,
+		EntryID:  entryID,
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapWaitlistEntryToResponse(entry))
+}
+
+// AcceptOffer handles POST /api/clients/{id}/waitlist/{entry_id}/accept
+func (h *WaitlistHandler) AcceptOffer(c *gin.Context) {
+	clientID, entryID, ok := parseWaitlistEntryParams(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.waitlistService.AcceptOffer(c.Request.Context(), waitlist.AcceptOfferInput{
+		ClientID:    clientID,
+		EntryID:     entryID,
+		Description: "Personal training",
+	})
+	if err != nil {
+		common.HandleServiceError(c, err)
+		return
+	}
+
+	common.SetAppointmentETag(c, appointment.UpdatedAt)
+	c.JSON(http.StatusCreated, mapAppointmentToAcceptOfferResponse(appointment))
+}
+
+// parseWaitlistEntryParams parses the client and waitlist entry IDs from the path
+func parseWaitlistEntryParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	clientID, ok := common.ParseClientID(c, c.Param("id"))
+	if !ok {
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	entryID, ok := common.ParseUUID(c, c.Param("entry_id"), common.ErrorCodeInvalidWaitlistEntryID, common.ErrorMsgInvalidWaitlistEntryID)
+	if !ok {
+		return uuid.UUID{}, uuid.UUID{}, false
+	}
+
+	return clientID, entryID, true
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vention/booking_api/internal/services/waitlist"
+)
+
+// WaitlistHandler handles HTTP requests for the waitlists of clients
+type WaitlistHandler struct {
+	waitlistService waitlist.Service
+}
+
+// NewWaitlistHandler creates a new handler with dependency injection
+func NewWaitlistHandler(service waitlist.Service) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: service,
+	}
+}
+
+// WaitlistHandlerParams defines the parameters for the WaitlistHandler
+type WaitlistHandlerParams struct {
+	Router          *gin.RouterGroup
+	WaitlistService waitlist.Service
+	Idempotency     gin.HandlerFunc // Makes retries with an Idempotency-Key header safe
+}
+
+// WaitlistRegister registers the WaitlistHandler with the router
+func WaitlistRegister(p WaitlistHandlerParams) error {
+	if p.Router == nil {
+		return errors.New("missing router")
+	}
+
+	if p.WaitlistService == nil {
+		return errors.New("missing waitlist service")
+	}
+
+	if p.Idempotency == nil {
+		return errors.New("missing idempotency middleware")
+	}
+
+	h := NewWaitlistHandler(p.WaitlistService)
+
+	entries := p.Router.Group("/clients/:id/waitlist")
+	{
+		entries.GET("", h.GetWaitlist)
+		entries.POST("", p.Idempotency, h.JoinWaitlist)
+		entries.DELETE("/:entry_id", h.LeaveWaitlist)
+		entries.POST("/:entry_id/accept", p.Idempotency, h.AcceptOffer)
+	}
+
+	return nil
+}
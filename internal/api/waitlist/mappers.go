@@ -0,0 +1,55 @@
+package api
+
+import (
+	common "github.com/vention/booking_api/internal/api/common"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// mapWaitlistEntryToResponse maps a waitlist entry to a WaitlistEntryResponse
+func mapWaitlistEntryToResponse(entry *db.WaitlistEntry) WaitlistEntryResponse {
+	response := WaitlistEntryResponse{
+		ID:             entry.ID.String(),
+		ProfessionalID: entry.ProfessionalID.String(),
+		WindowStart:    common.FormatTimeRFC3339(entry.WindowStart),
+		WindowEnd:      common.FormatTimeRFC3339(entry.WindowEnd),
+		Status:         string(entry.Status),
+		CreatedAt:      common.FormatTimeRFC3339(entry.CreatedAt),
+	}
+
+	// Offers are only shown while open, expired and cancelled entries keep them for the history
+	if entry.Status == db.WaitlistStatusOffered {
+		response.OfferedStartTime = common.FromNullTimeRFC3339(entry.OfferedStartTime)
+		response.OfferedEndTime = common.FromNullTimeRFC3339(entry.OfferedEndTime)
+		response.OfferExpiresAt = common.FromNullTimeRFC3339(entry.OfferExpiresAt)
+	}
+	if entry.AppointmentID.Valid {
+		response.AppointmentID = common.StringPtr(entry.AppointmentID.UUID.String())
+	}
+
+	return response
+}
+
+// mapWaitlistEntriesToResponse maps waitlist entries to a GetWaitlistResponse
+func mapWaitlistEntriesToResponse(entries []*db.WaitlistEntry) GetWaitlistResponse {
+	response := GetWaitlistResponse{
+		Entries: make([]WaitlistEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = mapWaitlistEntryToResponse(entry)
+	}
+	return response
+}
+
+// mapAppointmentToAcceptOfferResponse maps the booked appointment to an AcceptOfferResponse
+func mapAppointmentToAcceptOfferResponse(appointment *db.CreateAppointmentWithDetailsRow) AcceptOfferResponse {
+	return AcceptOfferResponse{
+		ID:             appointment.ID.String(),
+		ProfessionalID: appointment.ProfessionalID.String(),
+		StartTime:      common.FormatTimeRFC3339(appointment.StartTime),
+		EndTime:        common.FormatTimeRFC3339(appointment.EndTime),
+		Status:         string(appointment.Status.AppointmentStatus),
+		Description:    appointment.Description.String,
+		CreatedAt:      common.FormatTimeRFC3339(appointment.CreatedAt),
+		UpdatedAt:      common.FormatTimeRFC3339(appointment.UpdatedAt),
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+// WaitlistOperations describes the routes registered by WaitlistRegister for the OpenAPI document
+func WaitlistOperations() []openapi.Operation {
+	tags := []string{"waitlist"}
+
+	return []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/clients/:id/waitlist",
+			Summary: "List the waiting entries and open offers of a client",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: GetWaitlistResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/clients/:id/waitlist",
+			Summary:     "Join the waitlist of a professional",
+			Description: "When an appointment of the professional within the window is cancelled, the freed slot is offered to the earliest waiting client for a limited time.",
+			Tags:        tags,
+			Params:      []openapi.Param{common.IdempotencyKeyParam},
+			Request:     JoinWaitlistRequest{},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: WaitlistEntryResponse{}},
+				{Status: http.StatusNotFound, Description: "Client or professional not found"},
+			},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/clients/:id/waitlist/:entry_id",
+			Summary:     "Leave the waitlist",
+			Description: "An open offer is passed on to the next waiting client.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: WaitlistEntryResponse{}},
+				{Status: http.StatusNotFound, Description: "Waitlist entry not found"},
+				{Status: http.StatusConflict, Description: "Entry already booked, expired or cancelled"},
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/clients/:id/waitlist/:entry_id/accept",
+			Summary:     "Accept the slot offered to a waitlist entry",
+			Description: "Books the offered slot as a pending appointment.",
+			Tags:        tags,
+			Params:      []openapi.Param{common.IdempotencyKeyParam},
+			Responses: []openapi.Response{
+				{Status: http.StatusCreated, Body: AcceptOfferResponse{}, Headers: []openapi.Param{common.ETagResponseHeader}},
+				{Status: http.StatusNotFound, Description: "Waitlist entry not found"},
+				{Status: http.StatusConflict, Description: "No open offer or the slot is no longer free"},
+			},
+		},
+	}
+}
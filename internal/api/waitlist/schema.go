@@ -0,0 +1,40 @@
+package api
+
+// JoinWaitlistRequest represents the request to wait for a slot of a professional within a time window
+type JoinWaitlistRequest struct {
+	ProfessionalID string `json:"professional_id" binding:"required,uuid"`
+	WindowStart    string `json:"window_start" binding:"required"` // Earliest start of an acceptable slot, RFC3339
+	WindowEnd      string `json:"window_end" binding:"required"`   // Latest end of an acceptable slot, RFC3339
+}
+
+// WaitlistEntryResponse represents a waitlist entry of a client. The offer fields are set
+// while a freed slot is held for the client.
+type WaitlistEntryResponse struct {
+	ID               string  `json:"id"`
+	ProfessionalID   string  `json:"professional_id"`
+	WindowStart      string  `json:"window_start"`
+	WindowEnd        string  `json:"window_end"`
+	Status           string  `json:"status"` // waiting, offered, booked, expired or cancelled
+	OfferedStartTime *string `json:"offered_start_time,omitempty"`
+	OfferedEndTime   *string `json:"offered_end_time,omitempty"`
+	OfferExpiresAt   *string `json:"offer_expires_at,omitempty"`
+	AppointmentID    *string `json:"appointment_id,omitempty"` // Appointment booked from the offer
+	CreatedAt        string  `json:"created_at"`
+}
+
+// GetWaitlistResponse represents the response for getting the waitlist of a client
+type GetWaitlistResponse struct {
+	Entries []WaitlistEntryResponse `json:"entries"`
+}
+
+// AcceptOfferResponse represents the appointment booked from a waitlist offer
+type AcceptOfferResponse struct {
+	ID             string `json:"id"`
+	ProfessionalID string `json:"professional_id"`
+	StartTime      string `json:"start_time"`
+	EndTime        string `json:"end_time"`
+	Status         string `json:"status"`
+	Description    string `json:"description,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
@@ -13,6 +13,9 @@ type Config struct {
 	ServerPort         int           `env:"SERVER_PORT" envDefault:"8080"`
 	ServerReadTimeout  time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"30s"`
 	ServerWriteTimeout time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"30s"`
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
+	PublicBaseURL      string        `env:"PUBLIC_BASE_URL" envDefault:""` // Used to build absolute links, e.g. calendar feed URLs
+	TrustedProxies     string        `env:"TRUSTED_PROXIES" envDefault:""` // Proxies whose X-Forwarded-For is trusted for client IPs, e.g. 10.0.0.0/8
 
 	// Database config
 	DBHost            string        `env:"DB_HOST" envDefault:"localhost"`
@@ -25,6 +28,44 @@ type Config struct {
 	DBMaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" envDefault:"25"`
 	DBConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" envDefault:"5m"`
 
+	// Events config
+	SSEHeartbeatInterval time.Duration `env:"SSE_HEARTBEAT_INTERVAL" envDefault:"15s"`
+
+	// External calendars config
+	ExternalCalendarSyncInterval    time.Duration `env:"EXTERNAL_CALENDAR_SYNC_INTERVAL" envDefault:"15m"`
+	ExternalCalendarFetchTimeout    time.Duration `env:"EXTERNAL_CALENDAR_FETCH_TIMEOUT" envDefault:"30s"`
+	ExternalCalendarAllowedNetworks string        `env:"EXTERNAL_CALENDAR_ALLOWED_NETWORKS" envDefault:""` // Internal networks calendar URLs may reach, e.g. 10.0.0.0/8,127.0.0.1
+
+	// Admin reports config
+	AdminReportsCacheTTL time.Duration `env:"ADMIN_REPORTS_CACHE_TTL" envDefault:"5m"` // 0 disables caching
+
+	// Idempotency config
+	IdempotencyKeyTTL             time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+	IdempotencyKeyCleanupInterval time.Duration `env:"IDEMPOTENCY_KEY_CLEANUP_INTERVAL" envDefault:"1h"`
+
+	// Slot holds config
+	SlotHoldTTL             time.Duration `env:"SLOT_HOLD_TTL" envDefault:"5m"` // How long a slot is held for a client completing a booking
+	SlotHoldCleanupInterval time.Duration `env:"SLOT_HOLD_CLEANUP_INTERVAL" envDefault:"5m"`
+
+	// Waitlist config
+	WaitlistOfferTTL      time.Duration `env:"WAITLIST_OFFER_TTL" envDefault:"30m"`           // How long a freed slot is held for a waiting client
+	WaitlistSweepInterval time.Duration `env:"WAITLIST_OFFER_SWEEP_INTERVAL" envDefault:"1m"` // How often expired offers are passed on and missed slots offered
+
+	// Optimistic concurrency config
+	RequireIfMatch bool `env:"REQUIRE_IF_MATCH" envDefault:"false"` // Reject confirmations and cancellations without If-Match
+
+	// Rate limit config, policies are <limit>/<period>[:token|ip]
+	RateLimitEnabled  bool   `env:"RATE_LIMIT_ENABLED" envDefault:"false"` // Off by default, the bot usually calls the API as a single service
+	RateLimitStore    string `env:"RATE_LIMIT_STORE" envDefault:"memory"`  // memory or postgres, shared by all replicas
+	RateLimitDefault  string `env:"RATE_LIMIT_DEFAULT" envDefault:"1200/1m"`
+	RateLimitPolicies string `env:"RATE_LIMIT_POLICIES" envDefault:"/api/professionals/:id/availability=300/1m,/api/professionals/sign_in=20/1m:ip"`
+
+	// Tracing config, the OTLP exporter reads the standard OTEL_EXPORTER_OTLP_* variables
+	TracingExporter    string  `env:"TRACING_EXPORTER" envDefault:"none"` // none, otlp or stdout
+	TracingFile        string  `env:"TRACING_FILE" envDefault:""`         // stdout exporter output file, standard output when empty
+	TracingServiceName string  `env:"TRACING_SERVICE_NAME" envDefault:"booking_api"`
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+
 	// JWT config
 	JWTSecret string `env:"JWT_SECRET" envDefault:""`
 
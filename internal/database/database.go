@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	_ "github.com/lib/pq"
@@ -43,3 +45,20 @@ func (db *DB) Close() {
 func (db *DB) Health() error {
 	return db.Ping()
 }
+
+// MigrationVersion returns the schema version recorded by golang-migrate and whether
+// the last migration failed halfway. Version 0 means no migration has been applied.
+func (db *DB) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// subscriberBufferSize is the number of events buffered per subscriber before it is dropped
+const subscriberBufferSize = 64
+
+// Broker fans out appointment events to in-process subscribers
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the events matching its filter until it is closed
+type Subscription struct {
+	C <-chan *db.AppointmentEvent
+
+	ch     chan *db.AppointmentEvent
+	match  func(*db.AppointmentEvent) bool
+	broker *Broker
+	once   sync.Once
+}
+
+// NewBroker creates a new in-process event broker
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for the events accepted by match.
+// Once the broker is closed the returned subscription is already closed.
+func (b *Broker) Subscribe(match func(*db.AppointmentEvent) bool) *Subscription {
+	ch := make(chan *db.AppointmentEvent, subscriberBufferSize)
+	sub := &Subscription{
+		C:      ch,
+		ch:     ch,
+		match:  match,
+		broker: b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		sub.once.Do(func() { close(ch) })
+		return sub
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Close closes every subscription and rejects new ones, ending open event streams on server shutdown
+func (b *Broker) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subs := make([]*Subscription, 0, len(b.subscribers))
+	for sub := range b.subscribers {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// Publish delivers the event to every matching subscriber.
+// Subscribers that cannot keep up are dropped; they are expected to reconnect with Last-Event-ID.
+func (b *Broker) Publish(event *db.AppointmentEvent) {
+	b.mu.RLock()
+	var slow []*Subscription
+	for sub := range b.subscribers {
+		if !sub.match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range slow {
+		sub.Close()
+	}
+}
+
+// Close unregisters the subscription and closes its channel
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		delete(s.broker.subscribers, s)
+		s.broker.mu.Unlock()
+		close(s.ch)
+	})
+}
+
+// ForProfessional matches events of the given professional
+func ForProfessional(professionalID uuid.UUID) func(*db.AppointmentEvent) bool {
+	return func(event *db.AppointmentEvent) bool {
+		return event.ProfessionalID == professionalID
+	}
+}
+
+// ForClient matches events of the given client
+func ForClient(clientID uuid.UUID) func(*db.AppointmentEvent) bool {
+	return func(event *db.AppointmentEvent) bool {
+		return event.ClientID.Valid && event.ClientID.UUID == clientID
+	}
+}
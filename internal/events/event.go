@@ -0,0 +1,44 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Appointment event types
+const (
+	EventAppointmentCreated     = "appointment.created"
+	EventAppointmentConfirmed   = "appointment.confirmed"
+	EventAppointmentCancelled   = "appointment.cancelled"
+	EventAppointmentSlotOffered = "appointment.slot_offered" // The slot of the cancelled appointment is offered to a waitlisted client
+)
+
+// Cancellation sources
+const (
+	CancelledByProfessional = "professional"
+	CancelledByClient       = "client"
+)
+
+// AppointmentPayload is the appointment snapshot stored with every event
+type AppointmentPayload struct {
+	Type               string     `json:"type"`
+	Status             string     `json:"status"`
+	StartTime          time.Time  `json:"start_time"`
+	EndTime            time.Time  `json:"end_time"`
+	Description        string     `json:"description,omitempty"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	CancelledBy        string     `json:"cancelled_by,omitempty"`
+	LateCancellation   bool       `json:"late_cancellation,omitempty"` // Cancelled by the client with less notice than the policy requires
+	WaitlistEntryID    *uuid.UUID `json:"waitlist_entry_id,omitempty"` // Entry the slot is offered to
+	OfferExpiresAt     *time.Time `json:"offer_expires_at,omitempty"`  // The offered slot is held until then
+}
+
+// AppointmentChange describes a single appointment change to be recorded
+type AppointmentChange struct {
+	Type           string
+	AppointmentID  uuid.UUID
+	ProfessionalID uuid.UUID
+	ClientID       uuid.NullUUID
+	Payload        AppointmentPayload
+}
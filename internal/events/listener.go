@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// NotifyChannel is the PostgreSQL channel the appointment_events trigger notifies on
+const NotifyChannel = "appointment_events"
+
+const (
+	listenerMinReconnectInterval = 10 * time.Second
+	listenerMaxReconnectInterval = time.Minute
+	listenerPingInterval         = 90 * time.Second
+)
+
+// ListenerRepository defines the database operations needed by the events listener
+type ListenerRepository interface {
+	GetAppointmentEvent(ctx context.Context, id int64) (*db.AppointmentEvent, error)
+}
+
+// Listen forwards PostgreSQL notifications about new events to the broker until ctx is cancelled.
+// Every replica listens, so an event recorded by any instance reaches subscribers on all of them.
+// Notifications carry only the event id, the event itself is loaded from the repository.
+func Listen(ctx context.Context, dsn string, repo ListenerRepository, broker *Broker, logger zerolog.Logger) error {
+	listener := pq.NewListener(dsn, listenerMinReconnectInterval, listenerMaxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Warn().Err(err).Msg("Event listener connection problem")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(NotifyChannel); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", NotifyChannel, err)
+	}
+
+	logger.Info().Str("channel", NotifyChannel).Msg("Listening for appointment events")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case notification := <-listener.Notify:
+			// A nil notification is sent after the connection has been re-established
+			if notification == nil {
+				logger.Warn().Msg("Event listener reconnected, some events may only be available via Last-Event-ID resume")
+				continue
+			}
+
+			id, err := strconv.ParseInt(notification.Extra, 10, 64)
+			if err != nil {
+				logger.Error().Err(err).Str("payload", notification.Extra).Msg("Failed to decode appointment event notification")
+				continue
+			}
+			event, err := repo.GetAppointmentEvent(ctx, id)
+			if err != nil {
+				logger.Error().Err(err).Int64("event_id", id).Msg("Failed to load appointment event")
+				continue
+			}
+			broker.Publish(event)
+
+		case <-time.After(listenerPingInterval):
+			if err := listener.Ping(); err != nil {
+				logger.Warn().Err(err).Msg("Event listener ping failed")
+			}
+		}
+	}
+}
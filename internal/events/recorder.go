@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// EventsRepository defines the database operations needed by the events recorder
+type EventsRepository interface {
+	LockAppointmentEvents(ctx context.Context) error
+	CreateAppointmentEvent(ctx context.Context, arg *db.CreateAppointmentEventParams) (*db.AppointmentEvent, error)
+}
+
+// Recorder persists appointment changes to the event log
+type Recorder interface {
+	Record(ctx context.Context, repo EventsRepository, change AppointmentChange) error
+}
+
+type recorder struct{}
+
+// NewRecorder creates a new events recorder
+func NewRecorder() Recorder {
+	return &recorder{}
+}
+
+// Record stores the change in the event log; the database trigger notifies listeners once the
+// transaction commits. repo must be the transaction that makes the change, so that the change
+// and its event are committed together.
+//
+// Event IDs are the resume cursor of event streams, so they must be assigned in commit order:
+// the transaction-scoped lock taken before the insert makes transactions that record events
+// commit one at a time, and an event never becomes visible after one with a higher ID.
+// Record should therefore be the last statement of the transaction.
+func (r *recorder) Record(ctx context.Context, repo EventsRepository, change AppointmentChange) error {
+	payload, err := json.Marshal(change.Payload)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", change.Type, err)
+	}
+
+	if err := repo.LockAppointmentEvents(ctx); err != nil {
+		return err
+	}
+
+	if _, err := repo.CreateAppointmentEvent(ctx, &db.CreateAppointmentEventParams{
+		EventType:      change.Type,
+		AppointmentID:  change.AppointmentID,
+		ProfessionalID: change.ProfessionalID,
+		ClientID:       change.ClientID,
+		Payload:        payload,
+	}); err != nil {
+		return fmt.Errorf("record %s event: %w", change.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Package health tracks whether the instance is ready to serve traffic
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Check statuses
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// checkTimeout bounds every dependency check so that a hanging database cannot block the probe
+const checkTimeout = 2 * time.Second
+
+// Delays before restarting a worker that stopped, doubling after every restart
+const (
+	workerMinBackoff = time.Second
+	workerMaxBackoff = time.Minute
+)
+
+// Database is the database access needed by the readiness checks
+type Database interface {
+	PingContext(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+// CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Status string
+	Error  string
+}
+
+// Report is the outcome of all readiness checks
+type Report struct {
+	Ready  bool
+	Checks map[string]CheckResult
+}
+
+// Probe answers liveness and readiness probes
+type Probe struct {
+	db                Database
+	expectedMigration uint
+	draining          atomic.Bool
+
+	logger zerolog.Logger
+
+	mu      sync.Mutex
+	workers map[string]*workerState
+}
+
+// workerState is the state of a background worker
+type workerState struct {
+	running  bool
+	restarts int
+	lastErr  error // Why the worker last stopped
+}
+
+// NewProbe creates a probe expecting the database schema to be at least at expectedMigration
+func NewProbe(db Database, expectedMigration uint, logger zerolog.Logger) *Probe {
+	return &Probe{
+		db:                db,
+		expectedMigration: expectedMigration,
+		logger:            logger,
+		workers:           make(map[string]*workerState),
+	}
+}
+
+// Go runs a background worker in a goroutine until ctx is cancelled. A worker that returns or
+// panics before then is restarted with exponential backoff; the instance is not ready meanwhile.
+func (p *Probe) Go(ctx context.Context, name string, fn func()) {
+	p.setRunning(name)
+	go func() {
+		backoff := workerMinBackoff
+		for {
+			started := time.Now()
+			err := runWorker(fn)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				err = errors.New("worker returned")
+			}
+			p.setStopped(name, err)
+
+			// A worker that ran for a while failed afresh rather than in a crash loop
+			if time.Since(started) > workerMaxBackoff {
+				backoff = workerMinBackoff
+			}
+			p.logger.Error().Err(err).Str("worker", name).Dur("backoff", backoff).Msg("Background worker stopped, restarting")
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, workerMaxBackoff)
+			p.setRunning(name)
+		}
+	}()
+}
+
+// runWorker runs fn, converting a panic into an error
+func runWorker(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func (p *Probe) setRunning(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if worker, ok := p.workers[name]; ok {
+		worker.running = true
+		return
+	}
+	p.workers[name] = &workerState{running: true}
+}
+
+func (p *Probe) setStopped(name string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	worker := p.workers[name]
+	worker.running = false
+	worker.restarts++
+	worker.lastErr = err
+}
+
+// Drain marks the instance as not ready so that load balancers stop routing new requests to it
+func (p *Probe) Drain() {
+	p.draining.Store(true)
+}
+
+// Ready runs all readiness checks
+func (p *Probe) Ready(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{Ready: true, Checks: make(map[string]CheckResult)}
+	add := func(name string, err error) {
+		if err != nil {
+			report.Ready = false
+			report.Checks[name] = CheckResult{Status: StatusFail, Error: err.Error()}
+			return
+		}
+		report.Checks[name] = CheckResult{Status: StatusOK}
+	}
+
+	if p.draining.Load() {
+		add("shutdown", errors.New("shutting down"))
+	} else {
+		add("shutdown", nil)
+	}
+
+	add("database", p.db.PingContext(ctx))
+	add("migrations", p.checkMigrations(ctx))
+
+	p.mu.Lock()
+	for name, worker := range p.workers {
+		if worker.running {
+			add("worker:"+name, nil)
+		} else {
+			add("worker:"+name, fmt.Errorf("restarting after %d failures, last: %w", worker.restarts, worker.lastErr))
+		}
+	}
+	p.mu.Unlock()
+
+	return report
+}
+
+// checkMigrations accepts newer schemas so that instances of the previous release stay
+// ready while a rolling deployment migrates the database
+func (p *Probe) checkMigrations(ctx context.Context) error {
+	version, dirty, err := p.db.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed and left the schema dirty", version)
+	}
+	if version < p.expectedMigration {
+		return fmt.Errorf("schema version %d is older than the expected version %d", version, p.expectedMigration)
+	}
+	return nil
+}
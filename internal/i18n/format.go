@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"strconv"
+	"time"
+)
+
+// monthNames holds the month names used in dates, in the genitive case where the language
+// declines them after a day number
+var monthNames = map[Locale][12]string{
+	English: {
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	Russian: {
+		"января", "февраля", "марта", "апреля", "мая", "июня",
+		"июля", "августа", "сентября", "октября", "ноября", "декабря",
+	},
+	Ukrainian: {
+		"січня", "лютого", "березня", "квітня", "травня", "червня",
+		"липня", "серпня", "вересня", "жовтня", "листопада", "грудня",
+	},
+	German: {
+		"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember",
+	},
+}
+
+// FormatDate formats a date for people, e.g. 15 January 2024, 15 января 2024 or 15. Januar 2024
+func FormatDate(locale Locale, t time.Time) string {
+	months, ok := monthNames[locale]
+	if !ok {
+		months = monthNames[Default]
+	}
+
+	day := strconv.Itoa(t.Day())
+	if locale == German {
+		day += "."
+	}
+	return day + " " + months[t.Month()-1] + " " + strconv.Itoa(t.Year())
+}
+
+// FormatDateTime formats a date and 24-hour time for people, e.g. 15 January 2024, 10:00
+func FormatDateTime(locale Locale, t time.Time) string {
+	return FormatDate(locale, t) + ", " + t.Format("15:04")
+}
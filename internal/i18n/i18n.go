@@ -0,0 +1,139 @@
+// Package i18n translates API messages and formats dates for the languages of our users.
+// Messages are looked up in JSON catalogs keyed by error codes and message keys, with
+// {name} placeholders for the arguments.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language, identified by its ISO 639-1 code
+type Locale string
+
+// Supported locales
+const (
+	English   Locale = "en"
+	Russian   Locale = "ru"
+	Ukrainian Locale = "uk"
+	German    Locale = "de"
+)
+
+// Default is used when a client accepts none of the supported locales
+const Default = English
+
+// Supported lists the supported locales, the default first
+var Supported = []Locale{English, Russian, Ukrainian, German}
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// catalogs holds the messages of each locale by key
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[Locale]map[string]string {
+	catalogs := make(map[Locale]map[string]string, len(Supported))
+	for _, locale := range Supported {
+		data, err := localesFS.ReadFile("locales/" + string(locale) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read %s catalog: %v", locale, err))
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: failed to parse %s catalog: %v", locale, err))
+		}
+		catalogs[locale] = catalog
+	}
+	return catalogs
+}
+
+// Parse returns the supported locale of a language tag such as ru or de-AT
+func Parse(tag string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	locale := Locale(strings.ToLower(language))
+	if _, ok := catalogs[locale]; !ok {
+		return "", false
+	}
+	return locale, true
+}
+
+// Negotiate picks the supported locale a client prefers most from an Accept-Language header.
+// It reports false when the header accepts none of the supported locales.
+func Negotiate(acceptLanguage string) (Locale, bool) {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality == 0 {
+			continue
+		}
+
+		if tag == "*" {
+			candidates = append(candidates, candidate{locale: Default, quality: quality})
+			continue
+		}
+		if locale, ok := Parse(tag); ok {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].locale, true
+}
+
+// Message returns the message of a key in a locale, falling back to English, with the
+// placeholders replaced by args given as name and value pairs. It reports false when no
+// catalog has the key.
+func Message(locale Locale, key string, args ...string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return "", false
+	}
+
+	if len(args) > 0 {
+		oldnew := make([]string, 0, len(args))
+		for i := 0; i+1 < len(args); i += 2 {
+			oldnew = append(oldnew, "{"+args[i]+"}", args[i+1])
+		}
+		message = strings.NewReplacer(oldnew...).Replace(message)
+	}
+	return message, true
+}
+
+// Translate returns the message of a key in a locale, or the fallback when no catalog has the key
+func Translate(locale Locale, key, fallback string, args ...string) string {
+	if message, ok := Message(locale, key, args...); ok {
+		return message
+	}
+	return fallback
+}
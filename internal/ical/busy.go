@@ -0,0 +1,46 @@
+package ical
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// BusyBlock is a time range during which the calendar owner is busy
+type BusyBlock struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// BusyBlocks expands events into busy blocks overlapping [from, to).
+// Cancelled and transparent (free) events are ignored, and instances
+// overridden via RECURRENCE-ID replace the instance of the master event.
+func BusyBlocks(events []Event, from, to time.Time) []BusyBlock {
+	overrides := make(map[string][]time.Time)
+	for _, e := range events {
+		if e.RecurrenceID != nil {
+			overrides[e.UID] = append(overrides[e.UID], *e.RecurrenceID)
+		}
+	}
+
+	var blocks []BusyBlock
+	for _, e := range events {
+		if e.RRule != nil && e.RecurrenceID == nil {
+			e.ExDates = append(e.ExDates, overrides[e.UID]...)
+		}
+		if e.Transparent || strings.EqualFold(e.Status, "CANCELLED") {
+			continue
+		}
+		for _, o := range e.Occurrences(from, to) {
+			if !o.End.After(o.Start) {
+				continue
+			}
+			blocks = append(blocks, BusyBlock{UID: e.UID, Summary: e.Summary, Start: o.Start, End: o.End})
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Start.Before(blocks[j].Start) })
+	return blocks
+}
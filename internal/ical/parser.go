@@ -0,0 +1,301 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrNoCalendar is returned when the input does not contain a VCALENDAR
+var ErrNoCalendar = errors.New("no VCALENDAR found")
+
+// Event represents a parsed VEVENT
+type Event struct {
+	UID          string
+	Summary      string
+	Status       string
+	Transparent  bool
+	AllDay       bool
+	Start        time.Time
+	End          time.Time
+	RRule        *RRule
+	ExDates      []time.Time
+	RecurrenceID *time.Time
+}
+
+// property is a single content line split into name, parameters and value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads all VEVENTs from an iCalendar stream.
+// Floating times and unknown TZIDs are interpreted in defaultLoc.
+func Parse(r io.Reader, defaultLoc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events      []Event
+		current     *Event
+		depth       int
+		hasCalendar bool
+	)
+
+	for _, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			continue // Tolerate malformed lines, common in exported calendars
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			switch strings.ToUpper(prop.value) {
+			case "VCALENDAR":
+				hasCalendar = true
+			case "VEVENT":
+				if depth == 0 {
+					current = &Event{}
+				}
+			}
+			if current != nil {
+				depth++
+			}
+			continue
+		case "END":
+			if current != nil {
+				depth--
+				if depth == 0 {
+					if !current.Start.IsZero() {
+						events = append(events, *current)
+					}
+					current = nil
+				}
+			}
+			continue
+		}
+
+		// Only top-level VEVENT properties are relevant (skip nested VALARMs)
+		if current == nil || depth != 1 {
+			continue
+		}
+
+		if err := applyProperty(current, prop, defaultLoc); err != nil {
+			return nil, fmt.Errorf("invalid %s in event %q: %w", prop.name, current.UID, err)
+		}
+	}
+
+	if !hasCalendar {
+		return nil, ErrNoCalendar
+	}
+
+	// Default end: one day for all-day events, zero duration otherwise
+	for i := range events {
+		if events[i].End.IsZero() {
+			if events[i].AllDay {
+				events[i].End = events[i].Start.AddDate(0, 0, 1)
+			} else {
+				events[i].End = events[i].Start
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// applyProperty sets the event field corresponding to the property
+func applyProperty(event *Event, prop property, defaultLoc *time.Location) error {
+	switch prop.name {
+	case "UID":
+		event.UID = prop.value
+	case "SUMMARY":
+		event.Summary = unescapeText(prop.value)
+	case "STATUS":
+		event.Status = strings.ToUpper(prop.value)
+	case "TRANSP":
+		event.Transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+	case "DTSTART":
+		t, allDay, err := parseDateTime(prop, defaultLoc)
+		if err != nil {
+			return err
+		}
+		event.Start, event.AllDay = t, allDay
+	case "DTEND":
+		t, _, err := parseDateTime(prop, defaultLoc)
+		if err != nil {
+			return err
+		}
+		event.End = t
+	case "DURATION":
+		if event.Start.IsZero() {
+			return errors.New("DURATION before DTSTART")
+		}
+		d, err := parseDuration(prop.value)
+		if err != nil {
+			return err
+		}
+		event.End = event.Start.Add(d)
+	case "RRULE":
+		rule, err := ParseRRule(prop.value, defaultLoc)
+		if err != nil {
+			return err
+		}
+		event.RRule = rule
+	case "EXDATE":
+		for _, value := range strings.Split(prop.value, ",") {
+			t, _, err := parseDateTime(property{name: prop.name, params: prop.params, value: value}, defaultLoc)
+			if err != nil {
+				return err
+			}
+			event.ExDates = append(event.ExDates, t)
+		}
+	case "RECURRENCE-ID":
+		t, _, err := parseDateTime(prop, defaultLoc)
+		if err != nil {
+			return err
+		}
+		event.RecurrenceID = &t
+	}
+	return nil
+}
+
+// unfold joins folded content lines (RFC 5545 section 3.1)
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits "NAME;PARAM=VALUE:value" into its parts
+func parseProperty(line string) (property, error) {
+	// The value starts after the first colon outside of quoted parameter values
+	inQuotes := false
+	sep := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return property{}, fmt.Errorf("missing value separator in %q", line)
+	}
+
+	parts := strings.Split(line[:sep], ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[sep+1:],
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, nil
+}
+
+// parseDateTime parses DATE and DATE-TIME values honouring TZID and the UTC suffix
+func parseDateTime(prop property, defaultLoc *time.Location) (time.Time, bool, error) {
+	loc := defaultLoc
+	if tzid, ok := prop.params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseDuration parses an RFC 5545 DURATION such as P1D, PT1H30M or P2W
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	s = s[1:]
+
+	var (
+		total  time.Duration
+		number int
+		inTime bool
+		digits bool
+	)
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+			digits = true
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		switch {
+		case r == 'W' && !inTime:
+			total += time.Duration(number) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			total += time.Duration(number) * 24 * time.Hour
+		case r == 'H' && inTime:
+			total += time.Duration(number) * time.Hour
+		case r == 'M' && inTime:
+			total += time.Duration(number) * time.Minute
+		case r == 'S' && inTime:
+			total += time.Duration(number) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return sign * total, nil
+}
+
+// unescapeText reverses TEXT escaping
+func unescapeText(s string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,314 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxPeriods bounds rule expansion for rules without COUNT or UNTIL
+	maxPeriods = 50000
+
+	// maxOccurrences bounds the number of instances returned per event
+	maxOccurrences = 10000
+)
+
+// weekdays maps RFC 5545 weekday codes to time.Weekday
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY entry such as MO, 2TU or -1FR
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RRule is the supported subset of an RFC 5545 recurrence rule
+type RRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+}
+
+// Occurrence is a single instance of a (possibly recurring) event
+type Occurrence struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParseRRule parses an RRULE value such as FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10
+func ParseRRule(value string, defaultLoc *time.Location) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToUpper(key)
+		val = strings.ToUpper(val)
+
+		switch key {
+		case "FREQ":
+			switch val {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				rule.Freq = val
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid interval %q", val)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid count %q", val)
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, _, err := parseDateTime(property{value: val, params: map[string]string{}}, defaultLoc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid until %q", val)
+			}
+			rule.Until = t
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				if len(day) < 2 {
+					return nil, fmt.Errorf("invalid weekday %q", day)
+				}
+				weekday, ok := weekdays[day[len(day)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("invalid weekday %q", day)
+				}
+				n := 0
+				if prefix := day[:len(day)-2]; prefix != "" {
+					var err error
+					if n, err = strconv.Atoi(prefix); err != nil {
+						return nil, fmt.Errorf("invalid weekday %q", day)
+					}
+				}
+				rule.ByDay = append(rule.ByDay, WeekdayNum{Weekday: weekday, N: n})
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid month day %q", day)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(month)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid month %q", month)
+				}
+				rule.ByMonth = append(rule.ByMonth, n)
+			}
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("missing frequency in %q", value)
+	}
+	return rule, nil
+}
+
+// Occurrences returns the instances of the event overlapping [from, to).
+// EXDATEs are removed; overridden instances must be excluded by the caller.
+func (e Event) Occurrences(from, to time.Time) []Occurrence {
+	duration := e.End.Sub(e.Start)
+	overlaps := func(start time.Time) bool {
+		return start.Before(to) && start.Add(duration).After(from)
+	}
+
+	if e.RRule == nil {
+		if overlaps(e.Start) {
+			return []Occurrence{{Start: e.Start, End: e.End}}
+		}
+		return nil
+	}
+
+	excluded := make(map[int64]bool, len(e.ExDates))
+	for _, t := range e.ExDates {
+		excluded[t.Unix()] = true
+	}
+
+	var (
+		occurrences []Occurrence
+		generated   int
+	)
+	for period := 0; period < maxPeriods; period++ {
+		for _, start := range e.RRule.candidates(e.Start, period) {
+			if start.Before(e.Start) {
+				continue
+			}
+			if !e.RRule.Until.IsZero() && start.After(e.RRule.Until) {
+				return occurrences
+			}
+			generated++
+			if e.RRule.Count > 0 && generated > e.RRule.Count {
+				return occurrences
+			}
+			if !start.Before(to) || len(occurrences) >= maxOccurrences {
+				return occurrences
+			}
+			if !excluded[start.Unix()] && overlaps(start) {
+				occurrences = append(occurrences, Occurrence{Start: start, End: start.Add(duration)})
+			}
+		}
+	}
+	return occurrences
+}
+
+// candidates returns the sorted instance starts of the n-th period of the rule
+func (r *RRule) candidates(dtstart time.Time, n int) []time.Time {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	hour, minute, second := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, second, 0, loc)
+	}
+
+	var result []time.Time
+	switch r.Freq {
+	case "DAILY":
+		t := at(year, month, day+n*r.Interval)
+		if r.matchesMonth(t) && r.matchesWeekday(t) && r.matchesMonthDay(t) {
+			result = append(result, t)
+		}
+	case "WEEKLY":
+		// Weeks start on Monday (WKST=MO)
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		weekStart := day - offset + n*7*r.Interval
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []WeekdayNum{{Weekday: dtstart.Weekday()}}
+		}
+		for _, wd := range days {
+			t := at(year, month, weekStart+(int(wd.Weekday)+6)%7)
+			if r.matchesMonth(t) {
+				result = append(result, t)
+			}
+		}
+	case "MONTHLY":
+		first := at(year, month+time.Month(n*r.Interval), 1)
+		if r.matchesMonth(first) {
+			result = r.monthDays(first, day, at)
+		}
+	case "YEARLY":
+		y := year + n*r.Interval
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(month)}
+		}
+		for _, m := range months {
+			result = append(result, r.monthDays(at(y, time.Month(m), 1), day, at)...)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// monthDays expands BYMONTHDAY/BYDAY within the month starting at first,
+// defaulting to the day of month of DTSTART
+func (r *RRule) monthDays(first time.Time, defaultDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	year, month, _ := first.Date()
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var result []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, d := range r.ByMonthDay {
+			if d < 0 {
+				d = lastDay + d + 1
+			}
+			if d >= 1 && d <= lastDay {
+				result = append(result, at(year, month, d))
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, wd := range r.ByDay {
+			firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+			firstMatch := 1 + (int(wd.Weekday)-int(firstWeekday)+7)%7
+			switch {
+			case wd.N > 0:
+				if d := firstMatch + (wd.N-1)*7; d <= lastDay {
+					result = append(result, at(year, month, d))
+				}
+			case wd.N < 0:
+				lastMatch := firstMatch + (lastDay-firstMatch)/7*7
+				if d := lastMatch + (wd.N+1)*7; d >= 1 {
+					result = append(result, at(year, month, d))
+				}
+			default:
+				for d := firstMatch; d <= lastDay; d += 7 {
+					result = append(result, at(year, month, d))
+				}
+			}
+		}
+	default:
+		if defaultDay <= lastDay {
+			result = append(result, at(year, month, defaultDay))
+		}
+	}
+	return result
+}
+
+// matchesMonth reports whether t satisfies BYMONTH
+func (r *RRule) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if int(t.Month()) == m {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday reports whether t satisfies BYDAY when used as a filter
+func (r *RRule) matchesWeekday(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if t.Weekday() == wd.Weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay reports whether t satisfies BYMONTHDAY when used as a filter
+func (r *RRule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == t.Day() || lastDay+d+1 == t.Day() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, berlin)
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		rrule   string
+		exdates []time.Time
+		from    time.Time
+		to      time.Time
+		want    []time.Time
+	}{
+		{
+			name:  "weekly by day with count",
+			start: at(2024, time.January, 1, 10), // Monday
+			rrule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2024, time.February, 1, 0),
+			want:  []time.Time{at(2024, time.January, 1, 10), at(2024, time.January, 3, 10), at(2024, time.January, 8, 10), at(2024, time.January, 10, 10)},
+		},
+		{
+			name:  "every other week",
+			start: at(2024, time.January, 2, 10), // Tuesday
+			rrule: "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2024, time.March, 1, 0),
+			want:  []time.Time{at(2024, time.January, 2, 10), at(2024, time.January, 16, 10), at(2024, time.January, 30, 10)},
+		},
+		{
+			name:  "monthly on the last friday",
+			start: at(2024, time.January, 26, 10),
+			rrule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2025, time.January, 1, 0),
+			want:  []time.Time{at(2024, time.January, 26, 10), at(2024, time.February, 23, 10), at(2024, time.March, 29, 10)},
+		},
+		{
+			name:  "monthly on the second tuesday",
+			start: at(2024, time.January, 9, 10),
+			rrule: "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2025, time.January, 1, 0),
+			want:  []time.Time{at(2024, time.January, 9, 10), at(2024, time.February, 13, 10), at(2024, time.March, 12, 10)},
+		},
+		{
+			name:  "monthly on the last day",
+			start: at(2024, time.January, 31, 10),
+			rrule: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2025, time.January, 1, 0),
+			want:  []time.Time{at(2024, time.January, 31, 10), at(2024, time.February, 29, 10), at(2024, time.March, 31, 10)},
+		},
+		{
+			name:  "daily until is inclusive",
+			start: at(2024, time.January, 1, 10),
+			rrule: "FREQ=DAILY;UNTIL=20240103T090000Z", // 10:00 in Berlin
+			from:  at(2024, time.January, 1, 0),
+			to:    at(2024, time.February, 1, 0),
+			want:  []time.Time{at(2024, time.January, 1, 10), at(2024, time.January, 2, 10), at(2024, time.January, 3, 10)},
+		},
+		{
+			name:    "exdate removes an instance but still counts",
+			start:   at(2024, time.January, 1, 10),
+			rrule:   "FREQ=DAILY;COUNT=3",
+			exdates: []time.Time{at(2024, time.January, 2, 10)},
+			from:    at(2024, time.January, 1, 0),
+			to:      at(2024, time.February, 1, 0),
+			want:    []time.Time{at(2024, time.January, 1, 10), at(2024, time.January, 3, 10)},
+		},
+		{
+			name:  "window limits instances of an unbounded rule",
+			start: at(2024, time.January, 1, 10),
+			rrule: "FREQ=WEEKLY",
+			from:  at(2024, time.March, 1, 0),
+			to:    at(2024, time.March, 20, 0),
+			want:  []time.Time{at(2024, time.March, 4, 10), at(2024, time.March, 11, 10), at(2024, time.March, 18, 10)},
+		},
+		{
+			name:  "local time is kept across the daylight saving change",
+			start: at(2024, time.March, 30, 10),
+			rrule: "FREQ=DAILY;COUNT=3",
+			from:  at(2024, time.March, 1, 0),
+			to:    at(2024, time.April, 30, 0),
+			want:  []time.Time{at(2024, time.March, 30, 10), at(2024, time.March, 31, 10), at(2024, time.April, 1, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rrule, berlin)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q): %v", tt.rrule, err)
+			}
+			event := Event{
+				Start:   tt.start,
+				End:     tt.start.Add(time.Hour),
+				RRule:   rule,
+				ExDates: tt.exdates,
+			}
+
+			got := event.Occurrences(tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i, o := range got {
+				if !o.Start.Equal(tt.want[i]) {
+					t.Errorf("occurrence %d starts at %v, want %v", i, o.Start, tt.want[i])
+				}
+				if d := o.End.Sub(o.Start); d != time.Hour {
+					t.Errorf("occurrence %d lasts %v, want 1h", i, d)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	tests := []string{
+		"BYDAY=MO",               // Missing frequency
+		"FREQ=HOURLY",            // Unsupported frequency
+		"FREQ=WEEKLY;BYDAY=XX",   // Unknown weekday
+		"FREQ=MONTHLY;BYDAY=AFR", // Invalid ordinal
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;INTERVAL=-1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+	}
+
+	for _, value := range tests {
+		if _, err := ParseRRule(value, time.UTC); err == nil {
+			t.Errorf("ParseRRule(%q) succeeded, want an error", value)
+		}
+	}
+}
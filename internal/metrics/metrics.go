@@ -0,0 +1,134 @@
+// Package metrics exposes Prometheus metrics of the booking API.
+// It has no HTTP framework dependencies so that services can record domain metrics directly.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "booking"
+
+// unmatchedRoute labels requests that did not match any route, keeping label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// Sign-in failure reasons
+const (
+	SignInUnknownUser     = "unknown_user"
+	SignInInvalidPassword = "invalid_password"
+)
+
+// registry holds all booking API metrics, separate from the global default registry
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	appointmentsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "appointments_created_total",
+		Help:      "Number of created appointments and unavailable periods by type.",
+	}, []string{"type"})
+
+	appointmentsConfirmed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "appointments_confirmed_total",
+		Help:      "Number of confirmed appointments.",
+	})
+
+	appointmentsCancelled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "appointments_cancelled_total",
+		Help:      "Number of cancelled appointments by who cancelled them.",
+	}, []string{"cancelled_by"})
+
+	waitlistSlotsOffered = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "waitlist_slots_offered_total",
+		Help:      "Number of freed slots offered to waitlisted clients.",
+	})
+
+	signInFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_in_failures_total",
+		Help:      "Number of failed professional sign-ins by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		appointmentsCreated,
+		appointmentsConfirmed,
+		appointmentsCancelled,
+		waitlistSlotsOffered,
+		signInFailures,
+	)
+}
+
+// Handler returns the HTTP handler serving all metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// RegisterDBStats exposes the connection pool statistics of db
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// ObserveHTTPRequest records a completed HTTP request, route is the matched route pattern
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	statusLabel := strconv.Itoa(status)
+	httpRequestsTotal.WithLabelValues(method, route, statusLabel).Inc()
+	httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// AppointmentCreated records a created appointment or unavailable period
+func AppointmentCreated(appointmentType string) {
+	appointmentsCreated.WithLabelValues(appointmentType).Inc()
+}
+
+// AppointmentConfirmed records a confirmed appointment
+func AppointmentConfirmed() {
+	appointmentsConfirmed.Inc()
+}
+
+// AppointmentCancelled records a cancelled appointment, cancelledBy is the cancelling party
+func AppointmentCancelled(cancelledBy string) {
+	appointmentsCancelled.WithLabelValues(cancelledBy).Inc()
+}
+
+// WaitlistSlotOffered records a freed slot offered to a waitlisted client
+func WaitlistSlotOffered() {
+	waitlistSlotsOffered.Inc()
+}
+
+// SignInFailed records a failed professional sign-in
+func SignInFailed(reason string) {
+	signInFailures.WithLabelValues(reason).Inc()
+}
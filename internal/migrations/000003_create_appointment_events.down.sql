@@ -0,0 +1,12 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS notify_appointment_events_insert ON appointment_events;
+
+-- Drop function
+DROP FUNCTION IF EXISTS notify_appointment_event();
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_appointment_events_client_id;
+DROP INDEX IF EXISTS idx_appointment_events_professional_id;
+
+-- Drop table
+DROP TABLE IF EXISTS appointment_events;
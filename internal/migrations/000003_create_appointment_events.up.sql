@@ -0,0 +1,25 @@
+-- Create appointment_events table (append-only log of appointment changes)
+CREATE TABLE IF NOT EXISTS appointment_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL, -- created, confirmed, cancelled, rescheduled
+    appointment_id UUID NOT NULL REFERENCES appointments(id),
+    professional_id UUID NOT NULL REFERENCES professionals(id),
+    client_id UUID REFERENCES clients(id), -- NULL for unavailable appointments
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb, -- Appointment snapshot at the time of the event
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_appointment_events_professional_id ON appointment_events(professional_id, id);
+CREATE INDEX IF NOT EXISTS idx_appointment_events_client_id ON appointment_events(client_id, id);
+
+-- Notify listeners on every new event so that all replicas can fan it out
+CREATE OR REPLACE FUNCTION notify_appointment_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('appointment_events', row_to_json(NEW)::text);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER notify_appointment_events_insert AFTER INSERT ON appointment_events FOR EACH ROW EXECUTE FUNCTION notify_appointment_event();
@@ -0,0 +1,5 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_calendar_feeds_updated_at ON calendar_feeds;
+
+-- Drop table
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,13 @@
+-- Create calendar_feeds table (secret tokens protecting iCalendar feeds)
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    professional_id UUID UNIQUE REFERENCES professionals(id), -- Owner when the feed belongs to a professional
+    client_id UUID UNIQUE REFERENCES clients(id), -- Owner when the feed belongs to a client
+    token VARCHAR(64) NOT NULL UNIQUE, -- Secret token included in the feed URL
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT calendar_feeds_single_owner CHECK ((professional_id IS NULL) <> (client_id IS NULL))
+);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_calendar_feeds_updated_at BEFORE UPDATE ON calendar_feeds FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,11 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_external_calendars_updated_at ON external_calendars;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_external_busy_blocks_professional_time;
+DROP INDEX IF EXISTS idx_external_busy_blocks_calendar_id;
+DROP INDEX IF EXISTS idx_external_calendars_professional_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS external_busy_blocks;
+DROP TABLE IF EXISTS external_calendars;
@@ -0,0 +1,31 @@
+-- Create external_calendars table (iCalendar sources whose events block availability)
+CREATE TABLE IF NOT EXISTS external_calendars (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    professional_id UUID NOT NULL REFERENCES professionals(id),
+    name VARCHAR(255) NOT NULL,
+    url TEXT, -- Subscription URL, NULL for one-off file uploads
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    last_sync_error TEXT, -- Error of the most recent sync, NULL when it succeeded
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create external_busy_blocks table (expanded busy times imported from external calendars)
+CREATE TABLE IF NOT EXISTS external_busy_blocks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    external_calendar_id UUID NOT NULL REFERENCES external_calendars(id) ON DELETE CASCADE,
+    professional_id UUID NOT NULL REFERENCES professionals(id),
+    uid TEXT NOT NULL, -- UID of the source VEVENT
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    summary TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_external_calendars_professional_id ON external_calendars(professional_id);
+CREATE INDEX IF NOT EXISTS idx_external_busy_blocks_calendar_id ON external_busy_blocks(external_calendar_id);
+CREATE INDEX IF NOT EXISTS idx_external_busy_blocks_professional_time ON external_busy_blocks(professional_id, start_time, end_time);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_external_calendars_updated_at BEFORE UPDATE ON external_calendars FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,15 @@
+-- Create idempotency_keys table (responses of requests sent with an Idempotency-Key header)
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope TEXT NOT NULL, -- Method and route the key was used for, e.g. POST /api/appointments/
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL, -- SHA-256 of the method, URL and body of the first request
+    response_status INTEGER, -- NULL while the first request is still being processed
+    response_content_type TEXT,
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
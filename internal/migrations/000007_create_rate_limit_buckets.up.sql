@@ -0,0 +1,10 @@
+-- Create rate_limit_buckets table (token buckets shared by all replicas when RATE_LIMIT_STORE=postgres)
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY, -- Policy name and client key, e.g. /api/professionals/:id/availability:ip:203.0.113.7
+    tokens DOUBLE PRECISION NOT NULL, -- Tokens left at updated_at
+    allowed BOOLEAN NOT NULL, -- Whether the last request took a token
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
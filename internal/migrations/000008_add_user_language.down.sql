@@ -0,0 +1,3 @@
+-- Remove preferred language from clients and professionals
+ALTER TABLE professionals DROP COLUMN language;
+ALTER TABLE clients DROP COLUMN language;
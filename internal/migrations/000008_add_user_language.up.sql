@@ -0,0 +1,3 @@
+-- Add preferred language of messages to clients and professionals (NULL to negotiate from Accept-Language)
+ALTER TABLE clients ADD COLUMN language VARCHAR(8);
+ALTER TABLE professionals ADD COLUMN language VARCHAR(8);
@@ -0,0 +1,11 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_cancellation_policies_updated_at ON cancellation_policies;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_appointments_client_late_cancellation;
+
+-- Remove late cancellation flag
+ALTER TABLE appointments DROP COLUMN late_cancellation;
+
+-- Drop table
+DROP TABLE IF EXISTS cancellation_policies;
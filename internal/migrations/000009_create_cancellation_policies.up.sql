@@ -0,0 +1,17 @@
+-- Create cancellation_policies table (rules of client cancellations, per professional)
+CREATE TABLE IF NOT EXISTS cancellation_policies (
+    professional_id UUID PRIMARY KEY REFERENCES professionals(id),
+    min_notice_hours INTEGER NOT NULL DEFAULT 0 CHECK (min_notice_hours >= 0), -- Hours before the start a cancellation is free
+    allow_late_cancellation BOOLEAN NOT NULL DEFAULT TRUE, -- Whether clients may cancel with less notice
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Flag appointments cancelled by the client with less notice than the policy requires
+ALTER TABLE appointments ADD COLUMN late_cancellation BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_appointments_client_late_cancellation ON appointments(client_id) WHERE late_cancellation;
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_cancellation_policies_updated_at BEFORE UPDATE ON cancellation_policies FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
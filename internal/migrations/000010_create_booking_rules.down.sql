@@ -0,0 +1,8 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_booking_rules_updated_at ON booking_rules;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_appointments_client_professional_start;
+
+-- Drop table
+DROP TABLE IF EXISTS booking_rules;
@@ -0,0 +1,16 @@
+-- Create booking_rules table (limits on client bookings, per professional)
+CREATE TABLE IF NOT EXISTS booking_rules (
+    professional_id UUID PRIMARY KEY REFERENCES professionals(id),
+    min_notice_hours INTEGER NOT NULL DEFAULT 0 CHECK (min_notice_hours >= 0), -- Hours ahead of the start an appointment must be booked
+    max_advance_days INTEGER CHECK (max_advance_days > 0), -- Days ahead an appointment may be booked, NULL for no limit
+    max_daily_per_client INTEGER CHECK (max_daily_per_client > 0), -- Appointments a client may have on one day, NULL for no limit
+    max_pending_per_client INTEGER CHECK (max_pending_per_client > 0), -- Unconfirmed requests a client may have, NULL for no limit
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_appointments_client_professional_start ON appointments(client_id, professional_id, start_time);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_booking_rules_updated_at BEFORE UPDATE ON booking_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
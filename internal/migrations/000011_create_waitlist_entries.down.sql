@@ -0,0 +1,14 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_waitlist_entries_updated_at ON waitlist_entries;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_waitlist_entries_open_offer;
+DROP INDEX IF EXISTS idx_waitlist_entries_offer_expires_at;
+DROP INDEX IF EXISTS idx_waitlist_entries_client_id;
+DROP INDEX IF EXISTS idx_waitlist_entries_professional_waiting;
+
+-- Drop table
+DROP TABLE IF EXISTS waitlist_entries;
+
+-- Drop enum
+DROP TYPE IF EXISTS waitlist_status;
@@ -0,0 +1,35 @@
+-- Create waitlist_status enum
+DO $$ BEGIN
+    CREATE TYPE waitlist_status AS ENUM ('waiting', 'offered', 'booked', 'expired', 'cancelled');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Create waitlist_entries table (clients waiting for a slot of a professional within a time window)
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id UUID NOT NULL REFERENCES clients(id),
+    professional_id UUID NOT NULL REFERENCES professionals(id),
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL, -- Earliest start of an acceptable slot
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL, -- Latest end of an acceptable slot
+    status waitlist_status NOT NULL DEFAULT 'waiting',
+    offered_appointment_id UUID REFERENCES appointments(id), -- Cancelled appointment whose slot is offered
+    offered_start_time TIMESTAMP WITH TIME ZONE,
+    offered_end_time TIMESTAMP WITH TIME ZONE,
+    offer_expires_at TIMESTAMP WITH TIME ZONE, -- The slot is held for the client until then
+    appointment_id UUID REFERENCES appointments(id), -- Appointment booked from the offer
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (window_end > window_start)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_professional_waiting ON waitlist_entries(professional_id, created_at) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_client_id ON waitlist_entries(client_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_offer_expires_at ON waitlist_entries(offer_expires_at) WHERE status = 'offered';
+
+-- A freed slot is offered to one client at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_entries_open_offer ON waitlist_entries(offered_appointment_id) WHERE status = 'offered';
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_waitlist_entries_updated_at BEFORE UPDATE ON waitlist_entries FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_slot_holds_expires_at;
+DROP INDEX IF EXISTS idx_slot_holds_professional_time;
+
+-- Drop table
+DROP TABLE IF EXISTS slot_holds;
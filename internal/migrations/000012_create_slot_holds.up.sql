@@ -0,0 +1,15 @@
+-- Create slot_holds table (slots reserved for a client while they complete a booking)
+CREATE TABLE IF NOT EXISTS slot_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    professional_id UUID NOT NULL REFERENCES professionals(id),
+    client_id UUID NOT NULL REFERENCES clients(id),
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- The hold is ignored and purged after this time
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_time > start_time)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_slot_holds_professional_time ON slot_holds(professional_id, start_time);
+CREATE INDEX IF NOT EXISTS idx_slot_holds_expires_at ON slot_holds(expires_at);
@@ -0,0 +1,5 @@
+-- Drop auto_confirm column from booking_rules
+ALTER TABLE booking_rules DROP COLUMN IF EXISTS auto_confirm;
+
+-- Drop enum
+DROP TYPE IF EXISTS auto_confirm_mode;
@@ -0,0 +1,9 @@
+-- Create auto_confirm_mode enum
+DO $$ BEGIN
+    CREATE TYPE auto_confirm_mode AS ENUM ('off', 'all', 'returning_clients');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+-- Add auto_confirm column to booking_rules (which bookings are confirmed without the professional)
+ALTER TABLE booking_rules ADD COLUMN IF NOT EXISTS auto_confirm auto_confirm_mode NOT NULL DEFAULT 'off';
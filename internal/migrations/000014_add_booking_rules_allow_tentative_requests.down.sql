@@ -0,0 +1,2 @@
+-- Drop allow_tentative_requests column from booking_rules
+ALTER TABLE booking_rules DROP COLUMN IF EXISTS allow_tentative_requests;
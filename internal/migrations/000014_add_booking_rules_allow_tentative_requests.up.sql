@@ -0,0 +1,2 @@
+-- Add allow_tentative_requests column to booking_rules (whether slots with a pending request may still be requested)
+ALTER TABLE booking_rules ADD COLUMN IF NOT EXISTS allow_tentative_requests BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,8 @@
+-- Restore notifications carrying the full event row
+CREATE OR REPLACE FUNCTION notify_appointment_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('appointment_events', row_to_json(NEW)::text);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
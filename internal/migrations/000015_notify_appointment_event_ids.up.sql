@@ -0,0 +1,9 @@
+-- Notify only the event id; a full row can exceed the 8000 byte pg_notify payload limit.
+-- Listeners load the event by id.
+CREATE OR REPLACE FUNCTION notify_appointment_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('appointment_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
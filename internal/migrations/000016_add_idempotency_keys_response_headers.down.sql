@@ -0,0 +1,2 @@
+-- Drop response_headers column from idempotency_keys
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS response_headers;
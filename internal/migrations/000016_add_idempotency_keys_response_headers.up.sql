@@ -0,0 +1,2 @@
+-- Add response_headers column to idempotency_keys (headers replayed with the stored response, e.g. ETag)
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS response_headers JSONB NOT NULL DEFAULT '{}'::jsonb;
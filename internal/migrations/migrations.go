@@ -0,0 +1,36 @@
+// Package migrations embeds the SQL migrations so that the server knows the schema version it expects
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"regexp"
+	"strconv"
+)
+
+// FS contains all up and down migration files
+//
+//go:embed *.sql
+var FS embed.FS
+
+// versionPattern matches the version prefix of migration file names, e.g. 000003_create_appointment_events.up.sql
+var versionPattern = regexp.MustCompile(`^(\d+)_`)
+
+// LatestVersion returns the highest migration version found in fsys
+func LatestVersion(fsys fs.FS) (uint, error) {
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, f := range files {
+		if matches := versionPattern.FindStringSubmatch(f.Name()); len(matches) > 1 {
+			v, _ := strconv.ParseUint(matches[1], 10, 64)
+			if uint(v) > latest {
+				latest = uint(v)
+			}
+		}
+	}
+	return latest, nil
+}
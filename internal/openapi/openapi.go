@@ -0,0 +1,394 @@
+// Package openapi builds the OpenAPI 3.1 document of the API from the routes registered
+// on the router and the request and response structs of the handlers.
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.1.0"
+
+// Parameter locations
+const (
+	InQuery  = "query"
+	InHeader = "header"
+	InPath   = "path"
+)
+
+// TypeFile marks form fields that carry an uploaded file
+const TypeFile = "file"
+
+// bearerAuth is the security scheme of JWT protected routes
+const bearerAuth = "bearerAuth"
+
+// Operation describes a route registered on the router
+type Operation struct {
+	Method      string
+	Path        string // gin path, relative to the router group until mounted
+	Summary     string
+	Description string
+	Tags        []string
+	Secured     bool       // Requires a JWT bearer token, set by Mount
+	Params      []Param    // Query and header parameters, path parameters default to strings
+	Request     any        // JSON request body
+	Form        []Param    // multipart/form-data request fields
+	Responses   []Response // Error responses without a Body use Config.ErrorResponse
+}
+
+// Param describes a path, query, header or form parameter
+type Param struct {
+	Name        string
+	In          string // InQuery, InHeader or InPath, ignored for form fields
+	Description string
+	Required    bool
+	Type        string // JSON schema type, string when empty
+	Format      string
+	Enum        []string
+	Default     string
+}
+
+// Response describes a response of an operation
+type Response struct {
+	Status       int
+	Description  string   // Defaults to the status text
+	Body         any      // JSON response body
+	ContentTypes []string // Representations other than JSON, documented as strings
+	Headers      []Param
+}
+
+// Config describes the API the document is built for
+type Config struct {
+	Title         string
+	Version       string
+	Description   string
+	ErrorResponse any // Body of error responses
+}
+
+// Document is an OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       Info                                   `json:"info"`
+	Paths      map[string]map[string]*operationObject `json:"paths"`
+	Components Components                             `json:"components"`
+}
+
+// Info describes the API
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the schemas referenced by operations
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat"`
+}
+
+type operationObject struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+	Parameters  []parameterObject          `json:"parameters,omitempty"`
+	RequestBody *requestBodyObject         `json:"requestBody,omitempty"`
+	Responses   map[string]*responseObject `json:"responses"`
+}
+
+type parameterObject struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBodyObject struct {
+	Required bool                       `json:"required"`
+	Content  map[string]mediaTypeObject `json:"content"`
+}
+
+type responseObject struct {
+	Description string                     `json:"description"`
+	Headers     map[string]headerObject    `json:"headers,omitempty"`
+	Content     map[string]mediaTypeObject `json:"content,omitempty"`
+}
+
+type headerObject struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type mediaTypeObject struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Mount prefixes the paths of operations declared relative to a router group
+func Mount(basePath string, secured bool, operations []Operation) []Operation {
+	mounted := make([]Operation, len(operations))
+	for i, op := range operations {
+		op.Path = strings.TrimSuffix(basePath, "/") + op.Path
+		op.Secured = secured
+		mounted[i] = op
+	}
+	return mounted
+}
+
+// Check reports routes registered on the router that are not documented, documented routes
+// that are not registered and routes documented twice, so that the document cannot drift from the router
+func Check(routes gin.RoutesInfo, operations []Operation) error {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[routeKey(route.Method, route.Path)] = true
+	}
+
+	var errs []error
+	var unregistered []string
+	documented := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		key := routeKey(op.Method, op.Path)
+		if documented[key] {
+			errs = append(errs, fmt.Errorf("route %s is documented twice", key))
+		}
+		documented[key] = true
+		if !registered[key] {
+			unregistered = append(unregistered, key)
+		}
+	}
+
+	var undocumented []string
+	for key := range registered {
+		if !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+
+	if len(undocumented) > 0 {
+		sort.Strings(undocumented)
+		errs = append(errs, fmt.Errorf("routes missing from the OpenAPI document: %s", strings.Join(undocumented, ", ")))
+	}
+	if len(unregistered) > 0 {
+		sort.Strings(unregistered)
+		errs = append(errs, fmt.Errorf("documented routes are not registered: %s", strings.Join(unregistered, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// Build creates the document for the operations; use Check to compare them with the router
+func Build(cfg Config, operations []Operation) *Document {
+	g := newSchemaGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       cfg.Title,
+			Version:     cfg.Version,
+			Description: cfg.Description,
+		},
+		Paths: make(map[string]map[string]*operationObject),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]securityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	var errorSchema *Schema
+	if cfg.ErrorResponse != nil {
+		errorSchema = g.schemaOf(cfg.ErrorResponse)
+	}
+
+	for _, op := range operations {
+		path, pathParams := convertPath(op.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(map[string]*operationObject)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = g.operation(op, pathParams, errorSchema)
+	}
+
+	return doc
+}
+
+// operation converts a declared operation to its OpenAPI object
+func (g *schemaGenerator) operation(op Operation, pathParams []string, errorSchema *Schema) *operationObject {
+	obj := &operationObject{
+		OperationID: operationID(op.Method, op.Path),
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Responses:   make(map[string]*responseObject, len(op.Responses)+1),
+	}
+	if op.Secured {
+		obj.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	// Path parameters are always required and default to strings, or UUIDs for ids
+	for _, name := range pathParams {
+		param := Param{Name: name, In: InPath}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			param.Format = "uuid"
+		}
+		for _, declared := range op.Params {
+			if declared.In == InPath && declared.Name == name {
+				param = declared
+			}
+		}
+		obj.Parameters = append(obj.Parameters, parameterObject{
+			Name:        param.Name,
+			In:          InPath,
+			Description: param.Description,
+			Required:    true,
+			Schema:      paramSchema(param),
+		})
+	}
+	for _, param := range op.Params {
+		if param.In == InPath {
+			continue
+		}
+		obj.Parameters = append(obj.Parameters, parameterObject{
+			Name:        param.Name,
+			In:          param.In,
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      paramSchema(param),
+		})
+	}
+
+	switch {
+	case op.Request != nil:
+		obj.RequestBody = &requestBodyObject{
+			Required: true,
+			Content:  map[string]mediaTypeObject{"application/json": {Schema: g.schemaOf(op.Request)}},
+		}
+	case len(op.Form) > 0:
+		form := &Schema{Type: "object", Properties: make(map[string]*Schema, len(op.Form))}
+		for _, field := range op.Form {
+			form.Properties[field.Name] = paramSchema(field)
+			if field.Required {
+				form.Required = append(form.Required, field.Name)
+			}
+		}
+		obj.RequestBody = &requestBodyObject{
+			Required: true,
+			Content:  map[string]mediaTypeObject{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	for _, resp := range op.Responses {
+		obj.Responses[strconv.Itoa(resp.Status)] = g.response(resp, errorSchema)
+	}
+	if errorSchema != nil {
+		obj.Responses["default"] = &responseObject{
+			Description: "Error",
+			Content:     map[string]mediaTypeObject{"application/json": {Schema: errorSchema}},
+		}
+	}
+
+	return obj
+}
+
+// response converts a declared response to its OpenAPI object
+func (g *schemaGenerator) response(resp Response, errorSchema *Schema) *responseObject {
+	obj := &responseObject{Description: resp.Description}
+	if obj.Description == "" {
+		obj.Description = http.StatusText(resp.Status)
+	}
+
+	body := resp.Body
+	if body == nil && resp.Status >= http.StatusBadRequest && errorSchema != nil {
+		obj.Content = map[string]mediaTypeObject{"application/json": {Schema: errorSchema}}
+	}
+	if body != nil || len(resp.ContentTypes) > 0 {
+		obj.Content = make(map[string]mediaTypeObject, len(resp.ContentTypes)+1)
+		if body != nil {
+			obj.Content["application/json"] = mediaTypeObject{Schema: g.schemaOf(body)}
+		}
+		for _, contentType := range resp.ContentTypes {
+			obj.Content[contentType] = mediaTypeObject{Schema: &Schema{Type: "string"}}
+		}
+	}
+
+	if len(resp.Headers) > 0 {
+		obj.Headers = make(map[string]headerObject, len(resp.Headers))
+		for _, header := range resp.Headers {
+			obj.Headers[header.Name] = headerObject{Description: header.Description, Schema: paramSchema(header)}
+		}
+	}
+
+	return obj
+}
+
+// paramSchema returns the schema of a parameter or form field
+func paramSchema(param Param) *Schema {
+	schema := &Schema{Type: param.Type, Format: param.Format, Enum: param.Enum}
+	switch param.Type {
+	case "":
+		schema.Type = "string"
+	case TypeFile:
+		schema.Type, schema.Format = "string", "binary"
+	}
+	if param.Default != "" {
+		schema.Default = param.Default
+	}
+	return schema
+}
+
+// convertPath converts a gin path to an OpenAPI path and returns its parameter names
+func convertPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives a stable operation id from the method and gin path,
+// e.g. patch_api_professionals_id_appointments_appointment_id_cancel
+func operationID(method, ginPath string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(ginPath, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		if segment == "" {
+			continue
+		}
+		b.WriteByte('_')
+		b.WriteString(strings.NewReplacer(".", "_", "-", "_").Replace(segment))
+	}
+	return b.String()
+}
+
+func routeKey(method, path string) string {
+	return method + " " + path
+}
+
+// typeOf returns the struct type of a value, dereferencing pointers
+func typeOf(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,28 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed swagger.html
+var swaggerHTML string
+
+var swaggerTemplate = template.Must(template.New("swagger").Parse(swaggerHTML))
+
+// UIHandler serves a Swagger UI page for the document at specURL.
+// The page is embedded in the binary, the Swagger UI assets are loaded from unpkg.
+func UIHandler(title, specURL string) gin.HandlerFunc {
+	var page bytes.Buffer
+	if err := swaggerTemplate.Execute(&page, struct{ Title, SpecURL string }{title, specURL}); err != nil {
+		panic(err)
+	}
+
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+	}
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucket holds the tokens left at updatedAt
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// MemoryStore keeps buckets in process memory, limits apply per replica
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take refills the bucket for the time elapsed since its last use and takes a token if one is left
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Limit), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = min(float64(policy.Limit), b.tokens+now.Sub(b.updatedAt).Seconds()*policy.refillRate())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return newResult(policy, allowed, b.tokens), nil
+}
+
+// Sweep deletes buckets that have not been used for idleFor
+func (s *MemoryStore) Sweep(_ context.Context, idleFor time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idleSince := time.Now().Add(-idleFor)
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(idleSince) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// RateLimitRepository defines the database operations needed by the PostgreSQL store
+type RateLimitRepository interface {
+	TakeRateLimitToken(ctx context.Context, arg *db.TakeRateLimitTokenParams) (*db.TakeRateLimitTokenRow, error)
+	DeleteIdleRateLimitBuckets(ctx context.Context, idleSince time.Time) (int64, error)
+}
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so that limits apply across replicas
+type PostgresStore struct {
+	repo RateLimitRepository
+}
+
+// NewPostgresStore creates a store backed by the database
+func NewPostgresStore(repo RateLimitRepository) *PostgresStore {
+	return &PostgresStore{repo: repo}
+}
+
+// Take refills and takes a token in a single atomic upsert
+func (s *PostgresStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	row, err := s.repo.TakeRateLimitToken(ctx, &db.TakeRateLimitTokenParams{
+		Key:        key,
+		Burst:      float64(policy.Limit),
+		RefillRate: policy.refillRate(),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return newResult(policy, row.Allowed, row.Tokens), nil
+}
+
+// Sweep deletes buckets that have not been used for idleFor
+func (s *PostgresStore) Sweep(ctx context.Context, idleFor time.Duration) error {
+	_, err := s.repo.DeleteIdleRateLimitBuckets(ctx, time.Now().Add(-idleFor))
+	return err
+}
@@ -0,0 +1,182 @@
+// Package ratelimit implements token bucket rate limiting with in-memory or PostgreSQL-backed buckets
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Keys that buckets of a policy are partitioned by
+const (
+	KeyByToken = "token" // JWT service, checked after authentication
+	KeyByIP    = "ip"
+)
+
+// DefaultPolicyName names the policy applied to routes without a policy of their own
+const DefaultPolicyName = "default"
+
+// Policy allows Limit requests per Period with bursts of up to Limit requests
+type Policy struct {
+	Name   string // Route prefix the policy applies to, e.g. /api/professionals/:id/availability
+	Limit  int
+	Period time.Duration
+	KeyBy  string
+}
+
+// refillRate returns the number of tokens added to a bucket per second
+func (p Policy) refillRate() float64 {
+	return float64(p.Limit) / p.Period.Seconds()
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Whole tokens left in the bucket
+	RetryAfter time.Duration // Time until the next token, zero when allowed
+	ResetAfter time.Duration // Time until the bucket is full again
+}
+
+// newResult derives the result from the tokens left in a bucket
+func newResult(policy Policy, allowed bool, tokens float64) Result {
+	rate := policy.refillRate()
+	result := Result{
+		Allowed:    allowed,
+		Remaining:  max(int(math.Floor(tokens)), 0),
+		ResetAfter: time.Duration((float64(policy.Limit) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return result
+}
+
+// Store keeps the token buckets
+type Store interface {
+	// Take takes a token from the bucket of key, refilled according to the policy
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+	// Sweep deletes buckets that have not been used for idleFor, they are full again by then
+	Sweep(ctx context.Context, idleFor time.Duration) error
+}
+
+// Limiter picks the policy of a route and takes tokens from its buckets
+type Limiter struct {
+	store    Store
+	fallback Policy
+	policies []Policy
+}
+
+// NewLimiter creates a limiter applying the most specific of policies to each route, and
+// fallback to routes that none of them covers
+func NewLimiter(store Store, fallback Policy, policies []Policy) *Limiter {
+	return &Limiter{
+		store:    store,
+		fallback: fallback,
+		policies: policies,
+	}
+}
+
+// Policy returns the policy with the longest name that is a prefix of route
+func (l *Limiter) Policy(route string) Policy {
+	policy, matched := l.fallback, 0
+	for _, p := range l.policies {
+		if strings.HasPrefix(route, p.Name) && len(p.Name) > matched {
+			policy, matched = p, len(p.Name)
+		}
+	}
+	return policy
+}
+
+// Take takes a token from the bucket of the client key under policy, buckets are per policy and client
+func (l *Limiter) Take(ctx context.Context, policy Policy, key string) (Result, error) {
+	return l.store.Take(ctx, policy.Name+":"+key, policy)
+}
+
+// maxPeriod returns the longest period of all policies, after which every bucket is full again
+func (l *Limiter) maxPeriod() time.Duration {
+	period := l.fallback.Period
+	for _, p := range l.policies {
+		period = max(period, p.Period)
+	}
+	return period
+}
+
+// RunSweeper deletes idle buckets until ctx is cancelled, sweeping once per longest policy period
+func RunSweeper(ctx context.Context, limiter *Limiter, logger zerolog.Logger) {
+	ticker := time.NewTicker(limiter.maxPeriod())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := limiter.store.Sweep(ctx, limiter.maxPeriod()); err != nil && ctx.Err() == nil {
+			logger.Error().Err(err).Msg("Failed to sweep rate limit buckets")
+		}
+	}
+}
+
+// ParsePolicy parses a policy of the form <limit>/<period>[:<key>], e.g. 60/1m or 10/1m:ip
+func ParsePolicy(name, value string) (Policy, error) {
+	rate, keyBy, found := strings.Cut(value, ":")
+	if !found {
+		keyBy = KeyByToken
+	}
+	if keyBy != KeyByToken && keyBy != KeyByIP {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: key must be %s or %s", value, KeyByToken, KeyByIP)
+	}
+
+	limitStr, periodStr, found := strings.Cut(rate, "/")
+	if !found {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: expected <limit>/<period>", value)
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: limit must be a positive integer", value)
+	}
+
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: period must be a positive duration", value)
+	}
+
+	return Policy{
+		Name:   name,
+		Limit:  limit,
+		Period: period,
+		KeyBy:  keyBy,
+	}, nil
+}
+
+// ParsePolicies parses a comma separated list of <route prefix>=<policy>, e.g.
+// /api/professionals/:id/availability=60/1m,/api/professionals/sign_in=10/1m:ip
+func ParsePolicies(value string) ([]Policy, error) {
+	var policies []Policy
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, policyStr, found := strings.Cut(entry, "=")
+		if !found || route == "" {
+			return nil, fmt.Errorf("invalid rate limit policy %q: expected <route prefix>=<policy>", entry)
+		}
+
+		policy, err := ParsePolicy(route, policyStr)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
@@ -20,11 +20,12 @@ WITH updated_appointment AS (
         status = 'cancelled',
         cancellation_reason = $3,
         cancelled_by_client_id = $2,
+        late_cancellation = $4,
         updated_at = NOW()
     WHERE appointments.id = $1 
     AND client_id = $2
     AND status IN ('pending', 'confirmed')
-    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description
+    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation
 )
 SELECT 
     ua.id,
@@ -39,6 +40,7 @@ SELECT
     ua.cancelled_by_client_id,
     ua.created_at,
     ua.updated_at,
+    ua.late_cancellation,
     c.id as client_id_full,
     c.first_name as client_first_name,
     c.last_name as client_last_name,
@@ -59,6 +61,7 @@ type CancelAppointmentByClientWithDetailsParams struct {
 	ID                  uuid.UUID      `json:"id"`
 	CancelledByClientID uuid.NullUUID  `json:"cancelled_by_client_id"`
 	CancellationReason  sql.NullString `json:"cancellation_reason"`
+	LateCancellation    bool           `json:"late_cancellation"`
 }
 
 type CancelAppointmentByClientWithDetailsRow struct {
@@ -74,6 +77,7 @@ type CancelAppointmentByClientWithDetailsRow struct {
 	CancelledByClientID       uuid.NullUUID         `json:"cancelled_by_client_id"`
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
+	LateCancellation          bool                  `json:"late_cancellation"`
 	ClientIDFull              uuid.UUID             `json:"client_id_full"`
 	ClientFirstName           sql.NullString        `json:"client_first_name"`
 	ClientLastName            sql.NullString        `json:"client_last_name"`
@@ -88,7 +92,12 @@ type CancelAppointmentByClientWithDetailsRow struct {
 }
 
 func (q *Queries) CancelAppointmentByClientWithDetails(ctx context.Context, arg *CancelAppointmentByClientWithDetailsParams) (*CancelAppointmentByClientWithDetailsRow, error) {
-	row := q.db.QueryRowContext(ctx, CancelAppointmentByClientWithDetails, arg.ID, arg.CancelledByClientID, arg.CancellationReason)
+	row := q.db.QueryRowContext(ctx, CancelAppointmentByClientWithDetails,
+		arg.ID,
+		arg.CancelledByClientID,
+		arg.CancellationReason,
+		arg.LateCancellation,
+	)
 	var i CancelAppointmentByClientWithDetailsRow
 	err := row.Scan(
 		&i.ID,
@@ -103,6 +112,7 @@ func (q *Queries) CancelAppointmentByClientWithDetails(ctx context.Context, arg
 		&i.CancelledByClientID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LateCancellation,
 		&i.ClientIDFull,
 		&i.ClientFirstName,
 		&i.ClientLastName,
@@ -129,7 +139,7 @@ WITH updated_appointment AS (
     WHERE appointments.id = $1 
     AND professional_id = $2
     AND status IN ('pending', 'confirmed')
-    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description
+    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation
 )
 SELECT 
     ua.id,
@@ -228,7 +238,7 @@ WITH updated_appointment AS (
     UPDATE appointments
     SET status = 'confirmed', updated_at = NOW()
     WHERE appointments.id = $1 AND appointments.professional_id = $2
-    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description
+    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation
 )
 SELECT 
     ua.id,
@@ -306,11 +316,11 @@ func (q *Queries) ConfirmAppointmentWithDetails(ctx context.Context, arg *Confir
 const CreateAppointmentWithDetails = `-- name: CreateAppointmentWithDetails :one
 WITH new_appointment AS (
     INSERT INTO appointments (type, client_id, professional_id, start_time, end_time, status, description)
-    VALUES ('appointment', $1, $2, $3, $4, 'pending', $5)
-    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description
+    VALUES ('appointment', $1, $2, $3, $4, $5, $6)
+    RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation
 )
 SELECT 
-    na.id, na.type, na.client_id, na.professional_id, na.start_time, na.end_time, na.status, na.cancellation_reason, na.cancelled_by_professional_id, na.cancelled_by_client_id, na.created_at, na.updated_at, na.description,
+    na.id, na.type, na.client_id, na.professional_id, na.start_time, na.end_time, na.status, na.cancellation_reason, na.cancelled_by_professional_id, na.cancelled_by_client_id, na.created_at, na.updated_at, na.description, na.late_cancellation,
     c.id as client_id_full,
     c.first_name as client_first_name,
     c.last_name as client_last_name,
@@ -332,11 +342,12 @@ LEFT JOIN professionals p ON p.id = na.professional_id
 `
 
 type CreateAppointmentWithDetailsParams struct {
-	ClientID       uuid.NullUUID  `json:"client_id"`
-	ProfessionalID uuid.UUID      `json:"professional_id"`
-	StartTime      time.Time      `json:"start_time"`
-	EndTime        time.Time      `json:"end_time"`
-	Description    sql.NullString `json:"description"`
+	ClientID       uuid.NullUUID         `json:"client_id"`
+	ProfessionalID uuid.UUID             `json:"professional_id"`
+	StartTime      time.Time             `json:"start_time"`
+	EndTime        time.Time             `json:"end_time"`
+	Status         NullAppointmentStatus `json:"status"`
+	Description    sql.NullString        `json:"description"`
 }
 
 type CreateAppointmentWithDetailsRow struct {
@@ -353,6 +364,7 @@ type CreateAppointmentWithDetailsRow struct {
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
 	Description               sql.NullString        `json:"description"`
+	LateCancellation          bool                  `json:"late_cancellation"`
 	ClientIDFull              uuid.UUID             `json:"client_id_full"`
 	ClientFirstName           sql.NullString        `json:"client_first_name"`
 	ClientLastName            sql.NullString        `json:"client_last_name"`
@@ -376,6 +388,7 @@ func (q *Queries) CreateAppointmentWithDetails(ctx context.Context, arg *CreateA
 		arg.ProfessionalID,
 		arg.StartTime,
 		arg.EndTime,
+		arg.Status,
 		arg.Description,
 	)
 	var i CreateAppointmentWithDetailsRow
@@ -393,6 +406,7 @@ func (q *Queries) CreateAppointmentWithDetails(ctx context.Context, arg *CreateA
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Description,
+		&i.LateCancellation,
 		&i.ClientIDFull,
 		&i.ClientFirstName,
 		&i.ClientLastName,
@@ -415,7 +429,7 @@ func (q *Queries) CreateAppointmentWithDetails(ctx context.Context, arg *CreateA
 const CreateUnavailableAppointment = `-- name: CreateUnavailableAppointment :one
 INSERT INTO appointments (type, professional_id, start_time, end_time, status, description)
 VALUES ('unavailable', $1, $2, $3, 'confirmed', $4)
-RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description
+RETURNING id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation
 `
 
 type CreateUnavailableAppointmentParams struct {
@@ -447,12 +461,13 @@ func (q *Queries) CreateUnavailableAppointment(ctx context.Context, arg *CreateU
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Description,
+		&i.LateCancellation,
 	)
 	return &i, err
 }
 
 const GetAppointmentByID = `-- name: GetAppointmentByID :one
-SELECT id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description FROM appointments
+SELECT id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation FROM appointments
 WHERE appointments.id = $1
 `
 
@@ -473,13 +488,42 @@ func (q *Queries) GetAppointmentByID(ctx context.Context, id uuid.UUID) (*Appoin
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Description,
+		&i.LateCancellation,
+	)
+	return &i, err
+}
+
+const GetAppointmentByIDForUpdate = `-- name: GetAppointmentByIDForUpdate :one
+SELECT id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation FROM appointments
+WHERE appointments.id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetAppointmentByIDForUpdate(ctx context.Context, id uuid.UUID) (*Appointment, error) {
+	row := q.db.QueryRowContext(ctx, GetAppointmentByIDForUpdate, id)
+	var i Appointment
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.ClientID,
+		&i.ProfessionalID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Status,
+		&i.CancellationReason,
+		&i.CancelledByProfessionalID,
+		&i.CancelledByClientID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Description,
+		&i.LateCancellation,
 	)
 	return &i, err
 }
 
 const GetAppointmentsByClientWithStatus = `-- name: GetAppointmentsByClientWithStatus :many
 SELECT 
-    a.id, a.type, a.client_id, a.professional_id, a.start_time, a.end_time, a.status, a.cancellation_reason, a.cancelled_by_professional_id, a.cancelled_by_client_id, a.created_at, a.updated_at, a.description,
+    a.id, a.type, a.client_id, a.professional_id, a.start_time, a.end_time, a.status, a.cancellation_reason, a.cancelled_by_professional_id, a.cancelled_by_client_id, a.created_at, a.updated_at, a.description, a.late_cancellation,
     c.id AS client_id_full,
     c.first_name AS client_first_name,
     c.last_name AS client_last_name,
@@ -520,6 +564,7 @@ type GetAppointmentsByClientWithStatusRow struct {
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
 	Description               sql.NullString        `json:"description"`
+	LateCancellation          bool                  `json:"late_cancellation"`
 	ClientIDFull              uuid.UUID             `json:"client_id_full"`
 	ClientFirstName           sql.NullString        `json:"client_first_name"`
 	ClientLastName            sql.NullString        `json:"client_last_name"`
@@ -556,6 +601,7 @@ func (q *Queries) GetAppointmentsByClientWithStatus(ctx context.Context, arg *Ge
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Description,
+			&i.LateCancellation,
 			&i.ClientIDFull,
 			&i.ClientFirstName,
 			&i.ClientLastName,
@@ -582,7 +628,7 @@ func (q *Queries) GetAppointmentsByClientWithStatus(ctx context.Context, arg *Ge
 }
 
 const GetAppointmentsByProfessionalAndDate = `-- name: GetAppointmentsByProfessionalAndDate :many
-SELECT id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description FROM appointments
+SELECT id, type, client_id, professional_id, start_time, end_time, status, cancellation_reason, cancelled_by_professional_id, cancelled_by_client_id, created_at, updated_at, description, late_cancellation FROM appointments
 WHERE professional_id = $1
   AND DATE(start_time) = $2
   AND type = 'appointment' or type = 'unavailable'
@@ -618,6 +664,7 @@ func (q *Queries) GetAppointmentsByProfessionalAndDate(ctx context.Context, arg
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Description,
+			&i.LateCancellation,
 		); err != nil {
 			return nil, err
 		}
@@ -651,7 +698,7 @@ LEFT JOIN clients c ON a.client_id = c.id
 WHERE a.professional_id = $1
   AND DATE(a.start_time) = $2
   AND (a.type = 'appointment' OR a.type = 'unavailable')
-  AND a.status NOT IN ('cancelled', 'pending')
+  AND a.status IS DISTINCT FROM 'cancelled'
 ORDER BY a.start_time ASC
 `
 
@@ -713,7 +760,7 @@ func (q *Queries) GetAppointmentsByProfessionalAndDateWithClient(ctx context.Con
 
 const GetAppointmentsByProfessionalWithStatus = `-- name: GetAppointmentsByProfessionalWithStatus :many
 SELECT 
-    a.id, a.type, a.client_id, a.professional_id, a.start_time, a.end_time, a.status, a.cancellation_reason, a.cancelled_by_professional_id, a.cancelled_by_client_id, a.created_at, a.updated_at, a.description,
+    a.id, a.type, a.client_id, a.professional_id, a.start_time, a.end_time, a.status, a.cancellation_reason, a.cancelled_by_professional_id, a.cancelled_by_client_id, a.created_at, a.updated_at, a.description, a.late_cancellation,
     c.id AS client_id,
     c.first_name AS client_first_name,
     c.last_name AS client_last_name,
@@ -747,6 +794,7 @@ type GetAppointmentsByProfessionalWithStatusRow struct {
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
 	Description               sql.NullString        `json:"description"`
+	LateCancellation          bool                  `json:"late_cancellation"`
 	ClientID_2                uuid.UUID             `json:"client_id_2"`
 	ClientFirstName           sql.NullString        `json:"client_first_name"`
 	ClientLastName            sql.NullString        `json:"client_last_name"`
@@ -777,6 +825,7 @@ func (q *Queries) GetAppointmentsByProfessionalWithStatus(ctx context.Context, a
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Description,
+			&i.LateCancellation,
 			&i.ClientID_2,
 			&i.ClientFirstName,
 			&i.ClientLastName,
@@ -836,6 +885,46 @@ func (q *Queries) GetProfessionalAppointmentDates(ctx context.Context, arg *GetP
 	return items, nil
 }
 
+const GetProfessionalAppointmentIDsByDate = `-- name: GetProfessionalAppointmentIDsByDate :many
+SELECT id FROM appointments
+WHERE professional_id = $1
+  AND type = 'appointment'
+  AND status IN ('pending', 'confirmed')
+  AND ($2::appointment_status IS NULL OR status = $2::appointment_status)
+  AND DATE(start_time) = $3::date
+  AND start_time > NOW()
+ORDER BY id ASC
+`
+
+type GetProfessionalAppointmentIDsByDateParams struct {
+	ProfessionalID uuid.UUID             `json:"professional_id"`
+	Status         NullAppointmentStatus `json:"status"`
+	Date           time.Time             `json:"date"`
+}
+
+func (q *Queries) GetProfessionalAppointmentIDsByDate(ctx context.Context, arg *GetProfessionalAppointmentIDsByDateParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, GetProfessionalAppointmentIDsByDate, arg.ProfessionalID, arg.Status, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetProfessionalTimetable = `-- name: GetProfessionalTimetable :many
 SELECT 
     a.id,
@@ -13,9 +13,9 @@ import (
 )
 
 const CreateClient = `-- name: CreateClient :one
-INSERT INTO clients (first_name, last_name, phone_number, chat_id, created_by)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, chat_id, first_name, last_name, phone_number, created_by, created_at, updated_at
+INSERT INTO clients (first_name, last_name, phone_number, chat_id, created_by, language)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, chat_id, first_name, last_name, phone_number, created_by, created_at, updated_at, language
 `
 
 type CreateClientParams struct {
@@ -24,6 +24,7 @@ type CreateClientParams struct {
 	PhoneNumber sql.NullString `json:"phone_number"`
 	ChatID      sql.NullInt64  `json:"chat_id"`
 	CreatedBy   uuid.NullUUID  `json:"created_by"`
+	Language    sql.NullString `json:"language"`
 }
 
 func (q *Queries) CreateClient(ctx context.Context, arg *CreateClientParams) (*Client, error) {
@@ -33,6 +34,7 @@ func (q *Queries) CreateClient(ctx context.Context, arg *CreateClientParams) (*C
 		arg.PhoneNumber,
 		arg.ChatID,
 		arg.CreatedBy,
+		arg.Language,
 	)
 	var i Client
 	err := row.Scan(
@@ -44,6 +46,50 @@ func (q *Queries) CreateClient(ctx context.Context, arg *CreateClientParams) (*C
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Language,
 	)
 	return &i, err
 }
+
+const GetClientLanguage = `-- name: GetClientLanguage :one
+SELECT language FROM clients
+WHERE id = $1
+`
+
+func (q *Queries) GetClientLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, GetClientLanguage, id)
+	var language sql.NullString
+	err := row.Scan(&language)
+	return language, err
+}
+
+const LockClient = `-- name: LockClient :one
+SELECT id FROM clients
+WHERE id = $1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) LockClient(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, LockClient, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const UpdateClientLanguageByChatID = `-- name: UpdateClientLanguageByChatID :execrows
+UPDATE clients
+SET language = $2, updated_at = NOW()
+WHERE chat_id = $1
+`
+
+type UpdateClientLanguageByChatIDParams struct {
+	ChatID   sql.NullInt64  `json:"chat_id"`
+	Language sql.NullString `json:"language"`
+}
+
+func (q *Queries) UpdateClientLanguageByChatID(ctx context.Context, arg *UpdateClientLanguageByChatIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, UpdateClientLanguageByChatID, arg.ChatID, arg.Language)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes of constraint violations
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+// Errors of queries, translated from sql.ErrNoRows and constraint violations by TranslateError.
+// The not found errors all match ErrNotFound with errors.Is.
+var (
+	ErrNotFound             = errors.New("not found")
+	ErrAppointmentNotFound  = fmt.Errorf("appointment %w", ErrNotFound)
+	ErrProfessionalNotFound = fmt.Errorf("professional %w", ErrNotFound)
+	ErrClientNotFound       = fmt.Errorf("client %w", ErrNotFound)
+	ErrAlreadyExists        = errors.New("already exists")
+)
+
+// foreignKeyNotFound maps foreign key constraints to the not found error of the referenced row
+var foreignKeyNotFound = map[string]error{
+	"clients_created_by_fkey":                        ErrProfessionalNotFound,
+	"appointments_client_id_fkey":                    ErrClientNotFound,
+	"appointments_professional_id_fkey":              ErrProfessionalNotFound,
+	"appointments_cancelled_by_professional_id_fkey": ErrProfessionalNotFound,
+	"appointments_cancelled_by_client_id_fkey":       ErrClientNotFound,
+	"booking_rules_professional_id_fkey":             ErrProfessionalNotFound,
+	"calendar_feeds_professional_id_fkey":            ErrProfessionalNotFound,
+	"cancellation_policies_professional_id_fkey":     ErrProfessionalNotFound,
+	"calendar_feeds_client_id_fkey":                  ErrClientNotFound,
+	"external_calendars_professional_id_fkey":        ErrProfessionalNotFound,
+	"slot_holds_client_id_fkey":                      ErrClientNotFound,
+	"slot_holds_professional_id_fkey":                ErrProfessionalNotFound,
+	"waitlist_entries_client_id_fkey":                ErrClientNotFound,
+	"waitlist_entries_professional_id_fkey":          ErrProfessionalNotFound,
+}
+
+// TranslateError translates the error of a query: sql.ErrNoRows to notFound, foreign key violations
+// to the not found error of the referenced row and unique violations to ErrAlreadyExists. The original
+// error stays in the chain, other errors are returned unchanged. notFound may be nil for queries
+// that always return a row.
+func TranslateError(err error, notFound error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) && notFound != nil {
+		return fmt.Errorf("%w: %w", notFound, err)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+		case pqForeignKeyViolation:
+			if referenced, ok := foreignKeyNotFound[pqErr.Constraint]; ok {
+				return fmt.Errorf("%w: %w", referenced, err)
+			}
+		}
+	}
+
+	return err
+}
@@ -7,6 +7,7 @@ package db
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -135,6 +136,134 @@ func AllAppointmentTypeValues() []AppointmentType {
 	}
 }
 
+type AutoConfirmMode string
+
+const (
+	AutoConfirmModeOff              AutoConfirmMode = "off"
+	AutoConfirmModeAll              AutoConfirmMode = "all"
+	AutoConfirmModeReturningClients AutoConfirmMode = "returning_clients"
+)
+
+func (e *AutoConfirmMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AutoConfirmMode(s)
+	case string:
+		*e = AutoConfirmMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AutoConfirmMode: %T", src)
+	}
+	return nil
+}
+
+type NullAutoConfirmMode struct {
+	AutoConfirmMode AutoConfirmMode `json:"auto_confirm_mode"`
+	Valid           bool            `json:"valid"` // Valid is true if AutoConfirmMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAutoConfirmMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.AutoConfirmMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AutoConfirmMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAutoConfirmMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AutoConfirmMode), nil
+}
+
+func (e AutoConfirmMode) Valid() bool {
+	switch e {
+	case AutoConfirmModeOff,
+		AutoConfirmModeAll,
+		AutoConfirmModeReturningClients:
+		return true
+	}
+	return false
+}
+
+func AllAutoConfirmModeValues() []AutoConfirmMode {
+	return []AutoConfirmMode{
+		AutoConfirmModeOff,
+		AutoConfirmModeAll,
+		AutoConfirmModeReturningClients,
+	}
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusBooked    WaitlistStatus = "booked"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusCancelled WaitlistStatus = "cancelled"
+)
+
+func (e *WaitlistStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WaitlistStatus(s)
+	case string:
+		*e = WaitlistStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WaitlistStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWaitlistStatus struct {
+	WaitlistStatus WaitlistStatus `json:"waitlist_status"`
+	Valid          bool           `json:"valid"` // Valid is true if WaitlistStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWaitlistStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WaitlistStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WaitlistStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWaitlistStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WaitlistStatus), nil
+}
+
+func (e WaitlistStatus) Valid() bool {
+	switch e {
+	case WaitlistStatusWaiting,
+		WaitlistStatusOffered,
+		WaitlistStatusBooked,
+		WaitlistStatusExpired,
+		WaitlistStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func AllWaitlistStatusValues() []WaitlistStatus {
+	return []WaitlistStatus{
+		WaitlistStatusWaiting,
+		WaitlistStatusOffered,
+		WaitlistStatusBooked,
+		WaitlistStatusExpired,
+		WaitlistStatusCancelled,
+	}
+}
+
 type Appointment struct {
 	ID                        uuid.UUID             `json:"id"`
 	Type                      AppointmentType       `json:"type"`
@@ -149,6 +278,46 @@ type Appointment struct {
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
 	Description               sql.NullString        `json:"description"`
+	LateCancellation          bool                  `json:"late_cancellation"`
+}
+
+type AppointmentEvent struct {
+	ID             int64           `json:"id"`
+	EventType      string          `json:"event_type"`
+	AppointmentID  uuid.UUID       `json:"appointment_id"`
+	ProfessionalID uuid.UUID       `json:"professional_id"`
+	ClientID       uuid.NullUUID   `json:"client_id"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type BookingRule struct {
+	ProfessionalID         uuid.UUID       `json:"professional_id"`
+	MinNoticeHours         int32           `json:"min_notice_hours"`
+	MaxAdvanceDays         sql.NullInt32   `json:"max_advance_days"`
+	MaxDailyPerClient      sql.NullInt32   `json:"max_daily_per_client"`
+	MaxPendingPerClient    sql.NullInt32   `json:"max_pending_per_client"`
+	CreatedAt              time.Time       `json:"created_at"`
+	UpdatedAt              time.Time       `json:"updated_at"`
+	AutoConfirm            AutoConfirmMode `json:"auto_confirm"`
+	AllowTentativeRequests bool            `json:"allow_tentative_requests"`
+}
+
+type CalendarFeed struct {
+	ID             uuid.UUID     `json:"id"`
+	ProfessionalID uuid.NullUUID `json:"professional_id"`
+	ClientID       uuid.NullUUID `json:"client_id"`
+	Token          string        `json:"token"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type CancellationPolicy struct {
+	ProfessionalID        uuid.UUID `json:"professional_id"`
+	MinNoticeHours        int32     `json:"min_notice_hours"`
+	AllowLateCancellation bool      `json:"allow_late_cancellation"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
 }
 
 type Client struct {
@@ -160,6 +329,41 @@ type Client struct {
 	CreatedBy   uuid.NullUUID  `json:"created_by"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	Language    sql.NullString `json:"language"`
+}
+
+type ExternalBusyBlock struct {
+	ID                 uuid.UUID      `json:"id"`
+	ExternalCalendarID uuid.UUID      `json:"external_calendar_id"`
+	ProfessionalID     uuid.UUID      `json:"professional_id"`
+	Uid                string         `json:"uid"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Summary            sql.NullString `json:"summary"`
+	CreatedAt          time.Time      `json:"created_at"`
+}
+
+type ExternalCalendar struct {
+	ID             uuid.UUID      `json:"id"`
+	ProfessionalID uuid.UUID      `json:"professional_id"`
+	Name           string         `json:"name"`
+	Url            sql.NullString `json:"url"`
+	LastSyncedAt   sql.NullTime   `json:"last_synced_at"`
+	LastSyncError  sql.NullString `json:"last_sync_error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type IdempotencyKey struct {
+	Scope               string          `json:"scope"`
+	Key                 string          `json:"key"`
+	RequestHash         string          `json:"request_hash"`
+	ResponseStatus      sql.NullInt32   `json:"response_status"`
+	ResponseContentType sql.NullString  `json:"response_content_type"`
+	ResponseBody        []byte          `json:"response_body"`
+	CreatedAt           time.Time       `json:"created_at"`
+	ExpiresAt           time.Time       `json:"expires_at"`
+	ResponseHeaders     json.RawMessage `json:"response_headers"`
 }
 
 type Professional struct {
@@ -172,4 +376,31 @@ type Professional struct {
 	PasswordHash sql.NullString `json:"password_hash"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
+	Language     sql.NullString `json:"language"`
+}
+
+type SlotHold struct {
+	ID             uuid.UUID `json:"id"`
+	ProfessionalID uuid.UUID `json:"professional_id"`
+	ClientID       uuid.UUID `json:"client_id"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type WaitlistEntry struct {
+	ID                   uuid.UUID      `json:"id"`
+	ClientID             uuid.UUID      `json:"client_id"`
+	ProfessionalID       uuid.UUID      `json:"professional_id"`
+	WindowStart          time.Time      `json:"window_start"`
+	WindowEnd            time.Time      `json:"window_end"`
+	Status               WaitlistStatus `json:"status"`
+	OfferedAppointmentID uuid.NullUUID  `json:"offered_appointment_id"`
+	OfferedStartTime     sql.NullTime   `json:"offered_start_time"`
+	OfferedEndTime       sql.NullTime   `json:"offered_end_time"`
+	OfferExpiresAt       sql.NullTime   `json:"offer_expires_at"`
+	AppointmentID        uuid.NullUUID  `json:"appointment_id"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
 }
@@ -16,7 +16,7 @@ import (
 const CreateProfessional = `-- name: CreateProfessional :one
 INSERT INTO professionals (username, first_name, last_name, phone_number, password_hash, chat_id)
 VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at
+RETURNING id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at, language
 `
 
 type CreateProfessionalParams struct {
@@ -48,6 +48,7 @@ func (q *Queries) CreateProfessional(ctx context.Context, arg *CreateProfessiona
 		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Language,
 	)
 	return &i, err
 }
@@ -65,7 +66,20 @@ SELECT
     a.client_id,
     c.first_name as client_first_name,
     c.last_name as client_last_name,
-    c.phone_number as client_phone_number
+    c.phone_number as client_phone_number,
+    (
+        SELECT COUNT(*) FROM appointments lc
+        WHERE lc.client_id = a.client_id AND lc.late_cancellation
+    ) AS client_late_cancellations,
+    (
+        SELECT COUNT(*) FROM appointments cr
+        WHERE cr.professional_id = a.professional_id
+          AND cr.id <> a.id
+          AND cr.type = 'appointment'
+          AND cr.status = 'pending'
+          AND cr.start_time < a.end_time
+          AND cr.end_time > a.start_time
+    ) AS competing_requests
 FROM appointments a
 LEFT JOIN clients c ON a.client_id = c.id
 WHERE a.professional_id = $1
@@ -83,18 +97,20 @@ type GetAppointmentsByProfessionalWithStatusAndDateParams struct {
 }
 
 type GetAppointmentsByProfessionalWithStatusAndDateRow struct {
-	ID                uuid.UUID             `json:"id"`
-	Type              AppointmentType       `json:"type"`
-	StartTime         time.Time             `json:"start_time"`
-	EndTime           time.Time             `json:"end_time"`
-	Description       sql.NullString        `json:"description"`
-	Status            NullAppointmentStatus `json:"status"`
-	CreatedAt         time.Time             `json:"created_at"`
-	UpdatedAt         time.Time             `json:"updated_at"`
-	ClientID          uuid.NullUUID         `json:"client_id"`
-	ClientFirstName   sql.NullString        `json:"client_first_name"`
-	ClientLastName    sql.NullString        `json:"client_last_name"`
-	ClientPhoneNumber sql.NullString        `json:"client_phone_number"`
+	ID                      uuid.UUID             `json:"id"`
+	Type                    AppointmentType       `json:"type"`
+	StartTime               time.Time             `json:"start_time"`
+	EndTime                 time.Time             `json:"end_time"`
+	Description             sql.NullString        `json:"description"`
+	Status                  NullAppointmentStatus `json:"status"`
+	CreatedAt               time.Time             `json:"created_at"`
+	UpdatedAt               time.Time             `json:"updated_at"`
+	ClientID                uuid.NullUUID         `json:"client_id"`
+	ClientFirstName         sql.NullString        `json:"client_first_name"`
+	ClientLastName          sql.NullString        `json:"client_last_name"`
+	ClientPhoneNumber       sql.NullString        `json:"client_phone_number"`
+	ClientLateCancellations int64                 `json:"client_late_cancellations"`
+	CompetingRequests       int64                 `json:"competing_requests"`
 }
 
 func (q *Queries) GetAppointmentsByProfessionalWithStatusAndDate(ctx context.Context, arg *GetAppointmentsByProfessionalWithStatusAndDateParams) ([]*GetAppointmentsByProfessionalWithStatusAndDateRow, error) {
@@ -119,6 +135,8 @@ func (q *Queries) GetAppointmentsByProfessionalWithStatusAndDate(ctx context.Con
 			&i.ClientFirstName,
 			&i.ClientLastName,
 			&i.ClientPhoneNumber,
+			&i.ClientLateCancellations,
+			&i.CompetingRequests,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +152,7 @@ func (q *Queries) GetAppointmentsByProfessionalWithStatusAndDate(ctx context.Con
 }
 
 const GetProfessionalByUsername = `-- name: GetProfessionalByUsername :one
-SELECT id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at FROM professionals
+SELECT id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at, language FROM professionals
 WHERE username = $1
 `
 
@@ -151,12 +169,25 @@ func (q *Queries) GetProfessionalByUsername(ctx context.Context, username string
 		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Language,
 	)
 	return &i, err
 }
 
+const GetProfessionalLanguage = `-- name: GetProfessionalLanguage :one
+SELECT language FROM professionals
+WHERE id = $1
+`
+
+func (q *Queries) GetProfessionalLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, GetProfessionalLanguage, id)
+	var language sql.NullString
+	err := row.Scan(&language)
+	return language, err
+}
+
 const GetProfessionals = `-- name: GetProfessionals :many
-SELECT id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at FROM professionals
+SELECT id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at, language FROM professionals
 WHERE chat_id is not null
 ORDER BY created_at DESC
 `
@@ -180,6 +211,7 @@ func (q *Queries) GetProfessionals(ctx context.Context) ([]*Professional, error)
 			&i.PasswordHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Language,
 		); err != nil {
 			return nil, err
 		}
@@ -194,11 +226,23 @@ func (q *Queries) GetProfessionals(ctx context.Context) ([]*Professional, error)
 	return items, nil
 }
 
+const LockProfessional = `-- name: LockProfessional :one
+SELECT id FROM professionals
+WHERE id = $1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, LockProfessional, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
 const UpdateProfessionalChatID = `-- name: UpdateProfessionalChatID :one
 UPDATE professionals
 SET chat_id = $2
 WHERE id = $1
-RETURNING id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at
+RETURNING id, chat_id, first_name, last_name, phone_number, username, password_hash, created_at, updated_at, language
 `
 
 type UpdateProfessionalChatIDParams struct {
@@ -219,6 +263,26 @@ func (q *Queries) UpdateProfessionalChatID(ctx context.Context, arg *UpdateProfe
 		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Language,
 	)
 	return &i, err
 }
+
+const UpdateProfessionalLanguageByChatID = `-- name: UpdateProfessionalLanguageByChatID :execrows
+UPDATE professionals
+SET language = $2, updated_at = NOW()
+WHERE chat_id = $1
+`
+
+type UpdateProfessionalLanguageByChatIDParams struct {
+	ChatID   sql.NullInt64  `json:"chat_id"`
+	Language sql.NullString `json:"language"`
+}
+
+func (q *Queries) UpdateProfessionalLanguageByChatID(ctx context.Context, arg *UpdateProfessionalLanguageByChatIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, UpdateProfessionalLanguageByChatID, arg.ChatID, arg.Language)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
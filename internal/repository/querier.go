@@ -13,25 +13,103 @@ import (
 )
 
 type Querier interface {
+	AcquireIdempotencyKey(ctx context.Context, arg *AcquireIdempotencyKeyParams) (*IdempotencyKey, error)
+	BookWaitlistEntry(ctx context.Context, arg *BookWaitlistEntryParams) (*WaitlistEntry, error)
 	CancelAppointmentByClientWithDetails(ctx context.Context, arg *CancelAppointmentByClientWithDetailsParams) (*CancelAppointmentByClientWithDetailsRow, error)
 	CancelAppointmentByProfessionalWithDetails(ctx context.Context, arg *CancelAppointmentByProfessionalWithDetailsParams) (*CancelAppointmentByProfessionalWithDetailsRow, error)
+	CancelWaitlistEntry(ctx context.Context, id uuid.UUID) (*WaitlistEntry, error)
+	CompleteIdempotencyKey(ctx context.Context, arg *CompleteIdempotencyKeyParams) error
 	ConfirmAppointmentWithDetails(ctx context.Context, arg *ConfirmAppointmentWithDetailsParams) (*ConfirmAppointmentWithDetailsRow, error)
+	CountClientAppointmentsInRange(ctx context.Context, arg *CountClientAppointmentsInRangeParams) (int64, error)
+	CountClientPendingAppointments(ctx context.Context, arg *CountClientPendingAppointmentsParams) (int64, error)
+	CountClientsCreatedBefore(ctx context.Context, createdBefore time.Time) (int32, error)
+	CountOverlappingSlotHolds(ctx context.Context, arg *CountOverlappingSlotHoldsParams) (int64, error)
+	CountOverlappingWaitlistOffers(ctx context.Context, arg *CountOverlappingWaitlistOffersParams) (int64, error)
+	CreateAppointmentEvent(ctx context.Context, arg *CreateAppointmentEventParams) (*AppointmentEvent, error)
 	CreateAppointmentWithDetails(ctx context.Context, arg *CreateAppointmentWithDetailsParams) (*CreateAppointmentWithDetailsRow, error)
 	CreateClient(ctx context.Context, arg *CreateClientParams) (*Client, error)
+	CreateExternalCalendar(ctx context.Context, arg *CreateExternalCalendarParams) (*ExternalCalendar, error)
+	CreateImportedAppointment(ctx context.Context, arg *CreateImportedAppointmentParams) (*Appointment, error)
 	CreateProfessional(ctx context.Context, arg *CreateProfessionalParams) (*Professional, error)
+	CreateSlotHold(ctx context.Context, arg *CreateSlotHoldParams) (*SlotHold, error)
 	CreateUnavailableAppointment(ctx context.Context, arg *CreateUnavailableAppointmentParams) (*Appointment, error)
+	CreateWaitlistEntry(ctx context.Context, arg *CreateWaitlistEntryParams) (*WaitlistEntry, error)
+	DeleteClientSlotHolds(ctx context.Context, arg *DeleteClientSlotHoldsParams) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	DeleteExpiredSlotHolds(ctx context.Context) (int64, error)
+	DeleteExternalCalendar(ctx context.Context, arg *DeleteExternalCalendarParams) (int64, error)
+	DeleteIdempotencyKey(ctx context.Context, arg *DeleteIdempotencyKeyParams) error
+	DeleteIdleRateLimitBuckets(ctx context.Context, idleSince time.Time) (int64, error)
+	DeleteSlotHold(ctx context.Context, id uuid.UUID) error
+	ExpireWaitlistEntries(ctx context.Context) (int64, error)
+	ExpireWaitlistOffers(ctx context.Context) ([]*WaitlistEntry, error)
+	GetActiveAppointmentsByProfessionalInRange(ctx context.Context, arg *GetActiveAppointmentsByProfessionalInRangeParams) ([]*Appointment, error)
+	GetActiveSlotHoldsByProfessionalAndRange(ctx context.Context, arg *GetActiveSlotHoldsByProfessionalAndRangeParams) ([]*SlotHold, error)
+	GetActiveWaitlistEntriesByClient(ctx context.Context, clientID uuid.UUID) ([]*WaitlistEntry, error)
 	GetAppointmentByID(ctx context.Context, id uuid.UUID) (*Appointment, error)
+	GetAppointmentByIDForUpdate(ctx context.Context, id uuid.UUID) (*Appointment, error)
+	GetAppointmentEvent(ctx context.Context, id int64) (*AppointmentEvent, error)
 	GetAppointmentsByClientWithStatus(ctx context.Context, arg *GetAppointmentsByClientWithStatusParams) ([]*GetAppointmentsByClientWithStatusRow, error)
 	GetAppointmentsByProfessionalAndDate(ctx context.Context, arg *GetAppointmentsByProfessionalAndDateParams) ([]*Appointment, error)
 	GetAppointmentsByProfessionalAndDateWithClient(ctx context.Context, arg *GetAppointmentsByProfessionalAndDateWithClientParams) ([]*GetAppointmentsByProfessionalAndDateWithClientRow, error)
 	GetAppointmentsByProfessionalWithStatus(ctx context.Context, arg *GetAppointmentsByProfessionalWithStatusParams) ([]*GetAppointmentsByProfessionalWithStatusRow, error)
 	GetAppointmentsByProfessionalWithStatusAndDate(ctx context.Context, arg *GetAppointmentsByProfessionalWithStatusAndDateParams) ([]*GetAppointmentsByProfessionalWithStatusAndDateRow, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*BookingRule, error)
+	GetBookingTotals(ctx context.Context, arg *GetBookingTotalsParams) ([]*GetBookingTotalsRow, error)
+	GetCalendarFeedByToken(ctx context.Context, token string) (*CalendarFeed, error)
+	GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*CancellationPolicy, error)
+	GetCancellationReasons(ctx context.Context, arg *GetCancellationReasonsParams) ([]*GetCancellationReasonsRow, error)
+	GetCancelledAppointmentsToOffer(ctx context.Context) ([]uuid.UUID, error)
+	GetClientCalendarAppointments(ctx context.Context, arg *GetClientCalendarAppointmentsParams) ([]*GetClientCalendarAppointmentsRow, error)
+	GetClientEventsAfter(ctx context.Context, arg *GetClientEventsAfterParams) ([]*AppointmentEvent, error)
+	GetClientLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error)
+	GetClientsByPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]*Client, error)
+	GetConfirmationLatency(ctx context.Context, arg *GetConfirmationLatencyParams) (*GetConfirmationLatencyRow, error)
+	GetExternalBusyBlocksByProfessionalAndRange(ctx context.Context, arg *GetExternalBusyBlocksByProfessionalAndRangeParams) ([]*ExternalBusyBlock, error)
+	GetExternalCalendarByID(ctx context.Context, id uuid.UUID) (*ExternalCalendar, error)
+	GetExternalCalendarsByProfessional(ctx context.Context, professionalID uuid.UUID) ([]*ExternalCalendar, error)
+	GetExternalCalendarsToSync(ctx context.Context) ([]*ExternalCalendar, error)
+	GetIdempotencyKey(ctx context.Context, arg *GetIdempotencyKeyParams) (*IdempotencyKey, error)
+	GetNewClientsByMonth(ctx context.Context, arg *GetNewClientsByMonthParams) ([]*GetNewClientsByMonthRow, error)
+	GetNextWaitlistEntryForSlot(ctx context.Context, arg *GetNextWaitlistEntryForSlotParams) (*WaitlistEntry, error)
+	GetOpenWaitlistOffersByProfessionalAndRange(ctx context.Context, arg *GetOpenWaitlistOffersByProfessionalAndRangeParams) ([]*WaitlistEntry, error)
 	GetProfessionalAppointmentDates(ctx context.Context, arg *GetProfessionalAppointmentDatesParams) ([]time.Time, error)
+	GetProfessionalAppointmentIDsByDate(ctx context.Context, arg *GetProfessionalAppointmentIDsByDateParams) ([]uuid.UUID, error)
+	GetProfessionalAppointmentsForExport(ctx context.Context, arg *GetProfessionalAppointmentsForExportParams) ([]*GetProfessionalAppointmentsForExportRow, error)
+	GetProfessionalBusiestHours(ctx context.Context, arg *GetProfessionalBusiestHoursParams) ([]*GetProfessionalBusiestHoursRow, error)
+	GetProfessionalBusiestWeekdays(ctx context.Context, arg *GetProfessionalBusiestWeekdaysParams) ([]*GetProfessionalBusiestWeekdaysRow, error)
 	GetProfessionalByUsername(ctx context.Context, username string) (*Professional, error)
+	GetProfessionalCalendarAppointments(ctx context.Context, arg *GetProfessionalCalendarAppointmentsParams) ([]*GetProfessionalCalendarAppointmentsRow, error)
+	GetProfessionalClientRetention(ctx context.Context, arg *GetProfessionalClientRetentionParams) (*GetProfessionalClientRetentionRow, error)
+	GetProfessionalEventsAfter(ctx context.Context, arg *GetProfessionalEventsAfterParams) ([]*AppointmentEvent, error)
+	GetProfessionalLanguage(ctx context.Context, id uuid.UUID) (sql.NullString, error)
+	GetProfessionalStatsBuckets(ctx context.Context, arg *GetProfessionalStatsBucketsParams) ([]*GetProfessionalStatsBucketsRow, error)
+	GetProfessionalStatsSummary(ctx context.Context, arg *GetProfessionalStatsSummaryParams) (*GetProfessionalStatsSummaryRow, error)
 	GetProfessionalTimetable(ctx context.Context, arg *GetProfessionalTimetableParams) ([]*GetProfessionalTimetableRow, error)
 	GetProfessionals(ctx context.Context) ([]*Professional, error)
+	GetSlotHoldByIDForUpdate(ctx context.Context, id uuid.UUID) (*SlotHold, error)
+	GetTopProfessionalsByBookedHours(ctx context.Context, arg *GetTopProfessionalsByBookedHoursParams) ([]*GetTopProfessionalsByBookedHoursRow, error)
 	GetUserByChatID(ctx context.Context, chatID sql.NullInt64) (*GetUserByChatIDRow, error)
+	GetWaitlistEntryByIDForUpdate(ctx context.Context, id uuid.UUID) (*WaitlistEntry, error)
+	HasClientVisitedProfessional(ctx context.Context, arg *HasClientVisitedProfessionalParams) (bool, error)
+	HasOverlappingAppointment(ctx context.Context, arg *HasOverlappingAppointmentParams) (bool, error)
+	HasOverlappingConfirmedAppointment(ctx context.Context, arg *HasOverlappingConfirmedAppointmentParams) (bool, error)
+	HasOverlappingExternalBusyBlock(ctx context.Context, arg *HasOverlappingExternalBusyBlockParams) (bool, error)
+	LockAppointmentEvents(ctx context.Context) error
+	LockClient(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	LockClientPhoneNumbers(ctx context.Context) error
+	LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	OfferWaitlistEntry(ctx context.Context, arg *OfferWaitlistEntryParams) (*WaitlistEntry, error)
+	ReplaceExternalBusyBlocks(ctx context.Context, arg *ReplaceExternalBusyBlocksParams) error
+	TakeRateLimitToken(ctx context.Context, arg *TakeRateLimitTokenParams) (*TakeRateLimitTokenRow, error)
+	UpdateClientLanguageByChatID(ctx context.Context, arg *UpdateClientLanguageByChatIDParams) (int64, error)
+	UpdateExternalCalendarSyncResult(ctx context.Context, arg *UpdateExternalCalendarSyncResultParams) (*ExternalCalendar, error)
 	UpdateProfessionalChatID(ctx context.Context, arg *UpdateProfessionalChatIDParams) (*Professional, error)
+	UpdateProfessionalLanguageByChatID(ctx context.Context, arg *UpdateProfessionalLanguageByChatIDParams) (int64, error)
+	UpsertBookingRule(ctx context.Context, arg *UpsertBookingRuleParams) (*BookingRule, error)
+	UpsertCancellationPolicy(ctx context.Context, arg *UpsertCancellationPolicyParams) (*CancellationPolicy, error)
+	UpsertClientCalendarFeed(ctx context.Context, arg *UpsertClientCalendarFeedParams) (*CalendarFeed, error)
+	UpsertProfessionalCalendarFeed(ctx context.Context, arg *UpsertProfessionalCalendarFeedParams) (*CalendarFeed, error)
 }
 
 var _ Querier = (*Queries)(nil)
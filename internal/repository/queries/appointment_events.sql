@@ -0,0 +1,25 @@
+-- name: CreateAppointmentEvent :one
+INSERT INTO appointment_events (event_type, appointment_id, professional_id, client_id, payload)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: LockAppointmentEvents :exec
+SELECT pg_advisory_xact_lock(hashtext('appointment_events'));
+
+-- name: GetAppointmentEvent :one
+SELECT * FROM appointment_events
+WHERE id = $1;
+
+-- name: GetProfessionalEventsAfter :many
+SELECT * FROM appointment_events
+WHERE professional_id = $1
+  AND id > $2
+ORDER BY id ASC
+LIMIT $3;
+
+-- name: GetClientEventsAfter :many
+SELECT * FROM appointment_events
+WHERE client_id = $1
+  AND id > $2
+ORDER BY id ASC
+LIMIT $3;
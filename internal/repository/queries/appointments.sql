@@ -2,10 +2,15 @@
 SELECT * FROM appointments
 WHERE appointments.id = $1;
 
+-- name: GetAppointmentByIDForUpdate :one
+SELECT * FROM appointments
+WHERE appointments.id = $1
+FOR UPDATE;
+
 -- name: CreateAppointmentWithDetails :one
 WITH new_appointment AS (
     INSERT INTO appointments (type, client_id, professional_id, start_time, end_time, status, description)
-    VALUES ('appointment', $1, $2, $3, $4, 'pending', $5)
+    VALUES ('appointment', $1, $2, $3, $4, $5, $6)
     RETURNING *
 )
 SELECT 
@@ -147,6 +152,7 @@ WITH updated_appointment AS (
         status = 'cancelled',
         cancellation_reason = $3,
         cancelled_by_client_id = $2,
+        late_cancellation = $4,
         updated_at = NOW()
     WHERE appointments.id = $1 
     AND client_id = $2
@@ -166,6 +172,7 @@ SELECT
     ua.cancelled_by_client_id,
     ua.created_at,
     ua.updated_at,
+    ua.late_cancellation,
     c.id as client_id_full,
     c.first_name as client_first_name,
     c.last_name as client_last_name,
@@ -213,7 +220,7 @@ LEFT JOIN clients c ON a.client_id = c.id
 WHERE a.professional_id = $1
   AND DATE(a.start_time) = $2
   AND (a.type = 'appointment' OR a.type = 'unavailable')
-  AND a.status NOT IN ('cancelled', 'pending')
+  AND a.status IS DISTINCT FROM 'cancelled'
 ORDER BY a.start_time ASC;
 
 -- name: GetProfessionalAppointmentDates :many
@@ -226,6 +233,16 @@ WHERE professional_id = $1
   AND start_time < $3
 ORDER BY appointment_date ASC;
 
+-- name: GetProfessionalAppointmentIDsByDate :many
+SELECT id FROM appointments
+WHERE professional_id = $1
+  AND type = 'appointment'
+  AND status IN ('pending', 'confirmed')
+  AND (sqlc.narg(status)::appointment_status IS NULL OR status = sqlc.narg(status)::appointment_status)
+  AND DATE(start_time) = @date::date
+  AND start_time > NOW()
+ORDER BY id ASC;
+
 -- name: GetProfessionalTimetable :many
 SELECT 
     a.id,
@@ -0,0 +1,43 @@
+-- name: GetBookingRule :one
+SELECT * FROM booking_rules
+WHERE professional_id = $1;
+
+-- name: UpsertBookingRule :one
+INSERT INTO booking_rules (professional_id, min_notice_hours, max_advance_days, max_daily_per_client, max_pending_per_client, auto_confirm, allow_tentative_requests)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (professional_id) DO UPDATE
+SET min_notice_hours = EXCLUDED.min_notice_hours,
+    max_advance_days = EXCLUDED.max_advance_days,
+    max_daily_per_client = EXCLUDED.max_daily_per_client,
+    max_pending_per_client = EXCLUDED.max_pending_per_client,
+    auto_confirm = EXCLUDED.auto_confirm,
+    allow_tentative_requests = EXCLUDED.allow_tentative_requests,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: CountClientAppointmentsInRange :one
+SELECT COUNT(*) FROM appointments
+WHERE client_id = $1
+  AND professional_id = $2
+  AND type = 'appointment'
+  AND status IN ('pending', 'confirmed', 'completed')
+  AND start_time >= @range_start
+  AND start_time < @range_end;
+
+-- name: CountClientPendingAppointments :one
+SELECT COUNT(*) FROM appointments
+WHERE client_id = $1
+  AND professional_id = $2
+  AND type = 'appointment'
+  AND status = 'pending'
+  AND start_time > NOW();
+
+-- name: HasClientVisitedProfessional :one
+SELECT EXISTS (
+    SELECT 1 FROM appointments
+    WHERE client_id = $1
+      AND professional_id = $2
+      AND type = 'appointment'
+      AND status IN ('confirmed', 'completed')
+      AND end_time <= NOW()
+);
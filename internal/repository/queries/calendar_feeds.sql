@@ -0,0 +1,62 @@
+-- name: UpsertProfessionalCalendarFeed :one
+INSERT INTO calendar_feeds (professional_id, token)
+VALUES ($1, $2)
+ON CONFLICT (professional_id) DO UPDATE
+SET token = EXCLUDED.token, updated_at = NOW()
+RETURNING *;
+
+-- name: UpsertClientCalendarFeed :one
+INSERT INTO calendar_feeds (client_id, token)
+VALUES ($1, $2)
+ON CONFLICT (client_id) DO UPDATE
+SET token = EXCLUDED.token, updated_at = NOW()
+RETURNING *;
+
+-- name: GetCalendarFeedByToken :one
+SELECT * FROM calendar_feeds
+WHERE token = $1;
+
+-- name: GetProfessionalCalendarAppointments :many
+SELECT 
+    a.id,
+    a.type,
+    a.status,
+    a.start_time,
+    a.end_time,
+    a.description,
+    a.cancellation_reason,
+    a.created_at,
+    a.updated_at,
+    c.first_name as client_first_name,
+    c.last_name as client_last_name,
+    c.phone_number as client_phone_number,
+    FLOOR(EXTRACT(EPOCH FROM a.updated_at - a.created_at))::int AS sequence
+FROM appointments a
+LEFT JOIN clients c ON c.id = a.client_id
+WHERE a.professional_id = $1
+  AND a.status IN ('confirmed', 'cancelled')
+  AND a.start_time >= $2
+ORDER BY a.start_time ASC;
+
+-- name: GetClientCalendarAppointments :many
+SELECT 
+    a.id,
+    a.type,
+    a.status,
+    a.start_time,
+    a.end_time,
+    a.description,
+    a.cancellation_reason,
+    a.created_at,
+    a.updated_at,
+    p.first_name as professional_first_name,
+    p.last_name as professional_last_name,
+    p.phone_number as professional_phone_number,
+    FLOOR(EXTRACT(EPOCH FROM a.updated_at - a.created_at))::int AS sequence
+FROM appointments a
+LEFT JOIN professionals p ON p.id = a.professional_id
+WHERE a.client_id = $1
+  AND a.type = 'appointment'
+  AND a.status IN ('confirmed', 'cancelled')
+  AND a.start_time >= $2
+ORDER BY a.start_time ASC;
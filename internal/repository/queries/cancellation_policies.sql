@@ -0,0 +1,12 @@
+-- name: GetCancellationPolicy :one
+SELECT * FROM cancellation_policies
+WHERE professional_id = $1;
+
+-- name: UpsertCancellationPolicy :one
+INSERT INTO cancellation_policies (professional_id, min_notice_hours, allow_late_cancellation)
+VALUES ($1, $2, $3)
+ON CONFLICT (professional_id) DO UPDATE
+SET min_notice_hours = EXCLUDED.min_notice_hours,
+    allow_late_cancellation = EXCLUDED.allow_late_cancellation,
+    updated_at = NOW()
+RETURNING *;
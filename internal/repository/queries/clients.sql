@@ -1,5 +1,18 @@
 -- name: CreateClient :one
-INSERT INTO clients (first_name, last_name, phone_number, chat_id, created_by)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO clients (first_name, last_name, phone_number, chat_id, created_by, language)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
+-- name: GetClientLanguage :one
+SELECT language FROM clients
+WHERE id = $1;
+
+-- name: UpdateClientLanguageByChatID :execrows
+UPDATE clients
+SET language = $2, updated_at = NOW()
+WHERE chat_id = $1;
+
+-- name: LockClient :one
+SELECT id FROM clients
+WHERE id = $1
+FOR NO KEY UPDATE;
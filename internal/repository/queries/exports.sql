@@ -0,0 +1,20 @@
+-- name: GetProfessionalAppointmentsForExport :many
+SELECT
+    a.id,
+    a.status,
+    a.start_time,
+    a.end_time,
+    a.description,
+    a.cancellation_reason,
+    c.first_name AS client_first_name,
+    c.last_name AS client_last_name,
+    c.phone_number AS client_phone_number
+FROM appointments a
+LEFT JOIN clients c ON c.id = a.client_id
+WHERE a.professional_id = @professional_id
+  AND a.type = 'appointment'
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+  AND (a.start_time, a.id) > (@after_start_time::timestamptz, @after_id::uuid)
+ORDER BY a.start_time ASC, a.id ASC
+LIMIT @page_size;
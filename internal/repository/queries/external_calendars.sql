@@ -0,0 +1,57 @@
+-- name: CreateExternalCalendar :one
+INSERT INTO external_calendars (professional_id, name, url)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetExternalCalendarByID :one
+SELECT * FROM external_calendars
+WHERE id = $1;
+
+-- name: GetExternalCalendarsByProfessional :many
+SELECT * FROM external_calendars
+WHERE professional_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetExternalCalendarsToSync :many
+SELECT * FROM external_calendars
+WHERE url IS NOT NULL
+ORDER BY last_synced_at ASC NULLS FIRST;
+
+-- name: UpdateExternalCalendarSyncResult :one
+UPDATE external_calendars
+SET last_synced_at = NOW(), last_sync_error = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteExternalCalendar :execrows
+DELETE FROM external_calendars
+WHERE id = $1 AND professional_id = $2;
+
+-- name: ReplaceExternalBusyBlocks :exec
+WITH deleted AS (
+    DELETE FROM external_busy_blocks
+    WHERE external_calendar_id = @external_calendar_id
+)
+INSERT INTO external_busy_blocks (external_calendar_id, professional_id, uid, start_time, end_time, summary)
+SELECT
+    @external_calendar_id,
+    @professional_id,
+    unnest(@uids::text[]),
+    unnest(@start_times::timestamptz[]),
+    unnest(@end_times::timestamptz[]),
+    unnest(@summaries::text[]);
+
+-- name: GetExternalBusyBlocksByProfessionalAndRange :many
+SELECT * FROM external_busy_blocks
+WHERE professional_id = @professional_id
+  AND start_time < @range_end
+  AND end_time > @range_start
+ORDER BY start_time ASC;
+
+-- name: HasOverlappingExternalBusyBlock :one
+SELECT EXISTS (
+    SELECT 1 FROM external_busy_blocks
+    WHERE professional_id = $1
+      AND start_time < @end_time
+      AND end_time > @start_time
+);
@@ -0,0 +1,39 @@
+-- name: AcquireIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    scope,
+    key,
+    request_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (scope, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    response_status = NULL,
+    response_content_type = NULL,
+    response_body = NULL,
+    response_headers = '{}'::jsonb,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= NOW()
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE scope = $1 AND key = $2;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET response_status = @response_status::int,
+    response_content_type = @response_content_type::text,
+    response_body = @response_body::bytea,
+    response_headers = @response_headers::jsonb
+WHERE scope = @scope AND key = @key;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE scope = $1 AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= NOW();
@@ -0,0 +1,20 @@
+-- name: GetClientsByPhoneNumbers :many
+SELECT * FROM clients
+WHERE phone_number = ANY(@phone_numbers::text[]);
+
+-- name: GetActiveAppointmentsByProfessionalInRange :many
+SELECT * FROM appointments
+WHERE professional_id = @professional_id
+  AND status <> 'cancelled'
+  AND start_time < @range_end
+  AND end_time > @range_start
+ORDER BY start_time ASC;
+
+-- name: CreateImportedAppointment :one
+INSERT INTO appointments (type, client_id, professional_id, start_time, end_time, status, description)
+VALUES ('appointment', $1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: LockClientPhoneNumbers :exec
+-- Serializes the transactions that check phone numbers before creating clients
+SELECT pg_advisory_xact_lock(hashtext('client_phone_numbers'));
@@ -18,6 +18,15 @@ SET chat_id = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: GetProfessionalLanguage :one
+SELECT language FROM professionals
+WHERE id = $1;
+
+-- name: UpdateProfessionalLanguageByChatID :execrows
+UPDATE professionals
+SET language = $2, updated_at = NOW()
+WHERE chat_id = $1;
+
 -- name: GetAppointmentsByProfessionalWithStatusAndDate :many
 SELECT 
     a.id,
@@ -31,7 +40,20 @@ SELECT
     a.client_id,
     c.first_name as client_first_name,
     c.last_name as client_last_name,
-    c.phone_number as client_phone_number
+    c.phone_number as client_phone_number,
+    (
+        SELECT COUNT(*) FROM appointments lc
+        WHERE lc.client_id = a.client_id AND lc.late_cancellation
+    ) AS client_late_cancellations,
+    (
+        SELECT COUNT(*) FROM appointments cr
+        WHERE cr.professional_id = a.professional_id
+          AND cr.id <> a.id
+          AND cr.type = 'appointment'
+          AND cr.status = 'pending'
+          AND cr.start_time < a.end_time
+          AND cr.end_time > a.start_time
+    ) AS competing_requests
 FROM appointments a
 LEFT JOIN clients c ON a.client_id = c.id
 WHERE a.professional_id = $1
@@ -40,3 +62,8 @@ WHERE a.professional_id = $1
     AND a.start_time > NOW()
     AND a.type = 'appointment'
 ORDER BY a.start_time ASC;
+
+-- name: LockProfessional :one
+SELECT id FROM professionals
+WHERE id = $1
+FOR NO KEY UPDATE;
@@ -0,0 +1,22 @@
+-- name: TakeRateLimitToken :one
+INSERT INTO rate_limit_buckets AS b (
+    key,
+    tokens,
+    allowed,
+    updated_at
+) VALUES (
+    @key, @burst::float8 - 1, TRUE, NOW()
+)
+ON CONFLICT (key) DO UPDATE
+SET (tokens, allowed, updated_at) = (
+    SELECT
+        CASE WHEN refill.tokens >= 1 THEN refill.tokens - 1 ELSE refill.tokens END,
+        refill.tokens >= 1,
+        NOW()
+    FROM (SELECT LEAST(@burst::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * @refill_rate::float8) AS tokens) refill
+)
+RETURNING tokens, allowed;
+
+-- name: DeleteIdleRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE updated_at < @idle_since;
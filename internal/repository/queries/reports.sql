@@ -0,0 +1,84 @@
+-- name: GetBookingTotals :many
+SELECT
+    date_trunc(@granularity::text, a.start_time AT TIME ZONE @timezone::text)::timestamp AS bucket,
+    COUNT(*)::int AS total_count,
+    COUNT(*) FILTER (WHERE a.status = 'pending')::int AS pending_count,
+    COUNT(*) FILTER (WHERE a.status = 'confirmed')::int AS confirmed_count,
+    COUNT(*) FILTER (WHERE a.status = 'cancelled')::int AS cancelled_count,
+    COUNT(*) FILTER (WHERE a.status = 'completed')::int AS completed_count
+FROM appointments a
+WHERE a.type = 'appointment'
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY bucket
+ORDER BY bucket ASC;
+
+-- name: GetConfirmationLatency :one
+WITH confirmations AS (
+    SELECT appointment_id, MIN(created_at) AS confirmed_at
+    FROM appointment_events
+    WHERE event_type = 'appointment.confirmed'
+    GROUP BY appointment_id
+),
+latencies AS (
+    SELECT EXTRACT(EPOCH FROM c.confirmed_at - a.created_at) / 60 AS minutes
+    FROM appointments a
+    JOIN confirmations c ON c.appointment_id = a.id
+    WHERE a.type = 'appointment'
+      AND a.created_at >= @range_start
+      AND a.created_at < @range_end
+)
+SELECT
+    COUNT(*)::int AS confirmed_count,
+    COALESCE(AVG(minutes), 0)::float8 AS avg_minutes,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY minutes), 0)::float8 AS median_minutes,
+    COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY minutes), 0)::float8 AS p90_minutes,
+    COALESCE(MAX(minutes), 0)::float8 AS max_minutes
+FROM latencies;
+
+-- name: GetTopProfessionalsByBookedHours :many
+SELECT
+    p.id,
+    p.first_name,
+    p.last_name,
+    COUNT(a.id)::int AS appointment_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60), 0)::int AS booked_minutes
+FROM professionals p
+JOIN appointments a ON a.professional_id = p.id
+WHERE a.type = 'appointment'
+  AND a.status IN ('confirmed', 'completed')
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY p.id, p.first_name, p.last_name
+ORDER BY booked_minutes DESC, p.last_name ASC, p.first_name ASC
+LIMIT @row_limit;
+
+-- name: CountClientsCreatedBefore :one
+SELECT COUNT(*)::int AS client_count
+FROM clients
+WHERE created_at < @created_before;
+
+-- name: GetNewClientsByMonth :many
+SELECT
+    date_trunc('month', c.created_at AT TIME ZONE @timezone::text)::timestamp AS month,
+    COUNT(*)::int AS new_clients
+FROM clients c
+WHERE c.created_at >= @range_start
+  AND c.created_at < @range_end
+GROUP BY month
+ORDER BY month ASC;
+
+-- name: GetCancellationReasons :many
+SELECT
+    COALESCE(LOWER(TRIM(a.cancellation_reason)), '')::text AS reason,
+    COUNT(*)::int AS cancellation_count,
+    COUNT(*) FILTER (WHERE a.cancelled_by_client_id IS NOT NULL)::int AS by_client_count,
+    COUNT(*) FILTER (WHERE a.cancelled_by_professional_id IS NOT NULL)::int AS by_professional_count
+FROM appointments a
+WHERE a.type = 'appointment'
+  AND a.status = 'cancelled'
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY reason
+ORDER BY cancellation_count DESC, reason ASC
+LIMIT @row_limit;
@@ -0,0 +1,51 @@
+-- name: CreateSlotHold :one
+INSERT INTO slot_holds (professional_id, client_id, start_time, end_time, expires_at)
+VALUES ($1, $2, $3, $4, @expires_at::timestamptz)
+RETURNING *;
+
+-- name: DeleteClientSlotHolds :execrows
+DELETE FROM slot_holds
+WHERE professional_id = $1
+  AND client_id = $2;
+
+-- name: GetSlotHoldByIDForUpdate :one
+SELECT * FROM slot_holds
+WHERE id = $1
+  AND expires_at > NOW()
+FOR UPDATE;
+
+-- name: DeleteSlotHold :exec
+DELETE FROM slot_holds
+WHERE id = $1;
+
+-- name: CountOverlappingSlotHolds :one
+SELECT COUNT(*) FROM slot_holds
+WHERE professional_id = $1
+  AND client_id <> $2
+  AND expires_at > NOW()
+  AND start_time < @end_time::timestamptz
+  AND end_time > @start_time::timestamptz;
+
+-- name: GetActiveSlotHoldsByProfessionalAndRange :many
+SELECT * FROM slot_holds
+WHERE professional_id = $1
+  AND expires_at > NOW()
+  AND start_time < @range_end::timestamptz
+  AND end_time > @range_start::timestamptz
+  AND (sqlc.narg(exclude_client_id)::uuid IS NULL OR client_id <> sqlc.narg(exclude_client_id)::uuid)
+ORDER BY start_time ASC;
+
+-- name: DeleteExpiredSlotHolds :execrows
+DELETE FROM slot_holds
+WHERE expires_at <= NOW();
+
+-- name: HasOverlappingConfirmedAppointment :one
+SELECT EXISTS (
+    SELECT 1 FROM appointments
+    WHERE professional_id = $1
+      AND status IS DISTINCT FROM 'cancelled'
+      AND status IS DISTINCT FROM 'pending'
+      AND start_time < @end_time
+      AND end_time > @start_time
+      AND (sqlc.narg(exclude_id)::uuid IS NULL OR id <> sqlc.narg(exclude_id)::uuid)
+);
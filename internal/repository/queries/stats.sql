@@ -0,0 +1,90 @@
+-- name: GetProfessionalStatsSummary :one
+SELECT
+    COUNT(*) FILTER (WHERE a.type = 'appointment')::int AS total_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'pending')::int AS pending_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'confirmed')::int AS confirmed_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'cancelled')::int AS cancelled_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'completed')::int AS completed_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.cancelled_by_client_id IS NOT NULL)::int AS cancelled_by_client_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.cancelled_by_professional_id IS NOT NULL)::int AS cancelled_by_professional_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60) FILTER (WHERE a.type = 'appointment' AND a.status IN ('confirmed', 'completed')), 0)::int AS booked_minutes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60) FILTER (WHERE a.type = 'unavailable' AND a.status <> 'cancelled'), 0)::int AS unavailable_minutes,
+    COALESCE(AVG(EXTRACT(EPOCH FROM a.start_time - a.created_at) / 3600) FILTER (WHERE a.type = 'appointment'), 0)::float8 AS avg_lead_time_hours
+FROM appointments a
+WHERE a.professional_id = @professional_id
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end;
+
+-- name: GetProfessionalStatsBuckets :many
+SELECT
+    date_trunc(@granularity::text, a.start_time AT TIME ZONE @timezone::text)::timestamp AS bucket,
+    COUNT(*) FILTER (WHERE a.type = 'appointment')::int AS total_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'pending')::int AS pending_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'confirmed')::int AS confirmed_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'cancelled')::int AS cancelled_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.status = 'completed')::int AS completed_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.cancelled_by_client_id IS NOT NULL)::int AS cancelled_by_client_count,
+    COUNT(*) FILTER (WHERE a.type = 'appointment' AND a.cancelled_by_professional_id IS NOT NULL)::int AS cancelled_by_professional_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60) FILTER (WHERE a.type = 'appointment' AND a.status IN ('confirmed', 'completed')), 0)::int AS booked_minutes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60) FILTER (WHERE a.type = 'unavailable' AND a.status <> 'cancelled'), 0)::int AS unavailable_minutes,
+    COALESCE(AVG(EXTRACT(EPOCH FROM a.start_time - a.created_at) / 3600) FILTER (WHERE a.type = 'appointment'), 0)::float8 AS avg_lead_time_hours
+FROM appointments a
+WHERE a.professional_id = @professional_id
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY bucket
+ORDER BY bucket ASC;
+
+-- name: GetProfessionalBusiestWeekdays :many
+SELECT
+    EXTRACT(ISODOW FROM a.start_time AT TIME ZONE @timezone::text)::int AS weekday,
+    COUNT(*)::int AS appointment_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60), 0)::int AS booked_minutes
+FROM appointments a
+WHERE a.professional_id = @professional_id
+  AND a.type = 'appointment'
+  AND a.status IN ('confirmed', 'completed')
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY weekday
+ORDER BY appointment_count DESC, weekday ASC;
+
+-- name: GetProfessionalBusiestHours :many
+SELECT
+    EXTRACT(HOUR FROM a.start_time AT TIME ZONE @timezone::text)::int AS hour,
+    COUNT(*)::int AS appointment_count,
+    COALESCE(SUM(EXTRACT(EPOCH FROM a.end_time - a.start_time) / 60), 0)::int AS booked_minutes
+FROM appointments a
+WHERE a.professional_id = @professional_id
+  AND a.type = 'appointment'
+  AND a.status IN ('confirmed', 'completed')
+  AND a.start_time >= @range_start
+  AND a.start_time < @range_end
+GROUP BY hour
+ORDER BY appointment_count DESC, hour ASC;
+
+-- name: GetProfessionalClientRetention :one
+WITH first_visits AS (
+    SELECT client_id, MIN(start_time) AS first_start_time
+    FROM appointments
+    WHERE professional_id = @professional_id
+      AND type = 'appointment'
+      AND status <> 'cancelled'
+      AND client_id IS NOT NULL
+    GROUP BY client_id
+),
+active_clients AS (
+    SELECT DISTINCT client_id
+    FROM appointments
+    WHERE professional_id = @professional_id
+      AND type = 'appointment'
+      AND status <> 'cancelled'
+      AND client_id IS NOT NULL
+      AND start_time >= @range_start
+      AND start_time < @range_end
+)
+SELECT
+    COUNT(*) FILTER (WHERE f.first_start_time >= @range_start)::int AS new_clients,
+    COUNT(*) FILTER (WHERE f.first_start_time < @range_start)::int AS returning_clients
+FROM active_clients ac
+JOIN first_visits f ON f.client_id = ac.client_id;
@@ -8,7 +8,12 @@ SELECT
     created_at,
     updated_at,
     'client' as role,
-    NULL as username
+    NULL as username,
+    language,
+    (
+        SELECT COUNT(*) FROM appointments a
+        WHERE a.client_id = clients.id AND a.late_cancellation
+    ) AS late_cancellations
 FROM clients 
 WHERE clients.chat_id = $1
 
@@ -23,6 +28,8 @@ SELECT
     created_at,
     updated_at,
     'professional' as role,
-    username
+    username,
+    language,
+    0 AS late_cancellations
 FROM professionals 
 WHERE professionals.chat_id = $1;
@@ -0,0 +1,126 @@
+-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (client_id, professional_id, window_start, window_end)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWaitlistEntryByIDForUpdate :one
+SELECT * FROM waitlist_entries
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetActiveWaitlistEntriesByClient :many
+SELECT * FROM waitlist_entries
+WHERE client_id = $1
+  AND status IN ('waiting', 'offered')
+  AND window_end > NOW()
+ORDER BY window_start ASC, created_at ASC;
+
+-- name: CancelWaitlistEntry :one
+UPDATE waitlist_entries
+SET status = 'cancelled', updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetNextWaitlistEntryForSlot :one
+SELECT * FROM waitlist_entries w
+WHERE w.professional_id = $1
+  AND w.status = 'waiting'
+  AND w.window_start <= @slot_start
+  AND w.window_end >= @slot_end
+  AND w.client_id IS DISTINCT FROM (SELECT a.client_id FROM appointments a WHERE a.id = @offered_appointment_id::uuid)
+  AND NOT EXISTS (
+      SELECT 1 FROM waitlist_entries o
+      WHERE o.client_id = w.client_id
+        AND o.offered_appointment_id = @offered_appointment_id::uuid
+  )
+ORDER BY w.created_at ASC, w.id ASC
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: OfferWaitlistEntry :one
+UPDATE waitlist_entries
+SET status = 'offered',
+    offered_appointment_id = @offered_appointment_id::uuid,
+    offered_start_time = @offered_start_time::timestamptz,
+    offered_end_time = @offered_end_time::timestamptz,
+    offer_expires_at = @offer_expires_at::timestamptz,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: BookWaitlistEntry :one
+UPDATE waitlist_entries
+SET status = 'booked', appointment_id = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ExpireWaitlistOffers :many
+UPDATE waitlist_entries
+SET status = 'expired', updated_at = NOW()
+WHERE status = 'offered'
+  AND offer_expires_at <= NOW()
+RETURNING *;
+
+-- name: ExpireWaitlistEntries :execrows
+UPDATE waitlist_entries
+SET status = 'expired', updated_at = NOW()
+WHERE status = 'waiting'
+  AND window_end <= NOW();
+
+-- name: GetCancelledAppointmentsToOffer :many
+SELECT a.id FROM appointments a
+WHERE a.status = 'cancelled'
+  AND a.start_time > NOW()
+  AND NOT EXISTS (
+      SELECT 1 FROM waitlist_entries o
+      WHERE o.offered_appointment_id = a.id
+        AND o.status = 'offered'
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM appointments b
+      WHERE b.professional_id = a.professional_id
+        AND b.status IS DISTINCT FROM 'cancelled'
+        AND b.start_time < a.end_time
+        AND b.end_time > a.start_time
+  )
+  AND EXISTS (
+      SELECT 1 FROM waitlist_entries w
+      WHERE w.professional_id = a.professional_id
+        AND w.status = 'waiting'
+        AND w.window_start <= a.start_time
+        AND w.window_end >= a.end_time
+        AND w.client_id IS DISTINCT FROM a.client_id
+        AND NOT EXISTS (
+            SELECT 1 FROM waitlist_entries o
+            WHERE o.client_id = w.client_id
+              AND o.offered_appointment_id = a.id
+        )
+  )
+ORDER BY a.start_time ASC;
+
+-- name: GetOpenWaitlistOffersByProfessionalAndRange :many
+SELECT * FROM waitlist_entries
+WHERE professional_id = $1
+  AND status = 'offered'
+  AND offer_expires_at > NOW()
+  AND offered_start_time < @range_end::timestamptz
+  AND offered_end_time > @range_start::timestamptz
+ORDER BY offered_start_time ASC;
+
+-- name: CountOverlappingWaitlistOffers :one
+SELECT COUNT(*) FROM waitlist_entries
+WHERE professional_id = $1
+  AND client_id <> $2
+  AND status = 'offered'
+  AND offer_expires_at > NOW()
+  AND offered_start_time < @end_time::timestamptz
+  AND offered_end_time > @start_time::timestamptz;
+
+-- name: HasOverlappingAppointment :one
+SELECT EXISTS (
+    SELECT 1 FROM appointments
+    WHERE professional_id = $1
+      AND status IS DISTINCT FROM 'cancelled'
+      AND start_time < @end_time
+      AND end_time > @start_time
+);
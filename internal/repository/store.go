@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/vention/booking_api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxTxAttempts is the number of times ExecTx runs a transaction that keeps failing with a retryable error
+const maxTxAttempts = 3
+
+// txRetryBackoff is the delay before the second attempt, doubled for every further attempt
+const txRetryBackoff = 20 * time.Millisecond
+
+// PostgreSQL error codes of transactions that may succeed when run again
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+// Store provides all queries together with transaction support.
+// Every query is traced, see NewTracedDBTX.
+type Store struct {
+	*Queries
+	db *sql.DB
+}
+
+// NewStore creates a new store backed by the connection pool
+func NewStore(sqlDB *sql.DB) *Store {
+	return &Store{
+		Queries: New(NewTracedDBTX(sqlDB)),
+		db:      sqlDB,
+	}
+}
+
+// ExecTx runs fn as a unit of work within a database transaction, rolling back if fn returns an error.
+// Transactions aborted by a serialization failure or a deadlock are retried, so fn may run more than
+// once and must only change state through the queries it is given.
+func (s *Store) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	backoff := txRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.execTx(ctx, attempt, fn)
+		if err == nil || attempt == maxTxAttempts || !isRetryableTxError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// execTx runs a single attempt of ExecTx
+func (s *Store) execTx(ctx context.Context, attempt int, fn func(*Queries) error) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "db.Transaction", attribute.Int("db.transaction.attempt", attempt))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(New(NewTracedDBTX(tx))); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isRetryableTxError reports whether err aborted a transaction that may succeed when run again
+func isRetryableTxError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+}
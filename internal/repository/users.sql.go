@@ -23,7 +23,12 @@ SELECT
     created_at,
     updated_at,
     'client' as role,
-    NULL as username
+    NULL as username,
+    language,
+    (
+        SELECT COUNT(*) FROM appointments a
+        WHERE a.client_id = clients.id AND a.late_cancellation
+    ) AS late_cancellations
 FROM clients 
 WHERE clients.chat_id = $1
 
@@ -38,21 +43,25 @@ SELECT
     created_at,
     updated_at,
     'professional' as role,
-    username
+    username,
+    language,
+    0 AS late_cancellations
 FROM professionals 
 WHERE professionals.chat_id = $1
 `
 
 type GetUserByChatIDRow struct {
-	ID          uuid.UUID      `json:"id"`
-	ChatID      sql.NullInt64  `json:"chat_id"`
-	FirstName   string         `json:"first_name"`
-	LastName    string         `json:"last_name"`
-	PhoneNumber sql.NullString `json:"phone_number"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	Role        string         `json:"role"`
-	Username    interface{}    `json:"username"`
+	ID                uuid.UUID      `json:"id"`
+	ChatID            sql.NullInt64  `json:"chat_id"`
+	FirstName         string         `json:"first_name"`
+	LastName          string         `json:"last_name"`
+	PhoneNumber       sql.NullString `json:"phone_number"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	Role              string         `json:"role"`
+	Username          interface{}    `json:"username"`
+	Language          sql.NullString `json:"language"`
+	LateCancellations int64          `json:"late_cancellations"`
 }
 
 func (q *Queries) GetUserByChatID(ctx context.Context, chatID sql.NullInt64) (*GetUserByChatIDRow, error) {
@@ -68,6 +77,8 @@ func (q *Queries) GetUserByChatID(ctx context.Context, chatID sql.NullInt64) (*G
 		&i.UpdatedAt,
 		&i.Role,
 		&i.Username,
+		&i.Language,
+		&i.LateCancellations,
 	)
 	return &i, err
 }
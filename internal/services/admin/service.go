@@ -4,6 +4,7 @@ import (
 	"context"
 
 	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/tracing"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -25,6 +26,9 @@ func NewService(repo AdminsRepository) Service {
 
 // CreateProfessional creates a new professional with business logic validation
 func (s *service) CreateProfessional(ctx context.Context, input CreateProfessionalInput) (*db.Professional, error) {
+	ctx, span := tracing.StartSpan(ctx, "admin.CreateProfessional")
+	defer span.End()
+
 	// Hash password (business logic)
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -51,7 +55,7 @@ func (s *service) CreateProfessional(ctx context.Context, input CreateProfession
 	// Create professional in database
 	professional, err := s.repo.CreateProfessional(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, db.TranslateError(err, nil)
 	}
 
 	return professional, nil
@@ -3,9 +3,30 @@ package appointments
 import (
 	"context"
 
+	"github.com/google/uuid"
 	db "github.com/vention/booking_api/internal/repository"
 )
 
+// AppointmentsRepository defines the database operations needed by the appointments service
 type AppointmentsRepository interface {
 	CreateAppointmentWithDetails(ctx context.Context, arg *db.CreateAppointmentWithDetailsParams) (*db.CreateAppointmentWithDetailsRow, error)
+	LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	LockClient(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+	HasOverlappingAppointment(ctx context.Context, arg *db.HasOverlappingAppointmentParams) (bool, error)
+	HasClientVisitedProfessional(ctx context.Context, arg *db.HasClientVisitedProfessionalParams) (bool, error)
+	CountClientAppointmentsInRange(ctx context.Context, arg *db.CountClientAppointmentsInRangeParams) (int64, error)
+	CountClientPendingAppointments(ctx context.Context, arg *db.CountClientPendingAppointmentsParams) (int64, error)
+	CountOverlappingWaitlistOffers(ctx context.Context, arg *db.CountOverlappingWaitlistOffersParams) (int64, error)
+	GetSlotHoldByIDForUpdate(ctx context.Context, id uuid.UUID) (*db.SlotHold, error)
+	DeleteSlotHold(ctx context.Context, id uuid.UUID) error
+	CountOverlappingSlotHolds(ctx context.Context, arg *db.CountOverlappingSlotHoldsParams) (int64, error)
+	HasOverlappingExternalBusyBlock(ctx context.Context, arg *db.HasOverlappingExternalBusyBlockParams) (bool, error)
+}
+
+// AppointmentsStore adds transaction support so that the booking limits of a client are
+// checked and the appointment written atomically
+type AppointmentsStore interface {
+	AppointmentsRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
 }
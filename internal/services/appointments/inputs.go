@@ -13,4 +13,5 @@ type CreateAppointmentInput struct {
 	StartTime      time.Time
 	EndTime        time.Time
 	Description    string
+	HoldID         *uuid.UUID // Hold of the slot to consume, nil when the slot was not held
 }
@@ -3,9 +3,14 @@ package appointments
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/metrics"
 	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
 	"github.com/vention/booking_api/internal/util"
 )
 
@@ -15,18 +20,23 @@ type Service interface {
 }
 
 type service struct {
-	repo AppointmentsRepository
+	store    AppointmentsStore
+	recorder events.Recorder
 }
 
 // NewService creates a new appointments service
-func NewService(repo AppointmentsRepository) Service {
+func NewService(store AppointmentsStore, recorder events.Recorder) Service {
 	return &service{
-		repo: repo,
+		store:    store,
+		recorder: recorder,
 	}
 }
 
 // CreateAppointment creates a new appointment with business logic validation
 func (s *service) CreateAppointment(ctx context.Context, input CreateAppointmentInput) (*db.CreateAppointmentWithDetailsRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "appointments.CreateAppointment")
+	defer span.End()
+
 	// Convert times to application timezone (business rule)
 	startTime := util.ConvertToAppTimezone(input.StartTime)
 	endTime := util.ConvertToAppTimezone(input.EndTime)
@@ -36,17 +46,118 @@ func (s *service) CreateAppointment(ctx context.Context, input CreateAppointment
 		return nil, err
 	}
 
+	// Check the booking rules, create the appointment and record its event atomically so that
+	// concurrent requests of the client cannot exceed its limits
+	var result *db.CreateAppointmentWithDetailsRow
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		result, err = s.createAppointment(ctx, q, input, startTime, endTime)
+		if err != nil {
+			return err
+		}
+		return s.recorder.Record(ctx, q, events.AppointmentChange{
+			Type:           events.EventAppointmentCreated,
+			AppointmentID:  result.ID,
+			ProfessionalID: result.ProfessionalID,
+			ClientID:       result.ClientID,
+			Payload: events.AppointmentPayload{
+				Type:        string(result.Type),
+				Status:      string(result.Status.AppointmentStatus),
+				StartTime:   result.StartTime,
+				EndTime:     result.EndTime,
+				Description: result.Description.String,
+			},
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	metrics.AppointmentCreated(string(result.Type))
+	if result.Status.AppointmentStatus == db.AppointmentStatusConfirmed {
+		metrics.AppointmentConfirmed()
+	}
+
+	return result, nil
+}
+
+// createAppointment creates an appointment allowed by the booking rules of the professional,
+// locking the client until the transaction ends
+func (s *service) createAppointment(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) (*db.CreateAppointmentWithDetailsRow, error) {
+	// Lock the professional so that two clients cannot book or hold the same slot, then the client
+	// so that its appointments are counted consistently. Holds lock the professional as well.
+	if _, err := repo.LockProfessional(ctx, input.ProfessionalID); err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+	}
+	if _, err := repo.LockClient(ctx, input.ClientID); err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrClientNotFound)
+	}
+
+	if input.HoldID != nil {
+		if err := s.consumeHold(ctx, repo, input, startTime, endTime); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := svcCommon.GetBookingRule(ctx, repo, input.ProfessionalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateBookingWindow(startTime, rule, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.validateBookingLimits(ctx, repo, input, startTime, rule); err != nil {
+		return nil, err
+	}
+	if err := s.validateSlotNotOffered(ctx, repo, input, startTime, endTime); err != nil {
+		return nil, err
+	}
+	if err := s.validateSlotNotHeld(ctx, repo, input, startTime, endTime); err != nil {
+		return nil, err
+	}
+	if err := s.validateSlotNotExternallyBusy(ctx, repo, input, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	status, err := svcCommon.InitialStatus(ctx, repo, rule, input.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	// Auto-confirmed appointments and requests for slots that may not be requested tentatively
+	// need the slot free of other appointments, pending requests included
+	if status == db.AppointmentStatusConfirmed || svcCommon.PendingBlocksBooking(rule) {
+		if err := s.validateSlotNotBooked(ctx, repo, input, startTime, endTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create appointment in database
-	result, err := s.repo.CreateAppointmentWithDetails(ctx, &db.CreateAppointmentWithDetailsParams{
+	result, err := repo.CreateAppointmentWithDetails(ctx, &db.CreateAppointmentWithDetailsParams{
 		ClientID:       uuid.NullUUID{UUID: input.ClientID, Valid: true},
 		ProfessionalID: input.ProfessionalID,
 		StartTime:      startTime,
 		EndTime:        endTime,
+		Status:         db.NullAppointmentStatus{AppointmentStatus: status, Valid: true},
 		Description:    sql.NullString{String: input.Description, Valid: input.Description != ""},
 	})
 	if err != nil {
-		return nil, err
+		return nil, db.TranslateError(err, nil)
 	}
 
 	return result, nil
 }
+
+// consumeHold releases the hold of the slot being booked. The hold is kept when the
+// transaction rolls back.
+func (s *service) consumeHold(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	hold, err := repo.GetSlotHoldByIDForUpdate(ctx, *input.HoldID)
+	if err != nil {
+		return db.TranslateError(err, svcCommon.ErrSlotHoldNotFound)
+	}
+
+	if err := s.validateHoldMatches(hold, input, startTime, endTime); err != nil {
+		return err
+	}
+
+	return repo.DeleteSlotHold(ctx, hold.ID)
+}
@@ -1,8 +1,11 @@
 package appointments
 
 import (
+	"context"
 	"time"
 
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
 	svcCommon "github.com/vention/booking_api/internal/services/common"
 )
 
@@ -12,13 +15,141 @@ func (s *service) validateAppointmentTime(startTime, endTime time.Time) error {
 
 	// Check if start time is in the future
 	if startTime.Before(now) {
-		return svcCommon.ErrPastTime
+		return svcCommon.NewFieldError("start_time", "future", svcCommon.ErrPastTime)
 	}
 
 	// Check if end time is after start time
 	if endTime.Before(startTime) || endTime.Equal(startTime) {
-		return svcCommon.ErrInvalidTimeRange
+		return svcCommon.NewFieldError("end_time", "gtfield", svcCommon.ErrInvalidTimeRange, "field", "start_time")
 	}
 
 	return nil
 }
+
+// validateBookingWindow validates that the appointment starts within the notice and horizon of the booking rule
+func (s *service) validateBookingWindow(startTime time.Time, rule *db.BookingRule, now time.Time) error {
+	earliest, latest := svcCommon.BookingWindow(rule, now)
+
+	if startTime.Before(earliest) {
+		return svcCommon.NewFieldError("start_time", "min_notice", svcCommon.ErrBookingNoticeTooShort, "time", earliest.Format(time.RFC3339))
+	}
+	if !latest.IsZero() && startTime.After(latest) {
+		return svcCommon.NewFieldError("start_time", "max_advance", svcCommon.ErrBookingBeyondHorizon, "time", latest.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// validateBookingLimits validates that the client has not reached the daily and pending
+// appointment limits of the booking rule. Must run with the client locked.
+func (s *service) validateBookingLimits(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime time.Time, rule *db.BookingRule) error {
+	clientID := uuid.NullUUID{UUID: input.ClientID, Valid: true}
+
+	if rule.MaxDailyPerClient.Valid {
+		dayStart := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, startTime.Location())
+		count, err := repo.CountClientAppointmentsInRange(ctx, &db.CountClientAppointmentsInRangeParams{
+			ClientID:       clientID,
+			ProfessionalID: input.ProfessionalID,
+			RangeStart:     dayStart,
+			RangeEnd:       dayStart.AddDate(0, 0, 1),
+		})
+		if err != nil {
+			return err
+		}
+		if count >= int64(rule.MaxDailyPerClient.Int32) {
+			return svcCommon.ErrDailyBookingLimitReached
+		}
+	}
+
+	if rule.MaxPendingPerClient.Valid {
+		count, err := repo.CountClientPendingAppointments(ctx, &db.CountClientPendingAppointmentsParams{
+			ClientID:       clientID,
+			ProfessionalID: input.ProfessionalID,
+		})
+		if err != nil {
+			return err
+		}
+		if count >= int64(rule.MaxPendingPerClient.Int32) {
+			return svcCommon.ErrPendingBookingLimitReached
+		}
+	}
+
+	return nil
+}
+
+// validateHoldMatches validates that the hold was made by the client for the slot being booked
+func (s *service) validateHoldMatches(hold *db.SlotHold, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	if hold.ClientID != input.ClientID || hold.ProfessionalID != input.ProfessionalID ||
+		!hold.StartTime.Equal(startTime) || !hold.EndTime.Equal(endTime) {
+		return svcCommon.NewFieldError("hold_id", "match", svcCommon.ErrSlotHoldMismatch)
+	}
+	return nil
+}
+
+// validateSlotNotOffered validates that the slot is not held for another client by a waitlist offer
+func (s *service) validateSlotNotOffered(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	count, err := repo.CountOverlappingWaitlistOffers(ctx, &db.CountOverlappingWaitlistOffersParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return svcCommon.ErrSlotOffered
+	}
+	return nil
+}
+
+// validateSlotNotExternallyBusy validates that no busy block synced from an external calendar of the
+// professional overlaps the slot. Must run with the professional locked.
+func (s *service) validateSlotNotExternallyBusy(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	busy, err := repo.HasOverlappingExternalBusyBlock(ctx, &db.HasOverlappingExternalBusyBlockParams{
+		ProfessionalID: input.ProfessionalID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if busy {
+		return svcCommon.ErrSlotTaken
+	}
+	return nil
+}
+
+// validateSlotNotHeld validates that the slot is not held for another client
+func (s *service) validateSlotNotHeld(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	count, err := repo.CountOverlappingSlotHolds(ctx, &db.CountOverlappingSlotHoldsParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return svcCommon.ErrSlotHeld
+	}
+	return nil
+}
+
+// validateSlotNotBooked validates that no appointment, pending request or unavailable period
+// overlaps the slot
+func (s *service) validateSlotNotBooked(ctx context.Context, repo AppointmentsRepository, input CreateAppointmentInput, startTime, endTime time.Time) error {
+	taken, err := repo.HasOverlappingAppointment(ctx, &db.HasOverlappingAppointmentParams{
+		ProfessionalID: input.ProfessionalID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if taken {
+		return svcCommon.ErrSlotTaken
+	}
+	return nil
+}
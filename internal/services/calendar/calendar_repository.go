@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// CalendarRepository defines the database operations needed by the calendar service
+type CalendarRepository interface {
+	GetCalendarFeedByToken(ctx context.Context, token string) (*db.CalendarFeed, error)
+	UpsertProfessionalCalendarFeed(ctx context.Context, arg *db.UpsertProfessionalCalendarFeedParams) (*db.CalendarFeed, error)
+	UpsertClientCalendarFeed(ctx context.Context, arg *db.UpsertClientCalendarFeedParams) (*db.CalendarFeed, error)
+	GetProfessionalCalendarAppointments(ctx context.Context, arg *db.GetProfessionalCalendarAppointmentsParams) ([]*db.GetProfessionalCalendarAppointmentsRow, error)
+	GetClientCalendarAppointments(ctx context.Context, arg *db.GetClientCalendarAppointmentsParams) ([]*db.GetClientCalendarAppointmentsRow, error)
+
+	// External calendars
+	CreateExternalCalendar(ctx context.Context, arg *db.CreateExternalCalendarParams) (*db.ExternalCalendar, error)
+	GetExternalCalendarByID(ctx context.Context, id uuid.UUID) (*db.ExternalCalendar, error)
+	GetExternalCalendarsByProfessional(ctx context.Context, professionalID uuid.UUID) ([]*db.ExternalCalendar, error)
+	GetExternalCalendarsToSync(ctx context.Context) ([]*db.ExternalCalendar, error)
+	UpdateExternalCalendarSyncResult(ctx context.Context, arg *db.UpdateExternalCalendarSyncResultParams) (*db.ExternalCalendar, error)
+	DeleteExternalCalendar(ctx context.Context, arg *db.DeleteExternalCalendarParams) (int64, error)
+	ReplaceExternalBusyBlocks(ctx context.Context, arg *db.ReplaceExternalBusyBlocksParams) error
+}
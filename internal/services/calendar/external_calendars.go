@@ -0,0 +1,240 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/ical"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+	"github.com/vention/booking_api/internal/util"
+)
+
+const (
+	// syncHistory is how far back imported busy blocks are kept
+	syncHistory = 24 * time.Hour
+
+	// syncHorizon is how far ahead recurring events are expanded into busy blocks
+	syncHorizon = 365 * 24 * time.Hour
+
+	// maxCalendarSize bounds the size of fetched or uploaded calendars
+	maxCalendarSize = 10 << 20
+)
+
+// RegisterExternalCalendar subscribes the professional to an external calendar URL and imports it right away
+func (s *service) RegisterExternalCalendar(ctx context.Context, input RegisterExternalCalendarInput) (*db.ExternalCalendar, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.RegisterExternalCalendar")
+	defer span.End()
+
+	calendarURL, err := normalizeCalendarURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := s.repo.CreateExternalCalendar(ctx, &db.CreateExternalCalendarParams{
+		ProfessionalID: input.ProfessionalID,
+		Name:           input.Name,
+		Url:            sql.NullString{String: calendarURL, Valid: true},
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return s.syncExternalCalendar(ctx, calendar)
+}
+
+// UploadExternalCalendar imports the busy times of an uploaded .ics file once
+func (s *service) UploadExternalCalendar(ctx context.Context, input UploadExternalCalendarInput) (*db.ExternalCalendar, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.UploadExternalCalendar")
+	defer span.End()
+
+	events, err := parseCalendar(input.Content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", svcCommon.ErrInvalidCalendarData, err)
+	}
+
+	calendar, err := s.repo.CreateExternalCalendar(ctx, &db.CreateExternalCalendarParams{
+		ProfessionalID: input.ProfessionalID,
+		Name:           input.Name,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return s.importEvents(ctx, calendar, events)
+}
+
+// ListExternalCalendars retrieves the external calendars of a professional
+func (s *service) ListExternalCalendars(ctx context.Context, professionalID uuid.UUID) ([]*db.ExternalCalendar, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.ListExternalCalendars")
+	defer span.End()
+
+	return s.repo.GetExternalCalendarsByProfessional(ctx, professionalID)
+}
+
+// SyncExternalCalendar re-imports a single subscribed calendar of the professional
+func (s *service) SyncExternalCalendar(ctx context.Context, professionalID, calendarID uuid.UUID) (*db.ExternalCalendar, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.SyncExternalCalendar")
+	defer span.End()
+
+	calendar, err := s.repo.GetExternalCalendarByID(ctx, calendarID)
+	if err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrExternalCalendarNotFound)
+	}
+
+	if calendar.ProfessionalID != professionalID {
+		return nil, svcCommon.ErrForbidden
+	}
+
+	if !calendar.Url.Valid {
+		return nil, svcCommon.ErrCalendarNotSyncable
+	}
+
+	return s.syncExternalCalendar(ctx, calendar)
+}
+
+// SyncExternalCalendars re-imports all subscribed calendars, least recently synced first.
+// Fetch failures are stored on the calendar; only database errors are returned.
+func (s *service) SyncExternalCalendars(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "calendar.SyncExternalCalendars")
+	defer span.End()
+
+	calendars, err := s.repo.GetExternalCalendarsToSync(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, calendar := range calendars {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := s.syncExternalCalendar(ctx, calendar); err != nil {
+			errs = append(errs, fmt.Errorf("calendar %s: %w", calendar.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeleteExternalCalendar removes an external calendar together with its busy blocks
+func (s *service) DeleteExternalCalendar(ctx context.Context, professionalID, calendarID uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx, "calendar.DeleteExternalCalendar")
+	defer span.End()
+
+	deleted, err := s.repo.DeleteExternalCalendar(ctx, &db.DeleteExternalCalendarParams{
+		ID:             calendarID,
+		ProfessionalID: professionalID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return svcCommon.ErrExternalCalendarNotFound
+	}
+
+	return nil
+}
+
+// syncExternalCalendar fetches the calendar URL and replaces its busy blocks.
+// When the source cannot be fetched or parsed, the previously imported blocks are kept
+// so that a temporarily unreachable calendar does not free up the schedule.
+func (s *service) syncExternalCalendar(ctx context.Context, calendar *db.ExternalCalendar) (*db.ExternalCalendar, error) {
+	events, err := s.fetchCalendar(ctx, calendar.Url.String)
+	if err != nil {
+		return s.repo.UpdateExternalCalendarSyncResult(ctx, &db.UpdateExternalCalendarSyncResultParams{
+			ID:            calendar.ID,
+			LastSyncError: sql.NullString{String: err.Error(), Valid: true},
+		})
+	}
+
+	return s.importEvents(ctx, calendar, events)
+}
+
+// importEvents expands the events into busy blocks and atomically replaces those of the calendar
+func (s *service) importEvents(ctx context.Context, calendar *db.ExternalCalendar, events []ical.Event) (*db.ExternalCalendar, error) {
+	now := time.Now()
+	blocks := ical.BusyBlocks(events, now.Add(-syncHistory), now.Add(syncHorizon))
+
+	params := &db.ReplaceExternalBusyBlocksParams{
+		ExternalCalendarID: calendar.ID,
+		ProfessionalID:     calendar.ProfessionalID,
+		Uids:               make([]string, len(blocks)),
+		StartTimes:         make([]time.Time, len(blocks)),
+		EndTimes:           make([]time.Time, len(blocks)),
+		Summaries:          make([]string, len(blocks)),
+	}
+	for i, block := range blocks {
+		params.Uids[i] = block.UID
+		params.StartTimes[i] = block.Start
+		params.EndTimes[i] = block.End
+		params.Summaries[i] = block.Summary
+	}
+
+	if err := s.repo.ReplaceExternalBusyBlocks(ctx, params); err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateExternalCalendarSyncResult(ctx, &db.UpdateExternalCalendarSyncResultParams{
+		ID: calendar.ID,
+	})
+}
+
+// fetchCalendar downloads and parses an external calendar
+func (s *service) fetchCalendar(ctx context.Context, calendarURL string) ([]ical.Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, calendarURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return parseCalendar(resp.Body)
+}
+
+// parseCalendar reads and parses an iCalendar stream, rejecting calendars over maxCalendarSize
+func parseCalendar(r io.Reader) ([]ical.Event, error) {
+	data, err := svcCommon.ReadAllLimited(r, maxCalendarSize)
+	if err != nil {
+		return nil, err
+	}
+	return ical.Parse(bytes.NewReader(data), util.GetAppTimezone())
+}
+
+// normalizeCalendarURL validates a calendar URL, rewriting webcal:// subscriptions to https://
+func normalizeCalendarURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", svcCommon.NewFieldError("url", "url", svcCommon.ErrInvalidCalendarURL)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "webcal", "webcals":
+		u.Scheme = "https"
+	case "http", "https":
+	default:
+		return "", svcCommon.NewFieldError("url", "url", svcCommon.ErrInvalidCalendarURL)
+	}
+
+	return u.String(), nil
+}
@@ -0,0 +1,240 @@
+package calendar
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// fakeRepository records the external calendar writes of the service
+type fakeRepository struct {
+	CalendarRepository
+
+	calendar *db.ExternalCalendar
+	replaced *db.ReplaceExternalBusyBlocksParams
+	result   *db.UpdateExternalCalendarSyncResultParams
+}
+
+func (r *fakeRepository) CreateExternalCalendar(_ context.Context, arg *db.CreateExternalCalendarParams) (*db.ExternalCalendar, error) {
+	r.calendar = &db.ExternalCalendar{
+		ID:             uuid.New(),
+		ProfessionalID: arg.ProfessionalID,
+		Name:           arg.Name,
+		Url:            arg.Url,
+	}
+	return r.calendar, nil
+}
+
+func (r *fakeRepository) ReplaceExternalBusyBlocks(_ context.Context, arg *db.ReplaceExternalBusyBlocksParams) error {
+	r.replaced = arg
+	return nil
+}
+
+func (r *fakeRepository) UpdateExternalCalendarSyncResult(_ context.Context, arg *db.UpdateExternalCalendarSyncResultParams) (*db.ExternalCalendar, error) {
+	r.result = arg
+	calendar := *r.calendar
+	calendar.LastSyncError = arg.LastSyncError
+	calendar.LastSyncedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return &calendar, nil
+}
+
+// testCalendar returns a calendar with a weekly event starting tomorrow and a cancelled one-off event
+func testCalendar() string {
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	format := func(t time.Time) string { return t.Format("20060102T150405Z") }
+
+	return strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:weekly@example.com",
+		"SUMMARY:Gym",
+		"DTSTART:" + format(start),
+		"DTEND:" + format(start.Add(time.Hour)),
+		"RRULE:FREQ=WEEKLY;COUNT=3",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled@example.com",
+		"STATUS:CANCELLED",
+		"DTSTART:" + format(start.Add(2*time.Hour)),
+		"DTEND:" + format(start.Add(3*time.Hour)),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+}
+
+func TestRegisterExternalCalendar(t *testing.T) {
+	loopback := []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+	tests := []struct {
+		name            string
+		handler         http.HandlerFunc
+		allowedNetworks []netip.Prefix
+		wantBlocks      int
+		wantError       string // Substring of the stored sync error, empty when the sync succeeds
+	}{
+		{
+			name: "imports busy blocks",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if accept := r.Header.Get("Accept"); accept != "text/calendar" {
+					t.Errorf("Accept header %q, want text/calendar", accept)
+				}
+				w.Header().Set("Content-Type", "text/calendar")
+				_, _ = w.Write([]byte(testCalendar()))
+			},
+			allowedNetworks: loopback,
+			wantBlocks:      3,
+		},
+		{
+			name: "refuses internal addresses by default",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				t.Error("request reached a server on a loopback address")
+			},
+			wantError: "is not allowed",
+		},
+		{
+			name: "keeps blocks when the source fails",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			allowedNetworks: loopback,
+			wantError:       "502",
+		},
+		{
+			name: "rejects oversized calendars",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testCalendar()))
+				_, _ = w.Write([]byte(strings.Repeat("X", maxCalendarSize)))
+			},
+			allowedNetworks: loopback,
+			wantError:       "larger than",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			repo := &fakeRepository{}
+			svc := NewService(repo, NewHTTPClient(5*time.Second, tt.allowedNetworks))
+
+			calendar, err := svc.RegisterExternalCalendar(context.Background(), RegisterExternalCalendarInput{
+				ProfessionalID: uuid.New(),
+				Name:           "Private",
+				URL:            server.URL + "/private.ics",
+			})
+			if err != nil {
+				t.Fatalf("RegisterExternalCalendar: %v", err)
+			}
+
+			if tt.wantError != "" {
+				if !calendar.LastSyncError.Valid || !strings.Contains(calendar.LastSyncError.String, tt.wantError) {
+					t.Fatalf("sync error %q, want it to contain %q", calendar.LastSyncError.String, tt.wantError)
+				}
+				if repo.replaced != nil {
+					t.Fatal("busy blocks were replaced after a failed sync")
+				}
+				return
+			}
+
+			if calendar.LastSyncError.Valid {
+				t.Fatalf("unexpected sync error %q", calendar.LastSyncError.String)
+			}
+			if repo.replaced == nil {
+				t.Fatal("busy blocks were not replaced")
+			}
+			if got := len(repo.replaced.Uids); got != tt.wantBlocks {
+				t.Fatalf("got %d busy blocks, want %d", got, tt.wantBlocks)
+			}
+			for i, uid := range repo.replaced.Uids {
+				if uid != "weekly@example.com" {
+					t.Errorf("block %d has UID %q, want weekly@example.com", i, uid)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPClientIgnoresProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request to %s went through the proxy", r.URL)
+	}))
+	defer proxy.Close()
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+
+	// The proxy of the environment is read once per process, the transport must not consult it at all
+	transport := NewHTTPClient(5*time.Second, nil).Transport.(*http.Transport)
+	if transport.Proxy != nil {
+		t.Fatal("transport uses a proxy, connections to it would bypass the address check")
+	}
+
+	// The metadata address is still refused, not fetched through the proxy
+	_, err := NewHTTPClient(5*time.Second, nil).Get("http://169.254.169.254/latest/meta-data/")
+	if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		t.Fatalf("got error %v, want the address to be refused", err)
+	}
+}
+
+func TestParseAllowedNetworks(t *testing.T) {
+	networks, err := ParseAllowedNetworks(" 10.0.0.0/8, 127.0.0.1 ,,fd00::/8")
+	if err != nil {
+		t.Fatalf("ParseAllowedNetworks: %v", err)
+	}
+
+	want := []string{"10.0.0.0/8", "127.0.0.1/32", "fd00::/8"}
+	if len(networks) != len(want) {
+		t.Fatalf("got %v, want %v", networks, want)
+	}
+	for i, network := range networks {
+		if network.String() != want[i] {
+			t.Errorf("network %d is %s, want %s", i, network, want[i])
+		}
+	}
+
+	if _, err := ParseAllowedNetworks("10.0.0.0/33"); err == nil {
+		t.Error("ParseAllowedNetworks accepted an invalid prefix")
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	allowed := []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")}
+
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"[::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+		{"169.254.169.254:80", false},
+		{"192.168.1.10:80", false},
+		{"172.16.0.1:80", false},
+		{"100.64.0.1:80", false},
+		{"0.0.0.0:80", false},
+		{"[fd00::1]:80", false},
+		{"10.2.0.1:80", false},
+		{"10.1.2.3:80", true}, // In the allowed networks
+	}
+
+	for _, tt := range tests {
+		err := checkAddress(tt.address, allowed)
+		if tt.allowed && err != nil {
+			t.Errorf("checkAddress(%s) = %v, want allowed", tt.address, err)
+		}
+		if !tt.allowed && err == nil {
+			t.Errorf("checkAddress(%s) allowed, want refused", tt.address)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not covered by netip.Addr.IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewHTTPClient creates the client used to fetch external calendar URLs. Connections to loopback,
+// link-local, private and other internal addresses are refused, so that calendar URLs cannot reach
+// services inside the deployment, unless the address is in one of the allowed networks.
+// The check runs on the resolved address of every connection, including redirects. Proxies of the
+// environment are not used, the check would see the address of the proxy instead of the calendar host.
+func NewHTTPClient(timeout time.Duration, allowedNetworks []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(address, allowedNetworks)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// ParseAllowedNetworks parses a comma separated list of CIDR prefixes or single IP addresses
+func ParseAllowedNetworks(value string) ([]netip.Prefix, error) {
+	var networks []netip.Prefix
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network %q: %w", item, err)
+			}
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", item, err)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	return networks, nil
+}
+
+// checkAddress refuses internal addresses outside the allowed networks
+func checkAddress(address string, allowedNetworks []netip.Prefix) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	addr := addrPort.Addr().Unmap()
+
+	for _, network := range allowedNetworks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+
+	if isInternalAddress(addr) {
+		return fmt.Errorf("address %s is not allowed for external calendars", addr)
+	}
+	return nil
+}
+
+// isInternalAddress reports whether the address is not publicly routable
+func isInternalAddress(addr netip.Addr) bool {
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,21 @@
+package calendar
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// RegisterExternalCalendarInput represents the input for subscribing to an external calendar URL
+type RegisterExternalCalendarInput struct {
+	ProfessionalID uuid.UUID
+	Name           string
+	URL            string
+}
+
+// UploadExternalCalendarInput represents the input for importing an uploaded .ics file
+type UploadExternalCalendarInput struct {
+	ProfessionalID uuid.UUID
+	Name           string
+	Content        io.Reader
+}
@@ -0,0 +1,156 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+)
+
+const (
+	// feedHistory is how far back the feeds include past appointments
+	feedHistory = 90 * 24 * time.Hour
+
+	// tokenBytes is the amount of randomness in a feed token (hex encoded to 64 characters)
+	tokenBytes = 32
+)
+
+// Service defines the business logic operations for calendar feeds and external calendars
+type Service interface {
+	GetProfessionalFeed(ctx context.Context, professionalID uuid.UUID, token string) ([]*db.GetProfessionalCalendarAppointmentsRow, error)
+	GetClientFeed(ctx context.Context, clientID uuid.UUID, token string) ([]*db.GetClientCalendarAppointmentsRow, error)
+	RegenerateProfessionalToken(ctx context.Context, professionalID uuid.UUID) (*db.CalendarFeed, error)
+	RegenerateClientToken(ctx context.Context, clientID uuid.UUID) (*db.CalendarFeed, error)
+
+	// External calendars
+	RegisterExternalCalendar(ctx context.Context, input RegisterExternalCalendarInput) (*db.ExternalCalendar, error)
+	UploadExternalCalendar(ctx context.Context, input UploadExternalCalendarInput) (*db.ExternalCalendar, error)
+	ListExternalCalendars(ctx context.Context, professionalID uuid.UUID) ([]*db.ExternalCalendar, error)
+	SyncExternalCalendar(ctx context.Context, professionalID, calendarID uuid.UUID) (*db.ExternalCalendar, error)
+	SyncExternalCalendars(ctx context.Context) error
+	DeleteExternalCalendar(ctx context.Context, professionalID, calendarID uuid.UUID) error
+}
+
+type service struct {
+	repo       CalendarRepository
+	httpClient *http.Client
+}
+
+// NewService creates a new calendar service; httpClient is used to fetch external calendar URLs
+func NewService(repo CalendarRepository, httpClient *http.Client) Service {
+	return &service{
+		repo:       repo,
+		httpClient: httpClient,
+	}
+}
+
+// GetProfessionalFeed retrieves the appointments rendered in a professional's feed after validating the token
+func (s *service) GetProfessionalFeed(ctx context.Context, professionalID uuid.UUID, token string) ([]*db.GetProfessionalCalendarAppointmentsRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.GetProfessionalFeed")
+	defer span.End()
+
+	feed, err := s.getFeedByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !feed.ProfessionalID.Valid || feed.ProfessionalID.UUID != professionalID {
+		return nil, svcCommon.ErrForbidden
+	}
+
+	return s.repo.GetProfessionalCalendarAppointments(ctx, &db.GetProfessionalCalendarAppointmentsParams{
+		ProfessionalID: professionalID,
+		StartTime:      time.Now().Add(-feedHistory),
+	})
+}
+
+// GetClientFeed retrieves the appointments rendered in a client's feed after validating the token
+func (s *service) GetClientFeed(ctx context.Context, clientID uuid.UUID, token string) ([]*db.GetClientCalendarAppointmentsRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.GetClientFeed")
+	defer span.End()
+
+	feed, err := s.getFeedByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !feed.ClientID.Valid || feed.ClientID.UUID != clientID {
+		return nil, svcCommon.ErrForbidden
+	}
+
+	return s.repo.GetClientCalendarAppointments(ctx, &db.GetClientCalendarAppointmentsParams{
+		ClientID:  uuid.NullUUID{UUID: clientID, Valid: true},
+		StartTime: time.Now().Add(-feedHistory),
+	})
+}
+
+// RegenerateProfessionalToken issues a new feed token for the professional, invalidating the previous one
+func (s *service) RegenerateProfessionalToken(ctx context.Context, professionalID uuid.UUID) (*db.CalendarFeed, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.RegenerateProfessionalToken")
+	defer span.End()
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.repo.UpsertProfessionalCalendarFeed(ctx, &db.UpsertProfessionalCalendarFeedParams{
+		ProfessionalID: uuid.NullUUID{UUID: professionalID, Valid: true},
+		Token:          token,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return feed, nil
+}
+
+// RegenerateClientToken issues a new feed token for the client, invalidating the previous one
+func (s *service) RegenerateClientToken(ctx context.Context, clientID uuid.UUID) (*db.CalendarFeed, error) {
+	ctx, span := tracing.StartSpan(ctx, "calendar.RegenerateClientToken")
+	defer span.End()
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.repo.UpsertClientCalendarFeed(ctx, &db.UpsertClientCalendarFeedParams{
+		ClientID: uuid.NullUUID{UUID: clientID, Valid: true},
+		Token:    token,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return feed, nil
+}
+
+// getFeedByToken looks up the feed owning the token, treating unknown tokens as forbidden
+func (s *service) getFeedByToken(ctx context.Context, token string) (*db.CalendarFeed, error) {
+	feed, err := s.repo.GetCalendarFeedByToken(ctx, token)
+	if err != nil {
+		err = db.TranslateError(err, svcCommon.ErrNotFound)
+		if errors.Is(err, svcCommon.ErrNotFound) {
+			return nil, svcCommon.ErrForbidden
+		}
+		return nil, err
+	}
+	return feed, nil
+}
+
+// generateToken returns a random hex-encoded feed token
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
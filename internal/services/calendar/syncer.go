@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RunSync re-imports all subscribed external calendars every interval until ctx is cancelled
+func RunSync(ctx context.Context, service Service, interval time.Duration, logger zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.SyncExternalCalendars(ctx); err != nil && ctx.Err() == nil {
+			logger.Error().Err(err).Msg("Failed to sync external calendars")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
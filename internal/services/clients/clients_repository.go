@@ -11,6 +11,15 @@ import (
 type ClientsRepository interface {
 	CreateClient(ctx context.Context, arg *db.CreateClientParams) (*db.Client, error)
 	GetAppointmentsByClientWithStatus(ctx context.Context, arg *db.GetAppointmentsByClientWithStatusParams) ([]*db.GetAppointmentsByClientWithStatusRow, error)
-	GetAppointmentByID(ctx context.Context, id uuid.UUID) (*db.Appointment, error)
+	GetAppointmentByIDForUpdate(ctx context.Context, id uuid.UUID) (*db.Appointment, error)
 	CancelAppointmentByClientWithDetails(ctx context.Context, arg *db.CancelAppointmentByClientWithDetailsParams) (*db.CancelAppointmentByClientWithDetailsRow, error)
+	GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*db.CancellationPolicy, error)
+	GetClientEventsAfter(ctx context.Context, arg *db.GetClientEventsAfterParams) ([]*db.AppointmentEvent, error)
+}
+
+// ClientsStore adds transaction support so that appointment changes are validated
+// and written atomically
+type ClientsStore interface {
+	ClientsRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
 }
@@ -1,6 +1,10 @@
 package clients
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // RegisterClientInput represents the input for registering a client
 type RegisterClientInput struct {
@@ -8,6 +12,7 @@ type RegisterClientInput struct {
 	LastName    string
 	PhoneNumber string
 	ChatID      int64
+	Language    string // Preferred language of messages, empty to negotiate it per request
 }
 
 // CancelAppointmentInput represents the input for canceling an appointment
@@ -15,4 +20,5 @@ type CancelAppointmentInput struct {
 	ClientID           uuid.UUID
 	AppointmentID      uuid.UUID
 	CancellationReason string
+	ExpectedUpdatedAt  *time.Time // Version the client last read, nil skips the check
 }
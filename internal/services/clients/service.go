@@ -3,31 +3,45 @@ package clients
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/metrics"
 	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
 )
 
+// eventBacklogPageSize limits the number of events loaded per page when a stream resumes
+const eventBacklogPageSize = 1000
+
 // Service defines the business logic operations for clients
 type Service interface {
 	RegisterClient(ctx context.Context, input RegisterClientInput) (*db.Client, error)
 	GetClientAppointments(ctx context.Context, clientID uuid.UUID, statusFilter string) ([]*db.GetAppointmentsByClientWithStatusRow, error)
 	CancelAppointment(ctx context.Context, input CancelAppointmentInput) (*db.CancelAppointmentByClientWithDetailsRow, error)
+	GetEventsAfter(ctx context.Context, clientID uuid.UUID, lastEventID int64) ([]*db.AppointmentEvent, error)
 }
 
 type service struct {
-	repo ClientsRepository
+	store    ClientsStore
+	recorder events.Recorder
 }
 
 // NewService creates a new clients service
-func NewService(repo ClientsRepository) Service {
+func NewService(store ClientsStore, recorder events.Recorder) Service {
 	return &service{
-		repo: repo,
+		store:    store,
+		recorder: recorder,
 	}
 }
 
 // RegisterClient registers a new client
 func (s *service) RegisterClient(ctx context.Context, input RegisterClientInput) (*db.Client, error) {
+	ctx, span := tracing.StartSpan(ctx, "clients.RegisterClient")
+	defer span.End()
+
 	params := &db.CreateClientParams{
 		FirstName: input.FirstName,
 		LastName:  input.LastName,
@@ -45,12 +59,18 @@ func (s *service) RegisterClient(ctx context.Context, input RegisterClientInput)
 		params.ChatID.Valid = true
 	}
 
+	// Set optional language
+	if input.Language != "" {
+		params.Language.String = input.Language
+		params.Language.Valid = true
+	}
+
 	// CreatedBy is NULL for self-registration
 	params.CreatedBy = uuid.NullUUID{}
 
-	client, err := s.repo.CreateClient(ctx, params)
+	client, err := s.store.CreateClient(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, db.TranslateError(err, nil)
 	}
 
 	return client, nil
@@ -58,6 +78,9 @@ func (s *service) RegisterClient(ctx context.Context, input RegisterClientInput)
 
 // GetClientAppointments retrieves appointments for a client with optional status filter
 func (s *service) GetClientAppointments(ctx context.Context, clientID uuid.UUID, statusFilter string) ([]*db.GetAppointmentsByClientWithStatusRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "clients.GetClientAppointments")
+	defer span.End()
+
 	params := &db.GetAppointmentsByClientWithStatusParams{
 		ClientID: uuid.NullUUID{UUID: clientID, Valid: true},
 	}
@@ -70,7 +93,7 @@ func (s *service) GetClientAppointments(ctx context.Context, clientID uuid.UUID,
 		}
 	}
 
-	appointments, err := s.repo.GetAppointmentsByClientWithStatus(ctx, params)
+	appointments, err := s.store.GetAppointmentsByClientWithStatus(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -80,24 +103,81 @@ func (s *service) GetClientAppointments(ctx context.Context, clientID uuid.UUID,
 
 // CancelAppointment cancels an appointment with business logic validation
 func (s *service) CancelAppointment(ctx context.Context, input CancelAppointmentInput) (*db.CancelAppointmentByClientWithDetailsRow, error) {
-	// Get appointment for validation
-	appointment, err := s.repo.GetAppointmentByID(ctx, input.AppointmentID)
-	if err != nil {
+	ctx, span := tracing.StartSpan(ctx, "clients.CancelAppointment")
+	defer span.End()
+
+	// Lock, validate and cancel the appointment atomically so that concurrent
+	// confirmations and cancellations cannot both succeed
+	var (
+		appointment *db.Appointment
+		result      *db.CancelAppointmentByClientWithDetailsRow
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		appointment, result, err = s.cancelAppointment(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return s.recorder.Record(ctx, q, events.AppointmentChange{
+			Type:           events.EventAppointmentCancelled,
+			AppointmentID:  result.ID,
+			ProfessionalID: result.ProfessionalID,
+			ClientID:       result.ClientID,
+			Payload: events.AppointmentPayload{
+				Type:               string(result.Type),
+				Status:             string(result.Status.AppointmentStatus),
+				StartTime:          result.StartTime,
+				EndTime:            result.EndTime,
+				Description:        appointment.Description.String,
+				CancellationReason: result.CancellationReason.String,
+				CancelledBy:        events.CancelledByClient,
+				LateCancellation:   result.LateCancellation,
+			},
+		})
+	}); err != nil {
 		return nil, err
 	}
 
+	metrics.AppointmentCancelled(events.CancelledByClient)
+
+	return result, nil
+}
+
+// cancelAppointment cancels an active appointment of the client, locking it until the transaction ends
+func (s *service) cancelAppointment(ctx context.Context, repo ClientsRepository, input CancelAppointmentInput) (*db.Appointment, *db.CancelAppointmentByClientWithDetailsRow, error) {
+	// Lock appointment for validation
+	appointment, err := repo.GetAppointmentByIDForUpdate(ctx, input.AppointmentID)
+	if err != nil {
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
+	}
+
 	// Validate ownership
 	if err := s.validateAppointmentOwnership(appointment, input.ClientID); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate version
+	if err := s.validateAppointmentUnmodified(appointment, input.ExpectedUpdatedAt); err != nil {
+		return nil, nil, err
 	}
 
 	// Validate status
 	if err := s.validateAppointmentCancellable(appointment); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate notice against the professional's cancellation policy
+	policy, err := svcCommon.GetCancellationPolicy(ctx, repo, appointment.ProfessionalID)
+	if err != nil {
+		return nil, nil, err
+	}
+	late, err := s.validateCancellationNotice(appointment, policy, time.Now())
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Cancel appointment
-	result, err := s.repo.CancelAppointmentByClientWithDetails(ctx, &db.CancelAppointmentByClientWithDetailsParams{
+	result, err := repo.CancelAppointmentByClientWithDetails(ctx, &db.CancelAppointmentByClientWithDetailsParams{
 		ID: input.AppointmentID,
 		CancelledByClientID: uuid.NullUUID{
 			UUID:  input.ClientID,
@@ -107,10 +187,23 @@ func (s *service) CancelAppointment(ctx context.Context, input CancelAppointment
 			String: input.CancellationReason,
 			Valid:  input.CancellationReason != "",
 		},
+		LateCancellation: late,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
 	}
 
-	return result, nil
+	return appointment, result, nil
+}
+
+// GetEventsAfter retrieves the client's events recorded after the given event ID, one page at a time
+func (s *service) GetEventsAfter(ctx context.Context, clientID uuid.UUID, lastEventID int64) ([]*db.AppointmentEvent, error) {
+	ctx, span := tracing.StartSpan(ctx, "clients.GetEventsAfter")
+	defer span.End()
+
+	return s.store.GetClientEventsAfter(ctx, &db.GetClientEventsAfterParams{
+		ClientID: uuid.NullUUID{UUID: clientID, Valid: true},
+		ID:       lastEventID,
+		Limit:    eventBacklogPageSize,
+	})
 }
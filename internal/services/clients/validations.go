@@ -1,6 +1,8 @@
 package clients
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	db "github.com/vention/booking_api/internal/repository"
 	svcCommon "github.com/vention/booking_api/internal/services/common"
@@ -14,6 +16,14 @@ func (s *service) validateAppointmentOwnership(appointment *db.Appointment, clie
 	return nil
 }
 
+// validateAppointmentUnmodified validates that the appointment is still at the version the client read
+func (s *service) validateAppointmentUnmodified(appointment *db.Appointment, expectedUpdatedAt *time.Time) error {
+	if expectedUpdatedAt != nil && !appointment.UpdatedAt.Equal(*expectedUpdatedAt) {
+		return svcCommon.ErrAppointmentModified
+	}
+	return nil
+}
+
 // validateAppointmentCancellable validates that the appointment can be cancelled
 func (s *service) validateAppointmentCancellable(appointment *db.Appointment) error {
 	if appointment.Status.AppointmentStatus != db.AppointmentStatusPending &&
@@ -22,3 +32,21 @@ func (s *service) validateAppointmentCancellable(appointment *db.Appointment) er
 	}
 	return nil
 }
+
+// validateCancellationNotice reports whether cancelling a confirmed appointment now gives less
+// notice than the cancellation policy requires, failing when the policy forbids late cancellations.
+// Pending appointments were never accepted, withdrawing them is never late.
+func (s *service) validateCancellationNotice(appointment *db.Appointment, policy *db.CancellationPolicy, now time.Time) (bool, error) {
+	if appointment.Status.AppointmentStatus != db.AppointmentStatusConfirmed {
+		return false, nil
+	}
+
+	notice := time.Duration(policy.MinNoticeHours) * time.Hour
+	if appointment.StartTime.Sub(now) >= notice {
+		return false, nil
+	}
+	if !policy.AllowLateCancellation {
+		return true, svcCommon.ErrLateCancellationNotAllowed
+	}
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// BookingRuleReader reads the booking rules of professionals
+type BookingRuleReader interface {
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+}
+
+// ClientHistoryReader reads the past visits of clients
+type ClientHistoryReader interface {
+	HasClientVisitedProfessional(ctx context.Context, arg *db.HasClientVisitedProfessionalParams) (bool, error)
+}
+
+// DefaultBookingRule is the rule of professionals who have not set one: any future time
+// may be booked, without limits per client
+func DefaultBookingRule(professionalID uuid.UUID) *db.BookingRule {
+	return &db.BookingRule{
+		ProfessionalID:         professionalID,
+		MinNoticeHours:         0,
+		AutoConfirm:            db.AutoConfirmModeOff,
+		AllowTentativeRequests: true,
+	}
+}
+
+// GetBookingRule returns the booking rule of a professional, the default one when they
+// have not set one
+func GetBookingRule(ctx context.Context, repo BookingRuleReader, professionalID uuid.UUID) (*db.BookingRule, error) {
+	rule, err := repo.GetBookingRule(ctx, professionalID)
+	err = db.TranslateError(err, ErrNotFound)
+	if errors.Is(err, ErrNotFound) {
+		return DefaultBookingRule(professionalID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// BookingWindow returns the earliest and latest start times a rule allows booking at now.
+// The latest is zero when the rule has no horizon.
+func BookingWindow(rule *db.BookingRule, now time.Time) (earliest, latest time.Time) {
+	earliest = now.Add(time.Duration(rule.MinNoticeHours) * time.Hour)
+	if rule.MaxAdvanceDays.Valid {
+		latest = now.AddDate(0, 0, int(rule.MaxAdvanceDays.Int32))
+	}
+	return earliest, latest
+}
+
+// InitialStatus returns the status a new appointment of the client gets under the rule:
+// confirmed when the professional auto-confirms bookings of the client, pending otherwise
+func InitialStatus(ctx context.Context, repo ClientHistoryReader, rule *db.BookingRule, clientID uuid.UUID) (db.AppointmentStatus, error) {
+	switch rule.AutoConfirm {
+	case db.AutoConfirmModeAll:
+		return db.AppointmentStatusConfirmed, nil
+	case db.AutoConfirmModeReturningClients:
+		visited, err := repo.HasClientVisitedProfessional(ctx, &db.HasClientVisitedProfessionalParams{
+			ClientID:       uuid.NullUUID{UUID: clientID, Valid: true},
+			ProfessionalID: rule.ProfessionalID,
+		})
+		if err != nil {
+			return "", err
+		}
+		if visited {
+			return db.AppointmentStatusConfirmed, nil
+		}
+	}
+	return db.AppointmentStatusPending, nil
+}
+
+// PendingBlocksBooking reports whether a pending request makes its slot unavailable under
+// the rule: when the professional auto-confirms bookings, as the request may be confirmed at
+// any time, or does not accept requests for tentative slots. Otherwise others may still
+// request the slot until the request is confirmed.
+func PendingBlocksBooking(rule *db.BookingRule) bool {
+	return rule != nil && (rule.AutoConfirm != db.AutoConfirmModeOff || !rule.AllowTentativeRequests)
+}
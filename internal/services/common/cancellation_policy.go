@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// CancellationPolicyReader reads the cancellation policies of professionals
+type CancellationPolicyReader interface {
+	GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*db.CancellationPolicy, error)
+}
+
+// DefaultCancellationPolicy is the policy of professionals who have not set one: clients may
+// cancel at any time, cancellations after the start counting as late
+func DefaultCancellationPolicy(professionalID uuid.UUID) *db.CancellationPolicy {
+	return &db.CancellationPolicy{
+		ProfessionalID:        professionalID,
+		MinNoticeHours:        0,
+		AllowLateCancellation: true,
+	}
+}
+
+// GetCancellationPolicy returns the cancellation policy of a professional, the default one
+// when they have not set one
+func GetCancellationPolicy(ctx context.Context, repo CancellationPolicyReader, professionalID uuid.UUID) (*db.CancellationPolicy, error) {
+	policy, err := repo.GetCancellationPolicy(ctx, professionalID)
+	err = db.TranslateError(err, ErrNotFound)
+	if errors.Is(err, ErrNotFound) {
+		return DefaultCancellationPolicy(professionalID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
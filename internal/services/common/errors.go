@@ -1,6 +1,11 @@
 package common
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
 
 // Domain-level errors that are independent of HTTP
 var (
@@ -14,7 +19,83 @@ var (
 	// Authorization errors
 	ErrForbidden = errors.New("access forbidden")
 
+	// Not found errors, translated by the repository from missing rows and foreign keys.
+	// All of them match ErrNotFound.
+	ErrNotFound             = db.ErrNotFound
+	ErrAppointmentNotFound  = db.ErrAppointmentNotFound
+	ErrProfessionalNotFound = db.ErrProfessionalNotFound
+	ErrClientNotFound       = db.ErrClientNotFound
+
+	// Conflict errors, translated by the repository from unique violations
+	ErrAlreadyExists = db.ErrAlreadyExists
+
 	// Appointment validation errors
 	ErrAppointmentNotPending            = errors.New("appointment is not pending")
 	ErrAppointmentNotPendingOrConfirmed = errors.New("appointment is not pending or confirmed")
+	ErrAppointmentModified              = errors.New("appointment was modified since it was read")
+
+	// Cancellation policy errors
+	ErrLateCancellationNotAllowed = errors.New("cancellation notice is shorter than the policy allows")
+	ErrInvalidNoticeHours         = errors.New("invalid minimum notice hours")
+
+	// Booking rule errors
+	ErrBookingNoticeTooShort      = errors.New("appointment starts sooner than the minimum booking notice")
+	ErrBookingBeyondHorizon       = errors.New("appointment starts beyond the booking horizon")
+	ErrDailyBookingLimitReached   = errors.New("client reached the daily appointment limit")
+	ErrPendingBookingLimitReached = errors.New("client reached the pending request limit")
+	ErrInvalidBookingLimit        = errors.New("invalid booking limit")
+
+	// Waitlist errors
+	ErrWaitlistEntryNotFound = fmt.Errorf("waitlist entry %w", db.ErrNotFound)
+	ErrWaitlistEntryInactive = errors.New("waitlist entry is no longer active")
+	ErrNoWaitlistOffer       = errors.New("waitlist entry has no open offer")
+	ErrSlotOffered           = errors.New("slot is offered to a waitlisted client")
+
+	// Slot hold errors
+	ErrSlotHoldNotFound = fmt.Errorf("slot hold %w", db.ErrNotFound)
+	ErrSlotHoldMismatch = errors.New("slot hold does not match the appointment")
+	ErrSlotHeld         = errors.New("slot is held by another client")
+	ErrSlotTaken        = errors.New("slot is already booked or blocked")
+
+	// External calendar errors
+	ErrExternalCalendarNotFound = fmt.Errorf("external calendar %w", db.ErrNotFound)
+	ErrInvalidCalendarURL       = errors.New("invalid calendar URL")
+	ErrInvalidCalendarData      = errors.New("invalid calendar data")
+	ErrCalendarNotSyncable      = errors.New("uploaded calendars cannot be synced")
+
+	// Import errors
+	ErrInvalidImportFile       = errors.New("invalid import file")
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
+
+	// Statistics and report errors
+	ErrInvalidGranularity = errors.New("invalid granularity")
+	ErrInvalidLimit       = errors.New("invalid limit")
+
+	// Idempotency errors
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key reused for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is in progress")
 )
+
+// FieldError reports the input field that failed a validation. It wraps one of the
+// domain errors above, which still matches with errors.Is.
+type FieldError struct {
+	Field string   // Field as named in the API, e.g. start_time
+	Rule  string   // Violated rule, e.g. required or oneof
+	Args  []string // Name and value pairs of the rule message placeholders, e.g. "field", "start_time"
+	Err   error
+}
+
+// NewFieldError wraps a domain error with the field and rule that caused it and the arguments
+// of the rule message
+func NewFieldError(field, rule string, err error, args ...string) error {
+	return &FieldError{Field: field, Rule: rule, Args: args, Err: err}
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
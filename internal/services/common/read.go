@@ -0,0 +1,18 @@
+package common
+
+import (
+	"fmt"
+	"io"
+)
+
+// ReadAllLimited reads r to the end, failing instead of truncating when it holds more than limit bytes
+func ReadAllLimited(r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("content is larger than %d MB", limit>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,28 @@
+package holds
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RunCleanup purges expired holds every interval until ctx is cancelled
+func RunCleanup(ctx context.Context, service Service, interval time.Duration, logger zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := service.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			logger.Error().Err(err).Msg("Failed to purge expired slot holds")
+		} else if deleted > 0 {
+			logger.Debug().Int64("deleted", deleted).Msg("Purged expired slot holds")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
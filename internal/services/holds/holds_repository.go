@@ -0,0 +1,29 @@
+package holds
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// HoldsRepository defines the database operations needed by the holds service
+type HoldsRepository interface {
+	LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	CreateSlotHold(ctx context.Context, arg *db.CreateSlotHoldParams) (*db.SlotHold, error)
+	DeleteClientSlotHolds(ctx context.Context, arg *db.DeleteClientSlotHoldsParams) (int64, error)
+	CountOverlappingSlotHolds(ctx context.Context, arg *db.CountOverlappingSlotHoldsParams) (int64, error)
+	DeleteExpiredSlotHolds(ctx context.Context) (int64, error)
+	HasOverlappingAppointment(ctx context.Context, arg *db.HasOverlappingAppointmentParams) (bool, error)
+	HasOverlappingConfirmedAppointment(ctx context.Context, arg *db.HasOverlappingConfirmedAppointmentParams) (bool, error)
+	CountOverlappingWaitlistOffers(ctx context.Context, arg *db.CountOverlappingWaitlistOffersParams) (int64, error)
+	HasOverlappingExternalBusyBlock(ctx context.Context, arg *db.HasOverlappingExternalBusyBlockParams) (bool, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+}
+
+// HoldsStore adds transaction support so that concurrent holds of a professional's slots are
+// checked and written one at a time
+type HoldsStore interface {
+	HoldsRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
+}
@@ -0,0 +1,15 @@
+package holds
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateHoldInput represents the input for holding a slot of a professional for a client
+type CreateHoldInput struct {
+	ProfessionalID uuid.UUID
+	ClientID       uuid.UUID
+	StartTime      time.Time
+	EndTime        time.Time
+}
@@ -0,0 +1,110 @@
+package holds
+
+import (
+	"context"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+	"github.com/vention/booking_api/internal/util"
+)
+
+// Service defines the business logic operations for slot holds
+type Service interface {
+	CreateHold(ctx context.Context, input CreateHoldInput) (*db.SlotHold, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// Config contains the settings of slot holds
+type Config struct {
+	TTL time.Duration // How long a slot is held for the client
+}
+
+type service struct {
+	store  HoldsStore
+	config Config
+}
+
+// NewService creates a new holds service
+func NewService(store HoldsStore, config Config) Service {
+	return &service{
+		store:  store,
+		config: config,
+	}
+}
+
+// CreateHold reserves a free slot of the professional for the client until the hold expires,
+// so that other clients cannot book it while the client completes the booking. A client holds
+// one slot of a professional at a time, earlier holds are released.
+func (s *service) CreateHold(ctx context.Context, input CreateHoldInput) (*db.SlotHold, error) {
+	ctx, span := tracing.StartSpan(ctx, "holds.CreateHold")
+	defer span.End()
+
+	startTime := util.ConvertToAppTimezone(input.StartTime)
+	endTime := util.ConvertToAppTimezone(input.EndTime)
+
+	now := time.Now()
+	if err := s.validateHoldTime(startTime, endTime, now); err != nil {
+		return nil, err
+	}
+
+	var hold *db.SlotHold
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		hold, err = s.createHold(ctx, q, input, startTime, endTime, now)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// createHold holds the slot, locking the professional until the transaction ends so that
+// two clients cannot hold the same slot
+func (s *service) createHold(ctx context.Context, repo HoldsRepository, input CreateHoldInput, startTime, endTime, now time.Time) (*db.SlotHold, error) {
+	if _, err := repo.LockProfessional(ctx, input.ProfessionalID); err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+	}
+
+	rule, err := svcCommon.GetBookingRule(ctx, repo, input.ProfessionalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateBookingWindow(startTime, rule, now); err != nil {
+		return nil, err
+	}
+	if err := s.validateSlotFree(ctx, repo, input, startTime, endTime, rule); err != nil {
+		return nil, err
+	}
+
+	if _, err := repo.DeleteClientSlotHolds(ctx, &db.DeleteClientSlotHoldsParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+	}); err != nil {
+		return nil, err
+	}
+
+	hold, err := repo.CreateSlotHold(ctx, &db.CreateSlotHoldParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		ExpiresAt:      now.Add(s.config.TTL),
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return hold, nil
+}
+
+// PurgeExpired deletes holds that expired. Expired holds are ignored before they are purged.
+func (s *service) PurgeExpired(ctx context.Context) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "holds.PurgeExpired")
+	defer span.End()
+
+	return s.store.DeleteExpiredSlotHolds(ctx)
+}
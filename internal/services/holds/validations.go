@@ -0,0 +1,105 @@
+package holds
+
+import (
+	"context"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+)
+
+// validateHoldTime validates the time range of the held slot
+func (s *service) validateHoldTime(startTime, endTime, now time.Time) error {
+	if startTime.Before(now) {
+		return svcCommon.NewFieldError("start_time", "future", svcCommon.ErrPastTime)
+	}
+
+	if !endTime.After(startTime) {
+		return svcCommon.NewFieldError("end_time", "gtfield", svcCommon.ErrInvalidTimeRange, "field", "start_time")
+	}
+
+	return nil
+}
+
+// validateBookingWindow validates that the slot could be booked under the booking rule of the professional
+func (s *service) validateBookingWindow(startTime time.Time, rule *db.BookingRule, now time.Time) error {
+	earliest, latest := svcCommon.BookingWindow(rule, now)
+
+	if startTime.Before(earliest) {
+		return svcCommon.NewFieldError("start_time", "min_notice", svcCommon.ErrBookingNoticeTooShort, "time", earliest.Format(time.RFC3339))
+	}
+	if !latest.IsZero() && startTime.After(latest) {
+		return svcCommon.NewFieldError("start_time", "max_advance", svcCommon.ErrBookingBeyondHorizon, "time", latest.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// validateSlotFree validates that no appointment, unavailable period, external busy block, waitlist
+// offer or hold of another client overlaps the slot. Pending requests are only considered when the booking rule
+// makes them block their slots. Must run with the professional locked.
+func (s *service) validateSlotFree(ctx context.Context, repo HoldsRepository, input CreateHoldInput, startTime, endTime time.Time, rule *db.BookingRule) error {
+	var (
+		taken bool
+		err   error
+	)
+	if svcCommon.PendingBlocksBooking(rule) {
+		taken, err = repo.HasOverlappingAppointment(ctx, &db.HasOverlappingAppointmentParams{
+			ProfessionalID: input.ProfessionalID,
+			EndTime:        endTime,
+			StartTime:      startTime,
+		})
+	} else {
+		taken, err = repo.HasOverlappingConfirmedAppointment(ctx, &db.HasOverlappingConfirmedAppointmentParams{
+			ProfessionalID: input.ProfessionalID,
+			EndTime:        endTime,
+			StartTime:      startTime,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if taken {
+		return svcCommon.ErrSlotTaken
+	}
+
+	offers, err := repo.CountOverlappingWaitlistOffers(ctx, &db.CountOverlappingWaitlistOffersParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if offers > 0 {
+		return svcCommon.ErrSlotOffered
+	}
+
+	holds, err := repo.CountOverlappingSlotHolds(ctx, &db.CountOverlappingSlotHoldsParams{
+		ProfessionalID: input.ProfessionalID,
+		ClientID:       input.ClientID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if holds > 0 {
+		return svcCommon.ErrSlotHeld
+	}
+
+	busy, err := repo.HasOverlappingExternalBusyBlock(ctx, &db.HasOverlappingExternalBusyBlockParams{
+		ProfessionalID: input.ProfessionalID,
+		EndTime:        endTime,
+		StartTime:      startTime,
+	})
+	if err != nil {
+		return err
+	}
+	if busy {
+		return svcCommon.ErrSlotTaken
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// RunCleanup purges expired keys every interval until ctx is cancelled
+func RunCleanup(ctx context.Context, service Service, interval time.Duration, logger zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := service.PurgeExpired(ctx); err != nil && ctx.Err() == nil {
+			logger.Error().Err(err).Msg("Failed to purge expired idempotency keys")
+		} else if deleted > 0 {
+			logger.Debug().Int64("deleted", deleted).Msg("Purged expired idempotency keys")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
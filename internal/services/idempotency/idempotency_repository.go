@@ -0,0 +1,16 @@
+package idempotency
+
+import (
+	"context"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// IdempotencyRepository defines the database operations needed by the idempotency service
+type IdempotencyRepository interface {
+	AcquireIdempotencyKey(ctx context.Context, arg *db.AcquireIdempotencyKeyParams) (*db.IdempotencyKey, error)
+	GetIdempotencyKey(ctx context.Context, arg *db.GetIdempotencyKeyParams) (*db.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, arg *db.CompleteIdempotencyKeyParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg *db.DeleteIdempotencyKeyParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
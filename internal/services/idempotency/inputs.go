@@ -0,0 +1,22 @@
+package idempotency
+
+import "time"
+
+// Config holds the idempotency settings
+type Config struct {
+	TTL time.Duration // How long a key and its stored response are kept
+}
+
+// BeginInput identifies a request sent with an idempotency key
+type BeginInput struct {
+	Scope       string // Method and route, keys are unique per scope
+	Key         string
+	RequestHash string // Hash of the request, a key may only be reused for the same request
+}
+
+// CompleteInput stores the response of the request that acquired a key
+type CompleteInput struct {
+	Scope    string
+	Key      string
+	Response Response
+}
@@ -0,0 +1,140 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+)
+
+// Response is a stored response, replayed when a request is retried with the same key
+type Response struct {
+	Status      int
+	ContentType string
+	Headers     map[string]string // Other headers replayed with the response, e.g. ETag
+	Body        []byte
+}
+
+// Service defines the business logic operations for idempotency keys
+type Service interface {
+	Begin(ctx context.Context, input BeginInput) (*Response, error)
+	Complete(ctx context.Context, input CompleteInput) error
+	Release(ctx context.Context, scope, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type service struct {
+	repo   IdempotencyRepository
+	config Config
+}
+
+// NewService creates a new idempotency service
+func NewService(repo IdempotencyRepository, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// Begin acquires the key for a new request and returns nil, or returns the stored response
+// when the request was already processed. A key that is still being processed or was
+// used for a different request is rejected.
+func (s *service) Begin(ctx context.Context, input BeginInput) (*Response, error) {
+	ctx, span := tracing.StartSpan(ctx, "idempotency.Begin")
+	defer span.End()
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	// Expired keys are taken over, unexpired ones return no row
+	_, err := s.repo.AcquireIdempotencyKey(ctx, &db.AcquireIdempotencyKeyParams{
+		Scope:       input.Scope,
+		Key:         input.Key,
+		RequestHash: input.RequestHash,
+		ExpiresAt:   time.Now().Add(s.config.TTL),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if err = db.TranslateError(err, common.ErrNotFound); !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetIdempotencyKey(ctx, &db.GetIdempotencyKeyParams{
+		Scope: input.Scope,
+		Key:   input.Key,
+	})
+	if err != nil {
+		err = db.TranslateError(err, common.ErrNotFound)
+		if errors.Is(err, common.ErrNotFound) {
+			// Released by a failed request in the meantime, the client may retry
+			return nil, common.ErrIdempotencyKeyInProgress
+		}
+		return nil, err
+	}
+
+	if existing.RequestHash != input.RequestHash {
+		return nil, common.ErrIdempotencyKeyReused
+	}
+
+	if !existing.ResponseStatus.Valid {
+		return nil, common.ErrIdempotencyKeyInProgress
+	}
+
+	var headers map[string]string
+	if err := json.Unmarshal(existing.ResponseHeaders, &headers); err != nil {
+		return nil, fmt.Errorf("decode stored response headers: %w", err)
+	}
+
+	return &Response{
+		Status:      int(existing.ResponseStatus.Int32),
+		ContentType: existing.ResponseContentType.String,
+		Headers:     headers,
+		Body:        existing.ResponseBody,
+	}, nil
+}
+
+// Complete stores the response of a request that acquired its key with Begin
+func (s *service) Complete(ctx context.Context, input CompleteInput) error {
+	ctx, span := tracing.StartSpan(ctx, "idempotency.Complete")
+	defer span.End()
+
+	headers, err := json.Marshal(input.Response.Headers)
+	if err != nil {
+		return fmt.Errorf("encode response headers: %w", err)
+	}
+
+	return s.repo.CompleteIdempotencyKey(ctx, &db.CompleteIdempotencyKeyParams{
+		ResponseStatus:      int32(input.Response.Status),
+		ResponseContentType: input.Response.ContentType,
+		ResponseBody:        input.Response.Body,
+		ResponseHeaders:     headers,
+		Scope:               input.Scope,
+		Key:                 input.Key,
+	})
+}
+
+// Release deletes a key whose request failed, so that a retry is processed again
+func (s *service) Release(ctx context.Context, scope, key string) error {
+	ctx, span := tracing.StartSpan(ctx, "idempotency.Release")
+	defer span.End()
+
+	return s.repo.DeleteIdempotencyKey(ctx, &db.DeleteIdempotencyKeyParams{
+		Scope: scope,
+		Key:   key,
+	})
+}
+
+// PurgeExpired deletes expired keys and returns how many were deleted
+func (s *service) PurgeExpired(ctx context.Context) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "idempotency.PurgeExpired")
+	defer span.End()
+
+	return s.repo.DeleteExpiredIdempotencyKeys(ctx)
+}
@@ -0,0 +1,21 @@
+package idempotency
+
+import (
+	"strconv"
+
+	"github.com/vention/booking_api/internal/services/common"
+)
+
+// MaxKeyLength is the maximum length of an idempotency key
+const MaxKeyLength = 255
+
+// validateKey checks that the key is present and not too long
+func validateKey(key string) error {
+	switch {
+	case key == "":
+		return common.NewFieldError("Idempotency-Key", "required", common.ErrInvalidIdempotencyKey)
+	case len(key) > MaxKeyLength:
+		return common.NewFieldError("Idempotency-Key", "max", common.ErrInvalidIdempotencyKey, "param", strconv.Itoa(MaxKeyLength))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package imports
+
+import (
+	"context"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// ImportsRepository defines the database operations needed by the imports service
+type ImportsRepository interface {
+	LockClientPhoneNumbers(ctx context.Context) error
+	GetClientsByPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]*db.Client, error)
+	GetActiveAppointmentsByProfessionalInRange(ctx context.Context, arg *db.GetActiveAppointmentsByProfessionalInRangeParams) ([]*db.Appointment, error)
+}
+
+// ImportsStore adds transaction support so that an import is committed atomically
+type ImportsStore interface {
+	ImportsRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
+}
@@ -0,0 +1,16 @@
+package imports
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// ImportInput represents the input for importing clients or appointments from a spreadsheet
+type ImportInput struct {
+	ProfessionalID uuid.UUID
+	Format         string            // FormatCSV or FormatXLSX
+	Content        io.Reader         // File content, the first row contains the column headers
+	Mapping        map[string]string // Optional field name to column header mapping
+	DryRun         bool              // Validate only, nothing is written
+}
@@ -0,0 +1,189 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/xuri/excelize/v2"
+)
+
+// Supported import formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+const (
+	// maxImportSize bounds the size of an uploaded spreadsheet
+	maxImportSize = 10 << 20
+
+	// maxImportRows bounds the number of data rows per import
+	maxImportRows = 5000
+
+	// headerRow is the spreadsheet row number of the column headers
+	headerRow = 1
+)
+
+// table is a spreadsheet whose first row contains the column headers
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+// readTable reads all rows of a CSV file or the first sheet of an XLSX workbook
+func readTable(format string, r io.Reader) (*table, error) {
+	format = strings.ToLower(format)
+	if format != FormatCSV && format != FormatXLSX {
+		return nil, svcCommon.NewFieldError("format", "oneof", svcCommon.ErrUnsupportedImportFormat, "values", FormatCSV+", "+FormatXLSX)
+	}
+
+	// Files over the limit are rejected rather than imported partially
+	data, err := svcCommon.ReadAllLimited(r, maxImportSize)
+	if err != nil {
+		return nil, svcCommon.NewFieldError("file", "max", fmt.Errorf("%w: %v", svcCommon.ErrInvalidImportFile, err),
+			"param", fmt.Sprintf("%d MiB", maxImportSize>>20))
+	}
+
+	var records [][]string
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(data)
+	case FormatXLSX:
+		records, err = readXLSX(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", svcCommon.ErrInvalidImportFile, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: missing header row", svcCommon.ErrInvalidImportFile)
+	}
+	if len(records)-1 > maxImportRows {
+		return nil, fmt.Errorf("%w: more than %d rows", svcCommon.ErrInvalidImportFile, maxImportRows)
+	}
+
+	return &table{headers: records[0], rows: records[1:]}, nil
+}
+
+// readCSV reads comma or semicolon separated values, skipping a UTF-8 byte order mark
+func readCSV(data []byte) ([][]string, error) {
+	br := bufio.NewReader(bytes.NewReader(data))
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		if _, err := br.Discard(3); err != nil {
+			return nil, err
+		}
+	}
+
+	// Spreadsheet applications in many locales export semicolon separated files
+	reader := csv.NewReader(br)
+	if head, _ := br.Peek(4096); len(head) > 0 {
+		firstLine, _, _ := bytes.Cut(head, []byte("\n"))
+		if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+			reader.Comma = ';'
+		}
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	return reader.ReadAll()
+}
+
+// readXLSX reads the raw cell values of the first sheet, so dates arrive as Excel serial numbers
+func readXLSX(data []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+
+	return f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+}
+
+// resolveColumns finds the column index of every known field, either through the explicit
+// mapping (field name to header) or by matching header names. Problems are reported on the header row.
+func resolveColumns(headers []string, fields, required []string, mapping map[string]string) (map[string]int, []RowError) {
+	byHeader := make(map[string]int, len(headers))
+	for i, header := range headers {
+		if _, exists := byHeader[normalizeHeader(header)]; !exists {
+			byHeader[normalizeHeader(header)] = i
+		}
+	}
+
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field] = true
+	}
+
+	var errs []RowError
+	columns := make(map[string]int, len(fields))
+	for field, header := range mapping {
+		if !known[field] {
+			errs = append(errs, RowError{Row: headerRow, Field: field, Message: "unknown field"})
+			continue
+		}
+		idx, ok := byHeader[normalizeHeader(header)]
+		if !ok {
+			errs = append(errs, RowError{Row: headerRow, Field: field, Message: fmt.Sprintf("column %q not found", header)})
+			continue
+		}
+		columns[field] = idx
+	}
+
+	for _, field := range fields {
+		if _, mapped := columns[field]; mapped {
+			continue
+		}
+		if _, explicit := mapping[field]; explicit {
+			continue
+		}
+		if idx, ok := byHeader[field]; ok {
+			columns[field] = idx
+		}
+	}
+
+	for _, field := range required {
+		if _, ok := columns[field]; !ok {
+			if _, explicit := mapping[field]; !explicit {
+				errs = append(errs, RowError{Row: headerRow, Field: field, Message: "required column not found"})
+			}
+		}
+	}
+
+	return columns, errs
+}
+
+// normalizeHeader turns a header such as "First Name" into the field name "first_name"
+func normalizeHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(header)
+}
+
+// cell returns the trimmed value of a field in a record, empty when the column is absent
+func cell(record []string, columns map[string]int, field string) string {
+	idx, ok := columns[field]
+	if !ok || idx >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+// isEmptyRecord reports whether all cells of a record are blank
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
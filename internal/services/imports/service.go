@@ -0,0 +1,475 @@
+package imports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+	"github.com/vention/booking_api/internal/util"
+)
+
+// Importable fields, matched against column headers unless mapped explicitly
+const (
+	FieldFirstName         = "first_name"
+	FieldLastName          = "last_name"
+	FieldPhoneNumber       = "phone_number"
+	FieldStartTime         = "start_time"
+	FieldEndTime           = "end_time"
+	FieldStatus            = "status"
+	FieldDescription       = "description"
+	FieldClientFirstName   = "client_first_name"
+	FieldClientLastName    = "client_last_name"
+	FieldClientPhoneNumber = "client_phone_number"
+)
+
+var (
+	clientFields         = []string{FieldFirstName, FieldLastName, FieldPhoneNumber}
+	requiredClientFields = []string{FieldFirstName, FieldLastName}
+
+	appointmentFields = []string{
+		FieldStartTime, FieldEndTime, FieldStatus, FieldDescription,
+		FieldClientFirstName, FieldClientLastName, FieldClientPhoneNumber,
+	}
+	requiredAppointmentFields = []string{FieldStartTime, FieldEndTime}
+)
+
+// Report summarizes the outcome of an import
+type Report struct {
+	DryRun         bool
+	TotalRows      int
+	ValidRows      int
+	ImportedRows   int
+	CreatedClients int
+	Errors         []RowError
+}
+
+// RowError describes why a spreadsheet row (1-based, header is row 1) was rejected
+type RowError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+// Service defines the business logic operations for spreadsheet imports
+type Service interface {
+	ImportClients(ctx context.Context, input ImportInput) (*Report, error)
+	ImportAppointments(ctx context.Context, input ImportInput) (*Report, error)
+}
+
+type service struct {
+	store ImportsStore
+}
+
+// NewService creates a new imports service
+func NewService(store ImportsStore) Service {
+	return &service{
+		store: store,
+	}
+}
+
+// clientCandidate is a validated client row waiting to be created
+type clientCandidate struct {
+	row    int
+	params db.CreateClientParams
+}
+
+// ImportClients validates client rows and, unless in dry-run mode, creates all valid rows in one transaction.
+// Clients whose phone number already exists are rejected as duplicates. The phone numbers are checked
+// in the transaction that creates the clients, so that concurrent imports cannot both create a client.
+func (s *service) ImportClients(ctx context.Context, input ImportInput) (*Report, error) {
+	ctx, span := tracing.StartSpan(ctx, "imports.ImportClients")
+	defer span.End()
+
+	tbl, err := readTable(input.Format, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: input.DryRun}
+	columns, headerErrs := resolveColumns(tbl.headers, clientFields, requiredClientFields, input.Mapping)
+	if len(headerErrs) > 0 {
+		report.Errors = headerErrs
+		return report, nil
+	}
+
+	var (
+		candidates []clientCandidate
+		phones     []string
+		phoneRows  = make(map[string]int)
+	)
+	for i, record := range tbl.rows {
+		if isEmptyRecord(record) {
+			continue
+		}
+		row := i + headerRow + 1
+		report.TotalRows++
+
+		rowErrs := len(report.Errors)
+		firstName := cell(record, columns, FieldFirstName)
+		if msg := validateName(firstName); msg != "" {
+			report.addError(row, FieldFirstName, msg)
+		}
+		lastName := cell(record, columns, FieldLastName)
+		if msg := validateName(lastName); msg != "" {
+			report.addError(row, FieldLastName, msg)
+		}
+
+		params := db.CreateClientParams{
+			FirstName: firstName,
+			LastName:  lastName,
+			CreatedBy: uuid.NullUUID{UUID: input.ProfessionalID, Valid: true},
+		}
+		if raw := cell(record, columns, FieldPhoneNumber); raw != "" {
+			phone, ok := normalizePhoneNumber(raw)
+			switch {
+			case !ok:
+				report.addError(row, FieldPhoneNumber, "invalid phone number")
+			case phoneRows[phone] != 0:
+				report.addError(row, FieldPhoneNumber, fmt.Sprintf("duplicate of row %d", phoneRows[phone]))
+			default:
+				phoneRows[phone] = row
+				params.PhoneNumber.String, params.PhoneNumber.Valid = phone, true
+			}
+		}
+
+		if len(report.Errors) == rowErrs {
+			candidates = append(candidates, clientCandidate{row: row, params: params})
+			if params.PhoneNumber.Valid {
+				phones = append(phones, params.PhoneNumber.String)
+			}
+		}
+	}
+
+	rowErrs := report.Errors
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		report.Errors = rowErrs // The transaction may be retried
+
+		if err := q.LockClientPhoneNumbers(ctx); err != nil {
+			return err
+		}
+		existing, err := existingClientsByPhone(ctx, q, phones)
+		if err != nil {
+			return err
+		}
+
+		var valid []clientCandidate
+		for _, candidate := range candidates {
+			if _, exists := existing[candidate.params.PhoneNumber.String]; candidate.params.PhoneNumber.Valid && exists {
+				report.addError(candidate.row, FieldPhoneNumber, "client with this phone number already exists")
+				continue
+			}
+			valid = append(valid, candidate)
+		}
+		report.ValidRows = len(valid)
+		if input.DryRun {
+			return nil
+		}
+
+		for _, candidate := range valid {
+			if _, err := q.CreateClient(ctx, &candidate.params); err != nil {
+				return fmt.Errorf("row %d: %w", candidate.row, db.TranslateError(err, nil))
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if !input.DryRun {
+		report.ImportedRows = report.ValidRows
+		report.CreatedClients = report.ValidRows
+	}
+
+	report.sortErrors()
+	return report, nil
+}
+
+// appointmentCandidate is a validated appointment row waiting to be created
+type appointmentCandidate struct {
+	row         int
+	start       time.Time
+	end         time.Time
+	status      db.AppointmentStatus
+	description string
+	clientKey   string
+	client      db.CreateClientParams
+}
+
+// ImportAppointments validates appointment rows and, unless in dry-run mode, creates all valid rows
+// in one transaction. Clients are matched by phone number and created when unknown.
+// Rows overlapping an existing appointment or an earlier row of the file are rejected.
+func (s *service) ImportAppointments(ctx context.Context, input ImportInput) (*Report, error) {
+	ctx, span := tracing.StartSpan(ctx, "imports.ImportAppointments")
+	defer span.End()
+
+	tbl, err := readTable(input.Format, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: input.DryRun}
+	columns, headerErrs := resolveColumns(tbl.headers, appointmentFields, requiredAppointmentFields, input.Mapping)
+	if len(headerErrs) > 0 {
+		report.Errors = headerErrs
+		return report, nil
+	}
+
+	loc := util.GetAppTimezone()
+	now := time.Now()
+
+	var (
+		candidates []appointmentCandidate
+		phones     []string
+	)
+	for i, record := range tbl.rows {
+		if isEmptyRecord(record) {
+			continue
+		}
+		row := i + headerRow + 1
+		report.TotalRows++
+
+		rowErrs := len(report.Errors)
+		candidate := appointmentCandidate{row: row, description: cell(record, columns, FieldDescription)}
+
+		start, startErr := parseImportTime(cell(record, columns, FieldStartTime), loc)
+		if startErr != nil {
+			report.addError(row, FieldStartTime, startErr.Error())
+		}
+		end, endErr := parseImportTime(cell(record, columns, FieldEndTime), loc)
+		if endErr != nil {
+			report.addError(row, FieldEndTime, endErr.Error())
+		}
+		if startErr == nil && endErr == nil && !end.After(start) {
+			report.addError(row, FieldEndTime, "must be after start_time")
+		}
+		candidate.start, candidate.end = start, end
+
+		// Historical appointments default to completed, future ones to confirmed
+		switch status := strings.ToLower(cell(record, columns, FieldStatus)); {
+		case status == "" && end.Before(now):
+			candidate.status = db.AppointmentStatusCompleted
+		case status == "":
+			candidate.status = db.AppointmentStatusConfirmed
+		case db.AppointmentStatus(status).Valid():
+			candidate.status = db.AppointmentStatus(status)
+		default:
+			report.addError(row, FieldStatus, "must be one of: pending, confirmed, cancelled, completed")
+		}
+
+		candidate.client = db.CreateClientParams{
+			FirstName: cell(record, columns, FieldClientFirstName),
+			LastName:  cell(record, columns, FieldClientLastName),
+			CreatedBy: uuid.NullUUID{UUID: input.ProfessionalID, Valid: true},
+		}
+		if raw := cell(record, columns, FieldClientPhoneNumber); raw != "" {
+			phone, ok := normalizePhoneNumber(raw)
+			if !ok {
+				report.addError(row, FieldClientPhoneNumber, "invalid phone number")
+			} else {
+				candidate.client.PhoneNumber.String, candidate.client.PhoneNumber.Valid = phone, true
+				candidate.clientKey = phone
+			}
+		} else {
+			candidate.clientKey = "name:" + strings.ToLower(candidate.client.FirstName+" "+candidate.client.LastName)
+		}
+
+		if len(report.Errors) == rowErrs {
+			candidates = append(candidates, candidate)
+			if candidate.client.PhoneNumber.Valid {
+				phones = append(phones, candidate.client.PhoneNumber.String)
+			}
+		}
+	}
+
+	// Overlaps and clients are checked in the transaction that inserts the rows, with the professional
+	// and the phone numbers locked so that appointments booked and clients created meanwhile cannot
+	// end up overlapping imported appointments or duplicating imported clients
+	createdClients := 0
+	rowErrs := report.Errors
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		report.Errors = rowErrs // The transaction may be retried
+
+		if _, err := q.LockProfessional(ctx, input.ProfessionalID); err != nil {
+			return db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+		}
+		if err := q.LockClientPhoneNumbers(ctx); err != nil {
+			return err
+		}
+		existingClients, err := existingClientsByPhone(ctx, q, phones)
+		if err != nil {
+			return err
+		}
+
+		// New clients need names; existing ones are matched by phone number
+		var named []appointmentCandidate
+		for _, candidate := range candidates {
+			if _, exists := existingClients[candidate.clientKey]; !exists {
+				if msg := validateName(candidate.client.FirstName); msg != "" {
+					report.addError(candidate.row, FieldClientFirstName, msg+" for new clients")
+					continue
+				}
+				if msg := validateName(candidate.client.LastName); msg != "" {
+					report.addError(candidate.row, FieldClientLastName, msg+" for new clients")
+					continue
+				}
+			}
+			named = append(named, candidate)
+		}
+
+		valid, err := rejectOverlaps(ctx, q, input.ProfessionalID, named, report)
+		if err != nil {
+			return err
+		}
+		report.ValidRows = len(valid)
+		if input.DryRun {
+			return nil
+		}
+
+		clientIDs := make(map[string]uuid.UUID, len(existingClients))
+		for key, client := range existingClients {
+			clientIDs[key] = client.ID
+		}
+
+		createdClients = 0
+		for _, candidate := range valid {
+			clientID, ok := clientIDs[candidate.clientKey]
+			if !ok {
+				client, err := q.CreateClient(ctx, &candidate.client)
+				if err != nil {
+					return fmt.Errorf("row %d: %w", candidate.row, db.TranslateError(err, nil))
+				}
+				clientID = client.ID
+				clientIDs[candidate.clientKey] = clientID
+				createdClients++
+			}
+
+			if _, err := q.CreateImportedAppointment(ctx, &db.CreateImportedAppointmentParams{
+				ClientID:       uuid.NullUUID{UUID: clientID, Valid: true},
+				ProfessionalID: input.ProfessionalID,
+				StartTime:      candidate.start,
+				EndTime:        candidate.end,
+				Status:         db.NullAppointmentStatus{AppointmentStatus: candidate.status, Valid: true},
+				Description:    toNullString(candidate.description),
+			}); err != nil {
+				return fmt.Errorf("row %d: %w", candidate.row, db.TranslateError(err, nil))
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if !input.DryRun {
+		report.ImportedRows = report.ValidRows
+		report.CreatedClients = createdClients
+	}
+
+	report.sortErrors()
+	return report, nil
+}
+
+// rejectOverlaps removes rows overlapping an existing active appointment or an earlier accepted row.
+// Cancelled rows never block time and are always accepted.
+func rejectOverlaps(ctx context.Context, repo ImportsRepository, professionalID uuid.UUID, candidates []appointmentCandidate, report *Report) ([]appointmentCandidate, error) {
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	rangeStart, rangeEnd := candidates[0].start, candidates[0].end
+	for _, candidate := range candidates {
+		if candidate.start.Before(rangeStart) {
+			rangeStart = candidate.start
+		}
+		if candidate.end.After(rangeEnd) {
+			rangeEnd = candidate.end
+		}
+	}
+
+	existing, err := repo.GetActiveAppointmentsByProfessionalInRange(ctx, &db.GetActiveAppointmentsByProfessionalInRangeParams{
+		ProfessionalID: professionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]appointmentCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+
+	var (
+		valid    []appointmentCandidate
+		blocking *appointmentCandidate // Accepted row with the latest end so far
+	)
+	for i := range sorted {
+		candidate := sorted[i]
+		if candidate.status == db.AppointmentStatusCancelled {
+			valid = append(valid, candidate)
+			continue
+		}
+
+		if blocking != nil && candidate.start.Before(blocking.end) {
+			report.addError(candidate.row, FieldStartTime, fmt.Sprintf("overlaps row %d", blocking.row))
+			continue
+		}
+
+		overlapsExisting := false
+		for _, appt := range existing {
+			if candidate.start.Before(appt.EndTime) && candidate.end.After(appt.StartTime) {
+				overlapsExisting = true
+				break
+			}
+		}
+		if overlapsExisting {
+			report.addError(candidate.row, FieldStartTime, "overlaps an existing appointment")
+			continue
+		}
+
+		valid = append(valid, candidate)
+		if blocking == nil || candidate.end.After(blocking.end) {
+			blocking = &sorted[i]
+		}
+	}
+
+	// Keep the file order for inserts
+	sort.SliceStable(valid, func(i, j int) bool { return valid[i].row < valid[j].row })
+	return valid, nil
+}
+
+// existingClientsByPhone loads the clients owning any of the phone numbers, keyed by phone number
+func existingClientsByPhone(ctx context.Context, repo ImportsRepository, phones []string) (map[string]*db.Client, error) {
+	clients := make(map[string]*db.Client)
+	if len(phones) == 0 {
+		return clients, nil
+	}
+
+	found, err := repo.GetClientsByPhoneNumbers(ctx, phones)
+	if err != nil {
+		return nil, err
+	}
+	for _, client := range found {
+		clients[client.PhoneNumber.String] = client
+	}
+	return clients, nil
+}
+
+// addError records a rejected row
+func (r *Report) addError(row int, field, message string) {
+	r.Errors = append(r.Errors, RowError{Row: row, Field: field, Message: message})
+}
+
+// sortErrors orders errors by row, keeping the field order within a row
+func (r *Report) sortErrors() {
+	sort.SliceStable(r.Errors, func(i, j int) bool { return r.Errors[i].Row < r.Errors[j].Row })
+}
+
+// toNullString converts an optional string to sql.NullString
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
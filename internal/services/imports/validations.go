@@ -0,0 +1,78 @@
+package imports
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// maxNameLength matches the VARCHAR(255) name columns
+const maxNameLength = 255
+
+// phonePattern accepts an optional leading plus followed by 6 to 15 digits (E.164 allows at most 15)
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{6,15}$`)
+
+// importTimeLayouts are the accepted textual date-time formats, interpreted in the application timezone
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04",
+	"02/01/2006 15:04",
+}
+
+// errInvalidTimeFormat is reported for cells that match none of the accepted layouts
+var errInvalidTimeFormat = errors.New("invalid date-time, use YYYY-MM-DD HH:MM")
+
+// validateName validates a required first or last name
+func validateName(name string) string {
+	switch {
+	case name == "":
+		return "is required"
+	case len(name) > maxNameLength:
+		return "is too long"
+	}
+	return ""
+}
+
+// normalizePhoneNumber strips formatting characters such as spaces, dashes and parentheses
+// and reports whether the result is a valid phone number
+func normalizePhoneNumber(raw string) (string, bool) {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.', '/':
+			return -1
+		}
+		return r
+	}, raw)
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+	return normalized, phonePattern.MatchString(normalized)
+}
+
+// parseImportTime parses a date-time cell in the application timezone.
+// Numeric values are treated as Excel serial dates, as produced by XLSX date cells.
+func parseImportTime(value string, loc *time.Location) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return time.Time{}, errInvalidTimeFormat
+		}
+		t = t.Round(time.Second)
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+	}
+
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errInvalidTimeFormat
+}
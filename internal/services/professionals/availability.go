@@ -5,9 +5,20 @@ import (
 	"time"
 
 	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
 )
 
-// TimeSlot represents an availability time slot
+// Types of slots that are tentative or unavailable without a confirmed appointment
+const (
+	SlotTypeExternalBusy         = "external_busy"          // Blocked by an event imported from an external calendar
+	SlotTypeWaitlistOffer        = "waitlist_offer"         // Held for a waitlisted client the freed slot is offered to
+	SlotTypeHeld                 = "held"                   // Held for another client completing a booking
+	SlotTypeTentative            = "tentative"              // Has a pending request
+	SlotTypeOutsideBookingWindow = "outside_booking_window" // Too soon or too far ahead for the booking rule
+)
+
+// TimeSlot represents an availability time slot. Tentative slots are available unless the
+// booking rule makes pending requests block them.
 type TimeSlot struct {
 	StartTime   string
 	EndTime     string
@@ -21,15 +32,26 @@ type AvailabilityConfig struct {
 	WorkingHoursStart int
 	WorkingHoursEnd   int
 	AppTimezone       *time.Location
+	BookingRule       *db.BookingRule // Notice, horizon and auto-confirmation of client bookings, nil for none
 }
 
-// GenerateAvailabilitySlots generates time slots for a specific date with availability info
-func (s *service) GenerateAvailabilitySlots(date time.Time, appointments []*db.GetAppointmentsByProfessionalAndDateWithClientRow, config AvailabilityConfig) []TimeSlot {
+// GenerateAvailabilitySlots generates time slots for a specific date with availability info.
+// External busy blocks make slots unavailable without revealing the details of the private event,
+// as do open waitlist offers, holds of other clients and the booking rule for slots clients cannot
+// book yet or anymore. Slots with pending requests only are tentative, unavailable when the
+// professional auto-confirms bookings or does not accept requests for tentative slots.
+func (s *service) GenerateAvailabilitySlots(date time.Time, appointments []*db.GetAppointmentsByProfessionalAndDateWithClientRow, busyBlocks []*db.ExternalBusyBlock, offers []*db.WaitlistEntry, holds []*db.SlotHold, config AvailabilityConfig) []TimeSlot {
 	slots := make([]TimeSlot, 0, 18)
 
 	// Use provided timezone for current time
 	localNow := time.Now().In(config.AppTimezone)
 
+	var earliestBooking, latestBooking time.Time
+	if config.BookingRule != nil {
+		earliestBooking, latestBooking = svcCommon.BookingWindow(config.BookingRule, localNow)
+	}
+	pendingBlocks := svcCommon.PendingBlocksBooking(config.BookingRule)
+
 	// Create base date in application timezone
 	baseDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, config.AppTimezone)
 
@@ -63,6 +85,10 @@ func (s *service) GenerateAvailabilitySlots(date time.Time, appointments []*db.G
 
 		// Check if this slot conflicts with any existing appointment
 		for _, appointment := range appointments {
+			if appointment.Status.AppointmentStatus == db.AppointmentStatusPending {
+				continue
+			}
+
 			apptStart := appointment.StartTime
 			apptEnd := appointment.EndTime
 
@@ -92,6 +118,59 @@ func (s *service) GenerateAvailabilitySlots(date time.Time, appointments []*db.G
 			}
 		}
 
+		// Check external calendars only if no appointment already blocks the slot
+		if slot.Available {
+			for _, block := range busyBlocks {
+				if startTime.Before(block.EndTime) && endTime.After(block.StartTime) {
+					slot.Available = false
+					slot.Type = SlotTypeExternalBusy
+					break
+				}
+			}
+		}
+
+		// Check waitlist offers only if nothing else blocks the slot
+		if slot.Available {
+			for _, offer := range offers {
+				if startTime.Before(offer.OfferedEndTime.Time) && endTime.After(offer.OfferedStartTime.Time) {
+					slot.Available = false
+					slot.Type = SlotTypeWaitlistOffer
+					break
+				}
+			}
+		}
+
+		// Check slot holds only if nothing else blocks the slot
+		if slot.Available {
+			for _, hold := range holds {
+				if startTime.Before(hold.EndTime) && endTime.After(hold.StartTime) {
+					slot.Available = false
+					slot.Type = SlotTypeHeld
+					break
+				}
+			}
+		}
+
+		// Check pending requests only if nothing else blocks the slot
+		if slot.Available {
+			for _, appointment := range appointments {
+				if appointment.Status.AppointmentStatus == db.AppointmentStatusPending &&
+					startTime.Before(appointment.EndTime) && endTime.After(appointment.StartTime) {
+					slot.Available = !pendingBlocks
+					slot.Type = SlotTypeTentative
+					break
+				}
+			}
+		}
+
+		// Check the booking rule only if the slot is otherwise free
+		if slot.Available && config.BookingRule != nil {
+			if startTime.Before(earliestBooking) || (!latestBooking.IsZero() && startTime.After(latestBooking)) {
+				slot.Available = false
+				slot.Type = SlotTypeOutsideBookingWindow
+			}
+		}
+
 		slots = append(slots, slot)
 	}
 
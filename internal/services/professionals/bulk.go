@@ -0,0 +1,166 @@
+package professionals
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/metrics"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+)
+
+// BulkResult is the outcome of a bulk confirmation or cancellation for one appointment
+type BulkResult struct {
+	AppointmentID uuid.UUID
+	Status        db.AppointmentStatus // Status after the operation, empty when it failed
+	UpdatedAt     time.Time
+	Err           error // Why the appointment was skipped, nil when it succeeded
+}
+
+// ConfirmAppointments confirms the selected appointments of the professional in one transaction.
+// Appointments that are missing, of another professional, not pending or overlapping a confirmed
+// appointment are skipped and reported in their result; other errors roll back the whole batch.
+func (s *service) ConfirmAppointments(ctx context.Context, input BulkConfirmInput) ([]*BulkResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.ConfirmAppointments")
+	defer span.End()
+
+	var (
+		results []*BulkResult
+		changes []events.AppointmentChange
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		ids, err := s.bulkAppointmentIDs(ctx, q, input.ProfessionalID, input.AppointmentIDs, input.Date, db.AppointmentStatusPending)
+		if err != nil {
+			return err
+		}
+
+		results, changes = make([]*BulkResult, 0, len(ids)), nil
+		for _, id := range ids {
+			appointment, result, err := s.confirmAppointment(ctx, q, ConfirmAppointmentInput{
+				ProfessionalID: input.ProfessionalID,
+				AppointmentID:  id,
+			})
+			if isBulkItemError(err) {
+				results = append(results, &BulkResult{AppointmentID: id, Err: err})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			results = append(results, &BulkResult{
+				AppointmentID: id,
+				Status:        result.Status.AppointmentStatus,
+				UpdatedAt:     result.UpdatedAt,
+			})
+			changes = append(changes, confirmedChange(appointment, result))
+		}
+		return s.recordChanges(ctx, q, changes)
+	}); err != nil {
+		return nil, err
+	}
+
+	for range changes {
+		metrics.AppointmentConfirmed()
+	}
+
+	return results, nil
+}
+
+// CancelAppointments cancels the selected appointments of the professional in one transaction
+// with a shared reason. Appointments that are missing, of another professional or no longer
+// active are skipped and reported in their result; other errors roll back the whole batch.
+func (s *service) CancelAppointments(ctx context.Context, input BulkCancelInput) ([]*BulkResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.CancelAppointments")
+	defer span.End()
+
+	var (
+		results []*BulkResult
+		changes []events.AppointmentChange
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		ids, err := s.bulkAppointmentIDs(ctx, q, input.ProfessionalID, input.AppointmentIDs, input.Date, input.Status)
+		if err != nil {
+			return err
+		}
+
+		results, changes = make([]*BulkResult, 0, len(ids)), nil
+		for _, id := range ids {
+			appointment, result, err := s.cancelAppointment(ctx, q, CancelAppointmentInput{
+				ProfessionalID:     input.ProfessionalID,
+				AppointmentID:      id,
+				CancellationReason: input.CancellationReason,
+			})
+			if isBulkItemError(err) {
+				results = append(results, &BulkResult{AppointmentID: id, Err: err})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			results = append(results, &BulkResult{
+				AppointmentID: id,
+				Status:        result.Status.AppointmentStatus,
+				UpdatedAt:     result.UpdatedAt,
+			})
+			changes = append(changes, cancelledChange(appointment, result))
+		}
+		return s.recordChanges(ctx, q, changes)
+	}); err != nil {
+		return nil, err
+	}
+
+	for range changes {
+		metrics.AppointmentCancelled(events.CancelledByProfessional)
+	}
+
+	return results, nil
+}
+
+// recordChanges records the events of a batch once all appointments are processed, so that the
+// event log is locked only for the end of the transaction
+func (s *service) recordChanges(ctx context.Context, repo events.EventsRepository, changes []events.AppointmentChange) error {
+	for _, change := range changes {
+		if err := s.recorder.Record(ctx, repo, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulkAppointmentIDs returns the appointments a bulk operation applies to: the given IDs without
+// duplicates, or the upcoming appointments of the professional on the date with the status, all
+// active ones for an empty status. IDs are sorted so that concurrent batches lock appointments
+// in the same order.
+func (s *service) bulkAppointmentIDs(ctx context.Context, repo ProfessionalsRepository, professionalID uuid.UUID, ids []uuid.UUID, date *time.Time, status db.AppointmentStatus) ([]uuid.UUID, error) {
+	if date != nil {
+		return repo.GetProfessionalAppointmentIDsByDate(ctx, &db.GetProfessionalAppointmentIDsByDateParams{
+			ProfessionalID: professionalID,
+			Status:         db.NullAppointmentStatus{AppointmentStatus: status, Valid: status != ""},
+			Date:           *date,
+		})
+	}
+
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return slices.Compact(sorted), nil
+}
+
+// isBulkItemError reports whether an error skips one appointment of a bulk operation rather than
+// failing the whole batch
+func isBulkItemError(err error) bool {
+	return errors.Is(err, svcCommon.ErrAppointmentNotFound) ||
+		errors.Is(err, svcCommon.ErrForbidden) ||
+		errors.Is(err, svcCommon.ErrAppointmentNotPending) ||
+		errors.Is(err, svcCommon.ErrAppointmentNotPendingOrConfirmed) ||
+		errors.Is(err, svcCommon.ErrSlotTaken)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
 )
 
 // SignInInput represents the input for professional sign-in
@@ -15,8 +16,9 @@ type SignInInput struct {
 
 // ConfirmAppointmentInput represents the input for confirming an appointment
 type ConfirmAppointmentInput struct {
-	ProfessionalID uuid.UUID
-	AppointmentID  uuid.UUID
+	ProfessionalID    uuid.UUID
+	AppointmentID     uuid.UUID
+	ExpectedUpdatedAt *time.Time // Version the client last read, nil skips the check
 }
 
 // CancelAppointmentInput represents the input for canceling an appointment
@@ -24,6 +26,25 @@ type CancelAppointmentInput struct {
 	ProfessionalID     uuid.UUID
 	AppointmentID      uuid.UUID
 	CancellationReason string
+	ExpectedUpdatedAt  *time.Time // Version the client last read, nil skips the check
+}
+
+// BulkConfirmInput represents the input for confirming several appointments at once, selected by
+// ID or as the upcoming pending appointments on a date
+type BulkConfirmInput struct {
+	ProfessionalID uuid.UUID
+	AppointmentIDs []uuid.UUID
+	Date           *time.Time // Selects by date instead of ID when set
+}
+
+// BulkCancelInput represents the input for cancelling several appointments at once with a shared
+// reason, selected by ID or as the upcoming appointments on a date
+type BulkCancelInput struct {
+	ProfessionalID     uuid.UUID
+	AppointmentIDs     []uuid.UUID
+	Date               *time.Time           // Selects by date instead of ID when set
+	Status             db.AppointmentStatus // Restricts the date selection, empty for pending and confirmed
+	CancellationReason string
 }
 
 // CreateUnavailableAppointmentInput represents the input for creating unavailable appointment
@@ -33,3 +54,22 @@ type CreateUnavailableAppointmentInput struct {
 	EndTime        time.Time
 	Description    string
 }
+
+// UpdateCancellationPolicyInput represents the input for setting the cancellation policy of a professional
+type UpdateCancellationPolicyInput struct {
+	ProfessionalID        uuid.UUID
+	MinNoticeHours        int32 // Hours before the start a client may cancel without it counting as late
+	AllowLateCancellation bool  // Whether clients may cancel with less notice at all
+}
+
+// UpdateBookingRuleInput represents the input for setting the booking rule of a professional.
+// Nil limits are not enforced.
+type UpdateBookingRuleInput struct {
+	ProfessionalID         uuid.UUID
+	MinNoticeHours         int32              // Hours ahead of the start an appointment must be booked
+	MaxAdvanceDays         *int32             // Days ahead an appointment may be booked
+	MaxDailyPerClient      *int32             // Appointments a client may have on one day
+	MaxPendingPerClient    *int32             // Unconfirmed requests a client may have
+	AutoConfirm            db.AutoConfirmMode // Bookings confirmed without waiting for the professional
+	AllowTentativeRequests bool               // Whether slots with a pending request may still be requested
+}
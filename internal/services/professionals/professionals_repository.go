@@ -13,12 +13,31 @@ type ProfessionalsRepository interface {
 	GetProfessionals(ctx context.Context) ([]*db.Professional, error)
 	GetProfessionalByUsername(ctx context.Context, username string) (*db.Professional, error)
 	UpdateProfessionalChatID(ctx context.Context, arg *db.UpdateProfessionalChatIDParams) (*db.Professional, error)
-	GetAppointmentByID(ctx context.Context, id uuid.UUID) (*db.Appointment, error)
+	LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetAppointmentByIDForUpdate(ctx context.Context, id uuid.UUID) (*db.Appointment, error)
+	HasOverlappingConfirmedAppointment(ctx context.Context, arg *db.HasOverlappingConfirmedAppointmentParams) (bool, error)
 	ConfirmAppointmentWithDetails(ctx context.Context, arg *db.ConfirmAppointmentWithDetailsParams) (*db.ConfirmAppointmentWithDetailsRow, error)
 	CancelAppointmentByProfessionalWithDetails(ctx context.Context, arg *db.CancelAppointmentByProfessionalWithDetailsParams) (*db.CancelAppointmentByProfessionalWithDetailsRow, error)
 	CreateUnavailableAppointment(ctx context.Context, arg *db.CreateUnavailableAppointmentParams) (*db.Appointment, error)
 	GetAppointmentsByProfessionalWithStatusAndDate(ctx context.Context, arg *db.GetAppointmentsByProfessionalWithStatusAndDateParams) ([]*db.GetAppointmentsByProfessionalWithStatusAndDateRow, error)
 	GetProfessionalAppointmentDates(ctx context.Context, arg *db.GetProfessionalAppointmentDatesParams) ([]time.Time, error)
+	GetProfessionalAppointmentIDsByDate(ctx context.Context, arg *db.GetProfessionalAppointmentIDsByDateParams) ([]uuid.UUID, error)
 	GetAppointmentsByProfessionalAndDateWithClient(ctx context.Context, arg *db.GetAppointmentsByProfessionalAndDateWithClientParams) ([]*db.GetAppointmentsByProfessionalAndDateWithClientRow, error)
 	GetProfessionalTimetable(ctx context.Context, arg *db.GetProfessionalTimetableParams) ([]*db.GetProfessionalTimetableRow, error)
+	GetProfessionalEventsAfter(ctx context.Context, arg *db.GetProfessionalEventsAfterParams) ([]*db.AppointmentEvent, error)
+	GetProfessionalAppointmentsForExport(ctx context.Context, arg *db.GetProfessionalAppointmentsForExportParams) ([]*db.GetProfessionalAppointmentsForExportRow, error)
+	GetExternalBusyBlocksByProfessionalAndRange(ctx context.Context, arg *db.GetExternalBusyBlocksByProfessionalAndRangeParams) ([]*db.ExternalBusyBlock, error)
+	GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*db.CancellationPolicy, error)
+	UpsertCancellationPolicy(ctx context.Context, arg *db.UpsertCancellationPolicyParams) (*db.CancellationPolicy, error)
+	GetOpenWaitlistOffersByProfessionalAndRange(ctx context.Context, arg *db.GetOpenWaitlistOffersByProfessionalAndRangeParams) ([]*db.WaitlistEntry, error)
+	GetActiveSlotHoldsByProfessionalAndRange(ctx context.Context, arg *db.GetActiveSlotHoldsByProfessionalAndRangeParams) ([]*db.SlotHold, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+	UpsertBookingRule(ctx context.Context, arg *db.UpsertBookingRuleParams) (*db.BookingRule, error)
+}
+
+// ProfessionalsStore adds transaction support so that appointment changes are validated
+// and written atomically
+type ProfessionalsStore interface {
+	ProfessionalsRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
 }
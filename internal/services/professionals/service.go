@@ -3,13 +3,30 @@ package professionals
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/metrics"
 	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
 	"github.com/vention/booking_api/internal/util"
 )
 
+// eventBacklogPageSize limits the number of events loaded per page when a stream resumes
+const eventBacklogPageSize = 1000
+
+// exportPageSize is the number of appointments loaded per batch while exporting
+const exportPageSize = 500
+
+// maxNoticeHours bounds the minimum notice of cancellation policies and booking rules to 30 days
+const maxNoticeHours = 720
+
+// maxAdvanceDays bounds the booking horizon of booking rules to a year
+const maxAdvanceDays = 365
+
 // Service defines the business logic operations for professionals
 type Service interface {
 	GetProfessionals(ctx context.Context) ([]*db.Professional, error)
@@ -18,43 +35,68 @@ type Service interface {
 	GetAppointments(ctx context.Context, professionalID uuid.UUID, statusFilter, dateFilter string) ([]*db.GetAppointmentsByProfessionalWithStatusAndDateRow, error)
 	GetAppointmentDates(ctx context.Context, professionalID uuid.UUID, month time.Time) ([]time.Time, error)
 	CancelAppointment(ctx context.Context, input CancelAppointmentInput) (*db.CancelAppointmentByProfessionalWithDetailsRow, error)
+	ConfirmAppointments(ctx context.Context, input BulkConfirmInput) ([]*BulkResult, error)
+	CancelAppointments(ctx context.Context, input BulkCancelInput) ([]*BulkResult, error)
 	CreateUnavailableAppointment(ctx context.Context, input CreateUnavailableAppointmentInput) (*db.Appointment, error)
 	GetAvailability(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.GetAppointmentsByProfessionalAndDateWithClientRow, error)
+	GetExternalBusyBlocks(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.ExternalBusyBlock, error)
+	GetWaitlistOffers(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.WaitlistEntry, error)
+	GetSlotHolds(ctx context.Context, professionalID uuid.UUID, date time.Time, viewerID uuid.NullUUID) ([]*db.SlotHold, error)
 	GetTimetable(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.GetProfessionalTimetableRow, error)
-	GenerateAvailabilitySlots(date time.Time, appointments []*db.GetAppointmentsByProfessionalAndDateWithClientRow, config AvailabilityConfig) []TimeSlot
+	GenerateAvailabilitySlots(date time.Time, appointments []*db.GetAppointmentsByProfessionalAndDateWithClientRow, busyBlocks []*db.ExternalBusyBlock, offers []*db.WaitlistEntry, holds []*db.SlotHold, config AvailabilityConfig) []TimeSlot
+	GetEventsAfter(ctx context.Context, professionalID uuid.UUID, lastEventID int64) ([]*db.AppointmentEvent, error)
+	ExportAppointments(ctx context.Context, professionalID uuid.UUID, from, to time.Time, fn func(*db.GetProfessionalAppointmentsForExportRow) error) error
+	GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*db.CancellationPolicy, error)
+	UpdateCancellationPolicy(ctx context.Context, input UpdateCancellationPolicyInput) (*db.CancellationPolicy, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+	UpdateBookingRule(ctx context.Context, input UpdateBookingRuleInput) (*db.BookingRule, error)
 }
 
 type service struct {
-	repo ProfessionalsRepository
+	store    ProfessionalsStore
+	recorder events.Recorder
 }
 
 // NewService creates a new professionals service
-func NewService(repo ProfessionalsRepository) Service {
+func NewService(store ProfessionalsStore, recorder events.Recorder) Service {
 	return &service{
-		repo: repo,
+		store:    store,
+		recorder: recorder,
 	}
 }
 
 // GetProfessionals retrieves all professionals
 func (s *service) GetProfessionals(ctx context.Context) ([]*db.Professional, error) {
-	return s.repo.GetProfessionals(ctx)
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetProfessionals")
+	defer span.End()
+
+	return s.store.GetProfessionals(ctx)
 }
 
 // SignIn authenticates a professional and updates their chat ID
 func (s *service) SignIn(ctx context.Context, input SignInInput) (*db.Professional, error) {
-	// Get professional by username
-	professional, err := s.repo.GetProfessionalByUsername(ctx, input.Username)
+	ctx, span := tracing.StartSpan(ctx, "professionals.SignIn")
+	defer span.End()
+
+	// Get professional by username, unknown usernames fail like wrong passwords
+	professional, err := s.store.GetProfessionalByUsername(ctx, input.Username)
 	if err != nil {
+		err = db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+		if errors.Is(err, svcCommon.ErrProfessionalNotFound) {
+			metrics.SignInFailed(metrics.SignInUnknownUser)
+			return nil, svcCommon.ErrInvalidCredentials
+		}
 		return nil, err
 	}
 
 	// Validate password
 	if err := s.validatePassword(professional, input.Password); err != nil {
+		metrics.SignInFailed(metrics.SignInInvalidPassword)
 		return nil, err
 	}
 
 	// Update chat ID
-	updatedProfessional, err := s.repo.UpdateProfessionalChatID(ctx, &db.UpdateProfessionalChatIDParams{
+	updatedProfessional, err := s.store.UpdateProfessionalChatID(ctx, &db.UpdateProfessionalChatIDParams{
 		ID: professional.ID,
 		ChatID: sql.NullInt64{
 			Int64: input.ChatID,
@@ -62,7 +104,7 @@ func (s *service) SignIn(ctx context.Context, input SignInInput) (*db.Profession
 		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
 	}
 
 	return updatedProfessional, nil
@@ -70,37 +112,101 @@ func (s *service) SignIn(ctx context.Context, input SignInInput) (*db.Profession
 
 // ConfirmAppointment confirms an appointment with validation
 func (s *service) ConfirmAppointment(ctx context.Context, input ConfirmAppointmentInput) (*db.ConfirmAppointmentWithDetailsRow, error) {
-	// Get appointment
-	appointment, err := s.repo.GetAppointmentByID(ctx, input.AppointmentID)
-	if err != nil {
+	ctx, span := tracing.StartSpan(ctx, "professionals.ConfirmAppointment")
+	defer span.End()
+
+	// Lock, validate and confirm the appointment atomically so that concurrent
+	// confirmations and cancellations cannot both succeed
+	var (
+		appointment *db.Appointment
+		result      *db.ConfirmAppointmentWithDetailsRow
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		appointment, result, err = s.confirmAppointment(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return s.recorder.Record(ctx, q, confirmedChange(appointment, result))
+	}); err != nil {
 		return nil, err
 	}
 
+	metrics.AppointmentConfirmed()
+
+	return result, nil
+}
+
+// confirmAppointment confirms a pending appointment of the professional, locking the professional
+// and the appointment until the transaction ends so that competing requests for a slot cannot
+// both be confirmed
+func (s *service) confirmAppointment(ctx context.Context, repo ProfessionalsRepository, input ConfirmAppointmentInput) (*db.Appointment, *db.ConfirmAppointmentWithDetailsRow, error) {
+	// Lock the professional first, like bookings and holds do
+	if _, err := repo.LockProfessional(ctx, input.ProfessionalID); err != nil {
+		return nil, nil, db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+	}
+
+	// Lock appointment
+	appointment, err := repo.GetAppointmentByIDForUpdate(ctx, input.AppointmentID)
+	if err != nil {
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
+	}
+
 	// Validate ownership
 	if err := s.validateAppointmentOwnership(appointment, input.ProfessionalID); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate version
+	if err := s.validateAppointmentUnmodified(appointment, input.ExpectedUpdatedAt); err != nil {
+		return nil, nil, err
 	}
 
 	// Validate status
 	if err := s.validateAppointmentPending(appointment); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate slot
+	if err := s.validateSlotNotConfirmed(ctx, repo, appointment); err != nil {
+		return nil, nil, err
 	}
 
 	// Confirm appointment
-	result, err := s.repo.ConfirmAppointmentWithDetails(ctx, &db.ConfirmAppointmentWithDetailsParams{
+	result, err := repo.ConfirmAppointmentWithDetails(ctx, &db.ConfirmAppointmentWithDetailsParams{
 		ID:             input.AppointmentID,
 		ProfessionalID: input.ProfessionalID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
 	}
 
-	return result, nil
+	return appointment, result, nil
+}
+
+// confirmedChange describes the confirmation of an appointment for the event log
+func confirmedChange(appointment *db.Appointment, result *db.ConfirmAppointmentWithDetailsRow) events.AppointmentChange {
+	return events.AppointmentChange{
+		Type:           events.EventAppointmentConfirmed,
+		AppointmentID:  result.ID,
+		ProfessionalID: result.ProfessionalID,
+		ClientID:       result.ClientID,
+		Payload: events.AppointmentPayload{
+			Type:        string(result.Type),
+			Status:      string(result.Status.AppointmentStatus),
+			StartTime:   result.StartTime,
+			EndTime:     result.EndTime,
+			Description: appointment.Description.String,
+		},
+	}
 }
 
 // GetAppointments retrieves appointments with optional filters
 func (s *service) GetAppointments(ctx context.Context, professionalID uuid.UUID, statusFilter, dateFilter string) ([]*db.GetAppointmentsByProfessionalWithStatusAndDateRow, error) {
-	return s.repo.GetAppointmentsByProfessionalWithStatusAndDate(ctx, &db.GetAppointmentsByProfessionalWithStatusAndDateParams{
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetAppointments")
+	defer span.End()
+
+	return s.store.GetAppointmentsByProfessionalWithStatusAndDate(ctx, &db.GetAppointmentsByProfessionalWithStatusAndDateParams{
 		ProfessionalID: professionalID,
 		Column2:        statusFilter,
 		Column3:        dateFilter,
@@ -109,6 +215,9 @@ func (s *service) GetAppointments(ctx context.Context, professionalID uuid.UUID,
 
 // GetAppointmentDates retrieves distinct dates with appointments for a month
 func (s *service) GetAppointmentDates(ctx context.Context, professionalID uuid.UUID, month time.Time) ([]time.Time, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetAppointmentDates")
+	defer span.End()
+
 	appTimezone := util.GetAppTimezone()
 	now := time.Now().In(appTimezone)
 
@@ -126,7 +235,7 @@ func (s *service) GetAppointmentDates(ctx context.Context, professionalID uuid.U
 		startTime = startOfMonth
 	}
 
-	return s.repo.GetProfessionalAppointmentDates(ctx, &db.GetProfessionalAppointmentDatesParams{
+	return s.store.GetProfessionalAppointmentDates(ctx, &db.GetProfessionalAppointmentDatesParams{
 		ProfessionalID: professionalID,
 		StartTime:      startTime,
 		StartTime_2:    endOfMonth,
@@ -135,24 +244,56 @@ func (s *service) GetAppointmentDates(ctx context.Context, professionalID uuid.U
 
 // CancelAppointment cancels an appointment with validation
 func (s *service) CancelAppointment(ctx context.Context, input CancelAppointmentInput) (*db.CancelAppointmentByProfessionalWithDetailsRow, error) {
-	// Get appointment
-	appointment, err := s.repo.GetAppointmentByID(ctx, input.AppointmentID)
-	if err != nil {
+	ctx, span := tracing.StartSpan(ctx, "professionals.CancelAppointment")
+	defer span.End()
+
+	// Lock, validate and cancel the appointment atomically so that concurrent
+	// confirmations and cancellations cannot both succeed
+	var (
+		appointment *db.Appointment
+		result      *db.CancelAppointmentByProfessionalWithDetailsRow
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		appointment, result, err = s.cancelAppointment(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return s.recorder.Record(ctx, q, cancelledChange(appointment, result))
+	}); err != nil {
 		return nil, err
 	}
 
+	metrics.AppointmentCancelled(events.CancelledByProfessional)
+
+	return result, nil
+}
+
+// cancelAppointment cancels an active appointment of the professional, locking it until the transaction ends
+func (s *service) cancelAppointment(ctx context.Context, repo ProfessionalsRepository, input CancelAppointmentInput) (*db.Appointment, *db.CancelAppointmentByProfessionalWithDetailsRow, error) {
+	// Lock appointment
+	appointment, err := repo.GetAppointmentByIDForUpdate(ctx, input.AppointmentID)
+	if err != nil {
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
+	}
+
 	// Validate ownership
 	if err := s.validateAppointmentOwnership(appointment, input.ProfessionalID); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Validate version
+	if err := s.validateAppointmentUnmodified(appointment, input.ExpectedUpdatedAt); err != nil {
+		return nil, nil, err
 	}
 
 	// Validate status
 	if err := s.validateAppointmentCancellable(appointment); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Cancel appointment
-	result, err := s.repo.CancelAppointmentByProfessionalWithDetails(ctx, &db.CancelAppointmentByProfessionalWithDetailsParams{
+	result, err := repo.CancelAppointmentByProfessionalWithDetails(ctx, &db.CancelAppointmentByProfessionalWithDetailsParams{
 		ID: input.AppointmentID,
 		CancelledByProfessionalID: uuid.NullUUID{
 			UUID:  input.ProfessionalID,
@@ -164,48 +305,256 @@ func (s *service) CancelAppointment(ctx context.Context, input CancelAppointment
 		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
 	}
 
-	return result, nil
+	return appointment, result, nil
+}
+
+// cancelledChange describes the cancellation of an appointment by the professional for the event log
+func cancelledChange(appointment *db.Appointment, result *db.CancelAppointmentByProfessionalWithDetailsRow) events.AppointmentChange {
+	return events.AppointmentChange{
+		Type:           events.EventAppointmentCancelled,
+		AppointmentID:  result.ID,
+		ProfessionalID: result.ProfessionalID,
+		ClientID:       result.ClientID,
+		Payload: events.AppointmentPayload{
+			Type:               string(result.Type),
+			Status:             string(result.Status.AppointmentStatus),
+			StartTime:          result.StartTime,
+			EndTime:            result.EndTime,
+			Description:        appointment.Description.String,
+			CancellationReason: result.CancellationReason.String,
+			CancelledBy:        events.CancelledByProfessional,
+		},
+	}
 }
 
 // CreateUnavailableAppointment creates an unavailable time slot with validation
 func (s *service) CreateUnavailableAppointment(ctx context.Context, input CreateUnavailableAppointmentInput) (*db.Appointment, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.CreateUnavailableAppointment")
+	defer span.End()
+
 	// Validate time range
 	if err := s.validateTimeRange(input.StartTime, input.EndTime); err != nil {
 		return nil, err
 	}
 
-	// Create unavailable appointment
-	appointment, err := s.repo.CreateUnavailableAppointment(ctx, &db.CreateUnavailableAppointmentParams{
-		ProfessionalID: input.ProfessionalID,
-		StartTime:      input.StartTime,
-		EndTime:        input.EndTime,
-		Description: sql.NullString{
-			String: input.Description,
-			Valid:  input.Description != "",
-		},
-	})
-	if err != nil {
+	// Create unavailable appointment and record its event atomically
+	var appointment *db.Appointment
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		appointment, err = q.CreateUnavailableAppointment(ctx, &db.CreateUnavailableAppointmentParams{
+			ProfessionalID: input.ProfessionalID,
+			StartTime:      input.StartTime,
+			EndTime:        input.EndTime,
+			Description: sql.NullString{
+				String: input.Description,
+				Valid:  input.Description != "",
+			},
+		})
+		if err != nil {
+			return db.TranslateError(err, nil)
+		}
+
+		return s.recorder.Record(ctx, q, events.AppointmentChange{
+			Type:           events.EventAppointmentCreated,
+			AppointmentID:  appointment.ID,
+			ProfessionalID: appointment.ProfessionalID,
+			ClientID:       appointment.ClientID,
+			Payload: events.AppointmentPayload{
+				Type:        string(appointment.Type),
+				Status:      string(appointment.Status.AppointmentStatus),
+				StartTime:   appointment.StartTime,
+				EndTime:     appointment.EndTime,
+				Description: appointment.Description.String,
+			},
+		})
+	}); err != nil {
 		return nil, err
 	}
 
+	metrics.AppointmentCreated(string(appointment.Type))
+
 	return appointment, nil
 }
 
-// GetAvailability retrieves appointments for availability calculation
+// GetAvailability retrieves appointments for availability calculation, including pending
+// requests which make their slots tentative
 func (s *service) GetAvailability(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.GetAppointmentsByProfessionalAndDateWithClientRow, error) {
-	return s.repo.GetAppointmentsByProfessionalAndDateWithClient(ctx, &db.GetAppointmentsByProfessionalAndDateWithClientParams{
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetAvailability")
+	defer span.End()
+
+	return s.store.GetAppointmentsByProfessionalAndDateWithClient(ctx, &db.GetAppointmentsByProfessionalAndDateWithClientParams{
 		ProfessionalID: professionalID,
 		StartTime:      date,
 	})
 }
 
+// GetExternalBusyBlocks retrieves busy blocks imported from external calendars overlapping the given day
+func (s *service) GetExternalBusyBlocks(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.ExternalBusyBlock, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetExternalBusyBlocks")
+	defer span.End()
+
+	return s.store.GetExternalBusyBlocksByProfessionalAndRange(ctx, &db.GetExternalBusyBlocksByProfessionalAndRangeParams{
+		ProfessionalID: professionalID,
+		RangeStart:     date,
+		RangeEnd:       date.AddDate(0, 0, 1),
+	})
+}
+
+// GetWaitlistOffers retrieves the open waitlist offers of slots on a specific date
+func (s *service) GetWaitlistOffers(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.WaitlistEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetWaitlistOffers")
+	defer span.End()
+
+	return s.store.GetOpenWaitlistOffersByProfessionalAndRange(ctx, &db.GetOpenWaitlistOffersByProfessionalAndRangeParams{
+		ProfessionalID: professionalID,
+		RangeEnd:       date.AddDate(0, 0, 1),
+		RangeStart:     date,
+	})
+}
+
+// GetSlotHolds retrieves the active holds of slots on a specific date. Holds of the viewing
+// client are left out, the client may still book the slot they hold.
+func (s *service) GetSlotHolds(ctx context.Context, professionalID uuid.UUID, date time.Time, viewerID uuid.NullUUID) ([]*db.SlotHold, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetSlotHolds")
+	defer span.End()
+
+	return s.store.GetActiveSlotHoldsByProfessionalAndRange(ctx, &db.GetActiveSlotHoldsByProfessionalAndRangeParams{
+		ProfessionalID:  professionalID,
+		RangeEnd:        date.AddDate(0, 0, 1),
+		RangeStart:      date,
+		ExcludeClientID: viewerID,
+	})
+}
+
 // GetTimetable retrieves timetable for a specific date
 func (s *service) GetTimetable(ctx context.Context, professionalID uuid.UUID, date time.Time) ([]*db.GetProfessionalTimetableRow, error) {
-	return s.repo.GetProfessionalTimetable(ctx, &db.GetProfessionalTimetableParams{
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetTimetable")
+	defer span.End()
+
+	return s.store.GetProfessionalTimetable(ctx, &db.GetProfessionalTimetableParams{
 		ProfessionalID: professionalID,
 		StartTime:      date,
 	})
 }
+
+// GetEventsAfter retrieves the professional's events recorded after the given event ID, one page at a time
+func (s *service) GetEventsAfter(ctx context.Context, professionalID uuid.UUID, lastEventID int64) ([]*db.AppointmentEvent, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetEventsAfter")
+	defer span.End()
+
+	return s.store.GetProfessionalEventsAfter(ctx, &db.GetProfessionalEventsAfterParams{
+		ProfessionalID: professionalID,
+		ID:             lastEventID,
+		Limit:          eventBacklogPageSize,
+	})
+}
+
+// ExportAppointments calls fn for every appointment starting in [from, to), ordered by start time.
+// Appointments are loaded in batches so that large exports are streamed with bounded memory.
+func (s *service) ExportAppointments(ctx context.Context, professionalID uuid.UUID, from, to time.Time, fn func(*db.GetProfessionalAppointmentsForExportRow) error) error {
+	ctx, span := tracing.StartSpan(ctx, "professionals.ExportAppointments")
+	defer span.End()
+
+	params := &db.GetProfessionalAppointmentsForExportParams{
+		ProfessionalID: professionalID,
+		RangeStart:     from,
+		RangeEnd:       to,
+		PageSize:       exportPageSize,
+	}
+
+	for {
+		rows, err := s.store.GetProfessionalAppointmentsForExport(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < exportPageSize {
+			return nil
+		}
+
+		last := rows[len(rows)-1]
+		params.AfterStartTime, params.AfterID = last.StartTime, last.ID
+	}
+}
+
+// GetCancellationPolicy retrieves the cancellation policy of a professional, the default
+// policy when they have not set one
+func (s *service) GetCancellationPolicy(ctx context.Context, professionalID uuid.UUID) (*db.CancellationPolicy, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetCancellationPolicy")
+	defer span.End()
+
+	return svcCommon.GetCancellationPolicy(ctx, s.store, professionalID)
+}
+
+// UpdateCancellationPolicy sets the cancellation policy applied when clients cancel
+// appointments of the professional
+func (s *service) UpdateCancellationPolicy(ctx context.Context, input UpdateCancellationPolicyInput) (*db.CancellationPolicy, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.UpdateCancellationPolicy")
+	defer span.End()
+
+	if err := s.validateNoticeHours(input.MinNoticeHours); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.store.UpsertCancellationPolicy(ctx, &db.UpsertCancellationPolicyParams{
+		ProfessionalID:        input.ProfessionalID,
+		MinNoticeHours:        input.MinNoticeHours,
+		AllowLateCancellation: input.AllowLateCancellation,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return policy, nil
+}
+
+// GetBookingRule retrieves the booking rule of a professional, the default rule when they
+// have not set one
+func (s *service) GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.GetBookingRule")
+	defer span.End()
+
+	return svcCommon.GetBookingRule(ctx, s.store, professionalID)
+}
+
+// UpdateBookingRule sets the booking rule applied when clients book appointments with the professional
+func (s *service) UpdateBookingRule(ctx context.Context, input UpdateBookingRuleInput) (*db.BookingRule, error) {
+	ctx, span := tracing.StartSpan(ctx, "professionals.UpdateBookingRule")
+	defer span.End()
+
+	if err := s.validateBookingRule(input); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.store.UpsertBookingRule(ctx, &db.UpsertBookingRuleParams{
+		ProfessionalID:         input.ProfessionalID,
+		MinNoticeHours:         input.MinNoticeHours,
+		MaxAdvanceDays:         nullInt32(input.MaxAdvanceDays),
+		MaxDailyPerClient:      nullInt32(input.MaxDailyPerClient),
+		MaxPendingPerClient:    nullInt32(input.MaxPendingPerClient),
+		AutoConfirm:            input.AutoConfirm,
+		AllowTentativeRequests: input.AllowTentativeRequests,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return rule, nil
+}
+
+// nullInt32 converts an optional limit to its column value, NULL meaning no limit
+func nullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
@@ -0,0 +1,131 @@
+package professionals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+)
+
+// fakeRepository keeps the appointments of one professional in memory
+type fakeRepository struct {
+	ProfessionalsRepository
+
+	professionalID uuid.UUID
+	appointments   map[uuid.UUID]*db.Appointment
+}
+
+func (r *fakeRepository) LockProfessional(_ context.Context, id uuid.UUID) (uuid.UUID, error) {
+	if id != r.professionalID {
+		return uuid.UUID{}, sql.ErrNoRows
+	}
+	return id, nil
+}
+
+func (r *fakeRepository) GetAppointmentByIDForUpdate(_ context.Context, id uuid.UUID) (*db.Appointment, error) {
+	appointment, ok := r.appointments[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *appointment
+	return &copied, nil
+}
+
+func (r *fakeRepository) HasOverlappingConfirmedAppointment(_ context.Context, arg *db.HasOverlappingConfirmedAppointmentParams) (bool, error) {
+	for _, a := range r.appointments {
+		status := a.Status.AppointmentStatus
+		if a.ProfessionalID != arg.ProfessionalID || status == db.AppointmentStatusCancelled || status == db.AppointmentStatusPending {
+			continue
+		}
+		if arg.ExcludeID.Valid && a.ID == arg.ExcludeID.UUID {
+			continue
+		}
+		if a.StartTime.Before(arg.EndTime) && a.EndTime.After(arg.StartTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRepository) ConfirmAppointmentWithDetails(_ context.Context, arg *db.ConfirmAppointmentWithDetailsParams) (*db.ConfirmAppointmentWithDetailsRow, error) {
+	appointment, ok := r.appointments[arg.ID]
+	if !ok || appointment.ProfessionalID != arg.ProfessionalID {
+		return nil, sql.ErrNoRows
+	}
+	appointment.Status = db.NullAppointmentStatus{AppointmentStatus: db.AppointmentStatusConfirmed, Valid: true}
+	appointment.UpdatedAt = time.Now()
+	return &db.ConfirmAppointmentWithDetailsRow{
+		ID:             appointment.ID,
+		ProfessionalID: appointment.ProfessionalID,
+		StartTime:      appointment.StartTime,
+		EndTime:        appointment.EndTime,
+		Status:         appointment.Status,
+		UpdatedAt:      appointment.UpdatedAt,
+	}, nil
+}
+
+// addPending adds a pending request of the professional
+func (r *fakeRepository) addPending(start time.Time, duration time.Duration) uuid.UUID {
+	id := uuid.New()
+	r.appointments[id] = &db.Appointment{
+		ID:             id,
+		Type:           db.AppointmentTypeAppointment,
+		ClientID:       uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		ProfessionalID: r.professionalID,
+		StartTime:      start,
+		EndTime:        start.Add(duration),
+		Status:         db.NullAppointmentStatus{AppointmentStatus: db.AppointmentStatusPending, Valid: true},
+	}
+	return id
+}
+
+func TestConfirmCompetingRequests(t *testing.T) {
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	tests := []struct {
+		name        string
+		secondStart time.Time
+		wantErr     error
+	}{
+		{name: "same slot", secondStart: start, wantErr: svcCommon.ErrSlotTaken},
+		{name: "partially overlapping", secondStart: start.Add(30 * time.Minute), wantErr: svcCommon.ErrSlotTaken},
+		{name: "adjacent", secondStart: start.Add(time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{professionalID: uuid.New(), appointments: map[uuid.UUID]*db.Appointment{}}
+			first := repo.addPending(start, time.Hour)
+			second := repo.addPending(tt.secondStart, time.Hour)
+			s := &service{}
+
+			if _, _, err := s.confirmAppointment(context.Background(), repo, ConfirmAppointmentInput{
+				ProfessionalID: repo.professionalID,
+				AppointmentID:  first,
+			}); err != nil {
+				t.Fatalf("confirm first request: %v", err)
+			}
+
+			_, _, err := s.confirmAppointment(context.Background(), repo, ConfirmAppointmentInput{
+				ProfessionalID: repo.professionalID,
+				AppointmentID:  second,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("confirm second request: got %v, want %v", err, tt.wantErr)
+			}
+
+			wantStatus := db.AppointmentStatusConfirmed
+			if tt.wantErr != nil {
+				wantStatus = db.AppointmentStatusPending
+			}
+			if got := repo.appointments[second].Status.AppointmentStatus; got != wantStatus {
+				t.Errorf("second request is %s, want %s", got, wantStatus)
+			}
+		})
+	}
+}
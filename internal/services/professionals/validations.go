@@ -1,6 +1,8 @@
 package professionals
 
 import (
+	"context"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -40,6 +42,32 @@ func (s *service) validateAppointmentPending(appointment *db.Appointment) error
 	return nil
 }
 
+// validateSlotNotConfirmed validates that no other appointment or unavailable block of the
+// professional overlaps the appointment being confirmed. Must run with the professional locked.
+func (s *service) validateSlotNotConfirmed(ctx context.Context, repo ProfessionalsRepository, appointment *db.Appointment) error {
+	taken, err := repo.HasOverlappingConfirmedAppointment(ctx, &db.HasOverlappingConfirmedAppointmentParams{
+		ProfessionalID: appointment.ProfessionalID,
+		EndTime:        appointment.EndTime,
+		StartTime:      appointment.StartTime,
+		ExcludeID:      uuid.NullUUID{UUID: appointment.ID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if taken {
+		return svcCommon.ErrSlotTaken
+	}
+	return nil
+}
+
+// validateAppointmentUnmodified validates that the appointment is still at the version the client read
+func (s *service) validateAppointmentUnmodified(appointment *db.Appointment, expectedUpdatedAt *time.Time) error {
+	if expectedUpdatedAt != nil && !appointment.UpdatedAt.Equal(*expectedUpdatedAt) {
+		return svcCommon.ErrAppointmentModified
+	}
+	return nil
+}
+
 // validateAppointmentCancellable validates that the appointment can be cancelled
 func (s *service) validateAppointmentCancellable(appointment *db.Appointment) error {
 	if appointment.Status.AppointmentStatus != db.AppointmentStatusPending &&
@@ -53,12 +81,46 @@ func (s *service) validateAppointmentCancellable(appointment *db.Appointment) er
 func (s *service) validateTimeRange(startTime, endTime time.Time) error {
 	// Check if start time is in the future
 	if startTime.Before(time.Now()) {
-		return svcCommon.ErrPastTime
+		return svcCommon.NewFieldError("start_at", "future", svcCommon.ErrPastTime)
 	}
 
 	// Check if end time is after start time
 	if endTime.Before(startTime) || endTime.Equal(startTime) {
-		return svcCommon.ErrInvalidTimeRange
+		return svcCommon.NewFieldError("end_at", "gtfield", svcCommon.ErrInvalidTimeRange, "field", "start_at")
+	}
+
+	return nil
+}
+
+// validateNoticeHours validates the minimum notice of a cancellation policy
+func (s *service) validateNoticeHours(hours int32) error {
+	if hours < 0 || hours > maxNoticeHours {
+		return svcCommon.NewFieldError("min_notice_hours", "max", svcCommon.ErrInvalidNoticeHours, "param", strconv.Itoa(maxNoticeHours))
+	}
+	return nil
+}
+
+// validateBookingRule validates the notice, horizon and limits of a booking rule
+func (s *service) validateBookingRule(input UpdateBookingRuleInput) error {
+	if err := s.validateNoticeHours(input.MinNoticeHours); err != nil {
+		return err
+	}
+
+	if input.MaxAdvanceDays != nil {
+		days := *input.MaxAdvanceDays
+		if days < 1 || days > maxAdvanceDays {
+			return svcCommon.NewFieldError("max_advance_days", "max", svcCommon.ErrInvalidBookingLimit, "param", strconv.Itoa(maxAdvanceDays))
+		}
+		// The notice must leave part of the horizon bookable
+		if int64(input.MinNoticeHours) >= int64(days)*24 {
+			return svcCommon.NewFieldError("min_notice_hours", "ltfield", svcCommon.ErrInvalidBookingLimit, "field", "max_advance_days")
+		}
+	}
+	if input.MaxDailyPerClient != nil && *input.MaxDailyPerClient < 1 {
+		return svcCommon.NewFieldError("max_daily_per_client", "min", svcCommon.ErrInvalidBookingLimit, "param", "1")
+	}
+	if input.MaxPendingPerClient != nil && *input.MaxPendingPerClient < 1 {
+		return svcCommon.NewFieldError("max_pending_per_client", "min", svcCommon.ErrInvalidBookingLimit, "param", "1")
 	}
 
 	return nil
@@ -0,0 +1,70 @@
+package reports
+
+import (
+	"sync"
+	"time"
+)
+
+// reportCache keeps computed reports in memory for a fixed time, reports aggregate
+// over all appointments and are too expensive to compute on every dashboard refresh
+type reportCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+func newReportCache(ttl time.Duration) *reportCache {
+	return &reportCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the cached value for key if it has not expired
+func (c *reportCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set stores value for key and drops expired entries
+func (c *reportCache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// cached returns the cached report for key, computing and storing it on a miss
+func cached[T any](c *reportCache, key string, compute func() (T, error)) (T, error) {
+	if c.ttl <= 0 {
+		return compute()
+	}
+
+	if value, ok := c.get(key); ok {
+		return value.(T), nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return value, err
+	}
+	c.set(key, value)
+	return value, nil
+}
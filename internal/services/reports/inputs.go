@@ -0,0 +1,27 @@
+package reports
+
+import "time"
+
+// ReportRange is the date range of a report, both days inclusive
+type ReportRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// BookingTotalsInput represents the input for the booking totals report
+type BookingTotalsInput struct {
+	ReportRange
+	Granularity string // GranularityDay, GranularityWeek or GranularityMonth
+}
+
+// RankingInput represents the input for reports limited to the top entries
+type RankingInput struct {
+	ReportRange
+	Limit int // Between 1 and MaxLimit
+}
+
+// Config contains the reports service settings
+type Config struct {
+	CacheTTL    time.Duration // Zero disables caching
+	AppTimezone *time.Location
+}
@@ -0,0 +1,18 @@
+package reports
+
+import (
+	"context"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// ReportsRepository defines the database aggregates needed by the reports service
+type ReportsRepository interface {
+	GetBookingTotals(ctx context.Context, arg *db.GetBookingTotalsParams) ([]*db.GetBookingTotalsRow, error)
+	GetConfirmationLatency(ctx context.Context, arg *db.GetConfirmationLatencyParams) (*db.GetConfirmationLatencyRow, error)
+	GetTopProfessionalsByBookedHours(ctx context.Context, arg *db.GetTopProfessionalsByBookedHoursParams) ([]*db.GetTopProfessionalsByBookedHoursRow, error)
+	CountClientsCreatedBefore(ctx context.Context, createdBefore time.Time) (int32, error)
+	GetNewClientsByMonth(ctx context.Context, arg *db.GetNewClientsByMonthParams) ([]*db.GetNewClientsByMonthRow, error)
+	GetCancellationReasons(ctx context.Context, arg *db.GetCancellationReasonsParams) ([]*db.GetCancellationReasonsRow, error)
+}
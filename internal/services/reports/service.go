@@ -0,0 +1,341 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/tracing"
+)
+
+// Bucket granularities of the booking totals report, passed to date_trunc as is
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// MaxLimit is the maximum number of entries of ranking reports
+const MaxLimit = 100
+
+// BookingCounts holds appointment counts per status
+type BookingCounts struct {
+	Total     int
+	Pending   int
+	Confirmed int
+	Cancelled int
+	Completed int
+}
+
+// BookingBucket holds the bookings of one day, week or month
+type BookingBucket struct {
+	Start time.Time
+	BookingCounts
+}
+
+// BookingTotals is the booking totals report, buckets without bookings are omitted
+type BookingTotals struct {
+	GeneratedAt time.Time
+	Total       BookingCounts
+	Buckets     []BookingBucket
+}
+
+// ConfirmationLatency is the report of the time appointments stay pending before being confirmed
+type ConfirmationLatency struct {
+	GeneratedAt    time.Time
+	ConfirmedCount int
+	AvgMinutes     float64
+	MedianMinutes  float64
+	P90Minutes     float64
+	MaxMinutes     float64
+}
+
+// ProfessionalBookedHours holds the booked time of a professional
+type ProfessionalBookedHours struct {
+	ID               uuid.UUID
+	FirstName        string
+	LastName         string
+	AppointmentCount int
+	BookedMinutes    int
+}
+
+// TopProfessionals is the report of the professionals with the most booked time
+type TopProfessionals struct {
+	GeneratedAt   time.Time
+	Professionals []ProfessionalBookedHours
+}
+
+// ClientGrowthMonth holds the clients registered in a month and the running total
+type ClientGrowthMonth struct {
+	Month        time.Time
+	NewClients   int
+	TotalClients int
+}
+
+// ClientGrowth is the report of client registrations per month
+type ClientGrowth struct {
+	GeneratedAt time.Time
+	Months      []ClientGrowthMonth
+}
+
+// CancellationReason holds how often a reason was given, reasons are compared case-insensitively
+type CancellationReason struct {
+	Reason              string // Empty when no reason was given
+	CancellationCount   int
+	ByClientCount       int
+	ByProfessionalCount int
+}
+
+// CancellationReasons is the report of cancellation reasons, most frequent first
+type CancellationReasons struct {
+	GeneratedAt time.Time
+	Reasons     []CancellationReason
+}
+
+// Service defines the business logic operations for admin reports
+type Service interface {
+	GetBookingTotals(ctx context.Context, input BookingTotalsInput) (*BookingTotals, error)
+	GetConfirmationLatency(ctx context.Context, input ReportRange) (*ConfirmationLatency, error)
+	GetTopProfessionals(ctx context.Context, input RankingInput) (*TopProfessionals, error)
+	GetClientGrowth(ctx context.Context, input ReportRange) (*ClientGrowth, error)
+	GetCancellationReasons(ctx context.Context, input RankingInput) (*CancellationReasons, error)
+}
+
+type service struct {
+	repo   ReportsRepository
+	config Config
+	cache  *reportCache
+}
+
+// NewService creates a new reports service
+func NewService(repo ReportsRepository, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+		cache:  newReportCache(config.CacheTTL),
+	}
+}
+
+// GetBookingTotals counts appointments per status and per day, week or month
+func (s *service) GetBookingTotals(ctx context.Context, input BookingTotalsInput) (*BookingTotals, error) {
+	ctx, span := tracing.StartSpan(ctx, "reports.GetBookingTotals")
+	defer span.End()
+
+	if err := validateBookingTotalsInput(input); err != nil {
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := s.rangeBounds(input.ReportRange)
+	key := fmt.Sprintf("bookings:%s:%s:%s", rangeStart, rangeEnd, input.Granularity)
+
+	return cached(s.cache, key, func() (*BookingTotals, error) {
+		rows, err := s.repo.GetBookingTotals(ctx, &db.GetBookingTotalsParams{
+			Granularity: input.Granularity,
+			Timezone:    s.config.AppTimezone.String(),
+			RangeStart:  rangeStart,
+			RangeEnd:    rangeEnd,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report := &BookingTotals{
+			GeneratedAt: time.Now(),
+			Buckets:     make([]BookingBucket, 0, len(rows)),
+		}
+		for _, row := range rows {
+			bucket := BookingBucket{
+				// date_trunc returns local wall clock timestamps, scanned as UTC
+				Start: time.Date(row.Bucket.Year(), row.Bucket.Month(), row.Bucket.Day(), 0, 0, 0, 0, s.config.AppTimezone),
+				BookingCounts: BookingCounts{
+					Total:     int(row.TotalCount),
+					Pending:   int(row.PendingCount),
+					Confirmed: int(row.ConfirmedCount),
+					Cancelled: int(row.CancelledCount),
+					Completed: int(row.CompletedCount),
+				},
+			}
+			report.Buckets = append(report.Buckets, bucket)
+
+			report.Total.Total += bucket.Total
+			report.Total.Pending += bucket.Pending
+			report.Total.Confirmed += bucket.Confirmed
+			report.Total.Cancelled += bucket.Cancelled
+			report.Total.Completed += bucket.Completed
+		}
+
+		return report, nil
+	})
+}
+
+// GetConfirmationLatency measures the time from booking to the first confirmation
+// for appointments booked within the range
+func (s *service) GetConfirmationLatency(ctx context.Context, input ReportRange) (*ConfirmationLatency, error) {
+	ctx, span := tracing.StartSpan(ctx, "reports.GetConfirmationLatency")
+	defer span.End()
+
+	if err := validateRange(input); err != nil {
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := s.rangeBounds(input)
+	key := fmt.Sprintf("confirmation_latency:%s:%s", rangeStart, rangeEnd)
+
+	return cached(s.cache, key, func() (*ConfirmationLatency, error) {
+		row, err := s.repo.GetConfirmationLatency(ctx, &db.GetConfirmationLatencyParams{
+			RangeStart: rangeStart,
+			RangeEnd:   rangeEnd,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &ConfirmationLatency{
+			GeneratedAt:    time.Now(),
+			ConfirmedCount: int(row.ConfirmedCount),
+			AvgMinutes:     row.AvgMinutes,
+			MedianMinutes:  row.MedianMinutes,
+			P90Minutes:     row.P90Minutes,
+			MaxMinutes:     row.MaxMinutes,
+		}, nil
+	})
+}
+
+// GetTopProfessionals ranks professionals by confirmed and completed appointment time
+func (s *service) GetTopProfessionals(ctx context.Context, input RankingInput) (*TopProfessionals, error) {
+	ctx, span := tracing.StartSpan(ctx, "reports.GetTopProfessionals")
+	defer span.End()
+
+	if err := validateRankingInput(input); err != nil {
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := s.rangeBounds(input.ReportRange)
+	key := fmt.Sprintf("top_professionals:%s:%s:%d", rangeStart, rangeEnd, input.Limit)
+
+	return cached(s.cache, key, func() (*TopProfessionals, error) {
+		rows, err := s.repo.GetTopProfessionalsByBookedHours(ctx, &db.GetTopProfessionalsByBookedHoursParams{
+			RangeStart: rangeStart,
+			RangeEnd:   rangeEnd,
+			RowLimit:   int32(input.Limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report := &TopProfessionals{
+			GeneratedAt:   time.Now(),
+			Professionals: make([]ProfessionalBookedHours, 0, len(rows)),
+		}
+		for _, row := range rows {
+			report.Professionals = append(report.Professionals, ProfessionalBookedHours{
+				ID:               row.ID,
+				FirstName:        row.FirstName,
+				LastName:         row.LastName,
+				AppointmentCount: int(row.AppointmentCount),
+				BookedMinutes:    int(row.BookedMinutes),
+			})
+		}
+
+		return report, nil
+	})
+}
+
+// GetClientGrowth counts new clients per month of the range, including months without any
+func (s *service) GetClientGrowth(ctx context.Context, input ReportRange) (*ClientGrowth, error) {
+	ctx, span := tracing.StartSpan(ctx, "reports.GetClientGrowth")
+	defer span.End()
+
+	if err := validateRange(input); err != nil {
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := s.rangeBounds(input)
+	key := fmt.Sprintf("client_growth:%s:%s", rangeStart, rangeEnd)
+
+	return cached(s.cache, key, func() (*ClientGrowth, error) {
+		existingClients, err := s.repo.CountClientsCreatedBefore(ctx, rangeStart)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := s.repo.GetNewClientsByMonth(ctx, &db.GetNewClientsByMonthParams{
+			Timezone:   s.config.AppTimezone.String(),
+			RangeStart: rangeStart,
+			RangeEnd:   rangeEnd,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		newClientsByMonth := make(map[string]int, len(rows))
+		for _, row := range rows {
+			newClientsByMonth[row.Month.Format("2006-01")] = int(row.NewClients)
+		}
+
+		report := &ClientGrowth{
+			GeneratedAt: time.Now(),
+			Months:      []ClientGrowthMonth{},
+		}
+		total := int(existingClients)
+		for month := time.Date(rangeStart.Year(), rangeStart.Month(), 1, 0, 0, 0, 0, s.config.AppTimezone); month.Before(rangeEnd); month = month.AddDate(0, 1, 0) {
+			newClients := newClientsByMonth[month.Format("2006-01")]
+			total += newClients
+			report.Months = append(report.Months, ClientGrowthMonth{
+				Month:        month,
+				NewClients:   newClients,
+				TotalClients: total,
+			})
+		}
+
+		return report, nil
+	})
+}
+
+// GetCancellationReasons groups cancelled appointments by reason, most frequent first
+func (s *service) GetCancellationReasons(ctx context.Context, input RankingInput) (*CancellationReasons, error) {
+	ctx, span := tracing.StartSpan(ctx, "reports.GetCancellationReasons")
+	defer span.End()
+
+	if err := validateRankingInput(input); err != nil {
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := s.rangeBounds(input.ReportRange)
+	key := fmt.Sprintf("cancellation_reasons:%s:%s:%d", rangeStart, rangeEnd, input.Limit)
+
+	return cached(s.cache, key, func() (*CancellationReasons, error) {
+		rows, err := s.repo.GetCancellationReasons(ctx, &db.GetCancellationReasonsParams{
+			RangeStart: rangeStart,
+			RangeEnd:   rangeEnd,
+			RowLimit:   int32(input.Limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report := &CancellationReasons{
+			GeneratedAt: time.Now(),
+			Reasons:     make([]CancellationReason, 0, len(rows)),
+		}
+		for _, row := range rows {
+			report.Reasons = append(report.Reasons, CancellationReason{
+				Reason:              row.Reason,
+				CancellationCount:   int(row.CancellationCount),
+				ByClientCount:       int(row.ByClientCount),
+				ByProfessionalCount: int(row.ByProfessionalCount),
+			})
+		}
+
+		return report, nil
+	})
+}
+
+// rangeBounds converts the inclusive date range to [start, end) in the application timezone
+func (s *service) rangeBounds(r ReportRange) (time.Time, time.Time) {
+	loc := s.config.AppTimezone
+	return time.Date(r.From.Year(), r.From.Month(), r.From.Day(), 0, 0, 0, 0, loc),
+		time.Date(r.To.Year(), r.To.Month(), r.To.Day()+1, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,41 @@
+package reports
+
+import (
+	"strconv"
+
+	"github.com/vention/booking_api/internal/services/common"
+)
+
+// validateRange validates that the range does not end before it starts
+func validateRange(r ReportRange) error {
+	if r.To.Before(r.From) {
+		return common.NewFieldError("to", "gtefield", common.ErrInvalidTimeRange, "field", "from")
+	}
+	return nil
+}
+
+// validateBookingTotalsInput validates the range and bucket granularity
+func validateBookingTotalsInput(input BookingTotalsInput) error {
+	if err := validateRange(input.ReportRange); err != nil {
+		return err
+	}
+
+	switch input.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+		return nil
+	default:
+		return common.NewFieldError("granularity", "oneof", common.ErrInvalidGranularity, "values", GranularityDay+", "+GranularityWeek+", "+GranularityMonth)
+	}
+}
+
+// validateRankingInput validates the range and the number of entries
+func validateRankingInput(input RankingInput) error {
+	if err := validateRange(input.ReportRange); err != nil {
+		return err
+	}
+
+	if input.Limit < 1 || input.Limit > MaxLimit {
+		return common.NewFieldError("limit", "range", common.ErrInvalidLimit, "min", "1", "max", strconv.Itoa(MaxLimit))
+	}
+	return nil
+}
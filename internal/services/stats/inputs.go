@@ -0,0 +1,22 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProfessionalStatsInput represents the input for computing a professional's statistics
+type ProfessionalStatsInput struct {
+	ProfessionalID uuid.UUID
+	From           time.Time // First day of the range
+	To             time.Time // Last day of the range, inclusive
+	Granularity    string    // GranularityDay, GranularityWeek or GranularityMonth
+}
+
+// Config contains the working hours used to compute available time
+type Config struct {
+	WorkingHoursStart int
+	WorkingHoursEnd   int
+	AppTimezone       *time.Location
+}
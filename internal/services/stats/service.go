@@ -0,0 +1,306 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	db "github.com/vention/booking_api/internal/repository"
+	"github.com/vention/booking_api/internal/tracing"
+)
+
+// Bucket granularities, passed to date_trunc as is
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// Totals holds the appointment aggregates of a period
+type Totals struct {
+	TotalAppointments     int
+	PendingAppointments   int
+	ConfirmedAppointments int
+	CancelledAppointments int
+	CompletedAppointments int
+
+	CancelledByClient       int
+	CancelledByProfessional int
+
+	BookedMinutes      int // Confirmed and completed appointments
+	UnavailableMinutes int // Blocks marked unavailable by the professional
+	AvailableMinutes   int // Working hours minus unavailable blocks
+	AvgLeadTimeHours   float64
+}
+
+// Utilization returns the share of available working time that is booked
+func (t Totals) Utilization() float64 {
+	return ratio(t.BookedMinutes, t.AvailableMinutes)
+}
+
+// CancellationRate returns the share of appointments that were cancelled
+func (t Totals) CancellationRate() float64 {
+	return ratio(t.CancelledAppointments, t.TotalAppointments)
+}
+
+// ClientCancellationRate returns the share of appointments cancelled by the client
+func (t Totals) ClientCancellationRate() float64 {
+	return ratio(t.CancelledByClient, t.TotalAppointments)
+}
+
+// ProfessionalCancellationRate returns the share of appointments cancelled by the professional
+func (t Totals) ProfessionalCancellationRate() float64 {
+	return ratio(t.CancelledByProfessional, t.TotalAppointments)
+}
+
+// Bucket holds the aggregates of one day, week or month
+type Bucket struct {
+	Start time.Time
+	Totals
+}
+
+// WeekdayStat holds the booked appointments of an ISO weekday (1 = Monday, 7 = Sunday)
+type WeekdayStat struct {
+	Weekday          int
+	AppointmentCount int
+	BookedMinutes    int
+}
+
+// HourStat holds the booked appointments starting in an hour of the day
+type HourStat struct {
+	Hour             int
+	AppointmentCount int
+	BookedMinutes    int
+}
+
+// ProfessionalStats is the result of a professional statistics query
+type ProfessionalStats struct {
+	From             time.Time
+	To               time.Time
+	Granularity      string
+	Summary          Totals
+	Buckets          []Bucket
+	BusiestWeekdays  []WeekdayStat // Sorted by appointment count, busiest first
+	BusiestHours     []HourStat    // Sorted by appointment count, busiest first
+	NewClients       int           // Clients whose first visit falls in the range
+	ReturningClients int           // Clients who visited before the range
+}
+
+// Service defines the business logic operations for statistics
+type Service interface {
+	GetProfessionalStats(ctx context.Context, input ProfessionalStatsInput) (*ProfessionalStats, error)
+}
+
+type service struct {
+	repo   StatsRepository
+	config Config
+}
+
+// NewService creates a new stats service
+func NewService(repo StatsRepository, config Config) Service {
+	return &service{
+		repo:   repo,
+		config: config,
+	}
+}
+
+// GetProfessionalStats computes utilization, cancellation and client statistics for a date range
+func (s *service) GetProfessionalStats(ctx context.Context, input ProfessionalStatsInput) (*ProfessionalStats, error) {
+	ctx, span := tracing.StartSpan(ctx, "stats.GetProfessionalStats")
+	defer span.End()
+
+	if err := validateProfessionalStatsInput(input); err != nil {
+		return nil, err
+	}
+
+	loc := s.config.AppTimezone
+	rangeStart := time.Date(input.From.Year(), input.From.Month(), input.From.Day(), 0, 0, 0, 0, loc)
+	rangeEnd := time.Date(input.To.Year(), input.To.Month(), input.To.Day()+1, 0, 0, 0, 0, loc)
+
+	summary, err := s.repo.GetProfessionalStatsSummary(ctx, &db.GetProfessionalStatsSummaryParams{
+		ProfessionalID: input.ProfessionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bucketRows, err := s.repo.GetProfessionalStatsBuckets(ctx, &db.GetProfessionalStatsBucketsParams{
+		Granularity:    input.Granularity,
+		Timezone:       loc.String(),
+		ProfessionalID: input.ProfessionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	weekdayRows, err := s.repo.GetProfessionalBusiestWeekdays(ctx, &db.GetProfessionalBusiestWeekdaysParams{
+		Timezone:       loc.String(),
+		ProfessionalID: input.ProfessionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hourRows, err := s.repo.GetProfessionalBusiestHours(ctx, &db.GetProfessionalBusiestHoursParams{
+		Timezone:       loc.String(),
+		ProfessionalID: input.ProfessionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	retention, err := s.repo.GetProfessionalClientRetention(ctx, &db.GetProfessionalClientRetentionParams{
+		ProfessionalID: input.ProfessionalID,
+		RangeStart:     rangeStart,
+		RangeEnd:       rangeEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ProfessionalStats{
+		From:             rangeStart,
+		To:               input.To,
+		Granularity:      input.Granularity,
+		Summary:          summaryTotals(summary),
+		Buckets:          s.buildBuckets(rangeStart, rangeEnd, input.Granularity, bucketRows),
+		BusiestWeekdays:  make([]WeekdayStat, 0, len(weekdayRows)),
+		BusiestHours:     make([]HourStat, 0, len(hourRows)),
+		NewClients:       int(retention.NewClients),
+		ReturningClients: int(retention.ReturningClients),
+	}
+	stats.Summary.AvailableMinutes = s.availableMinutes(rangeStart, rangeEnd, stats.Summary.UnavailableMinutes)
+
+	for _, row := range weekdayRows {
+		stats.BusiestWeekdays = append(stats.BusiestWeekdays, WeekdayStat{
+			Weekday:          int(row.Weekday),
+			AppointmentCount: int(row.AppointmentCount),
+			BookedMinutes:    int(row.BookedMinutes),
+		})
+	}
+
+	for _, row := range hourRows {
+		stats.BusiestHours = append(stats.BusiestHours, HourStat{
+			Hour:             int(row.Hour),
+			AppointmentCount: int(row.AppointmentCount),
+			BookedMinutes:    int(row.BookedMinutes),
+		})
+	}
+
+	return stats, nil
+}
+
+// buildBuckets returns one bucket per day, week or month of the range, including empty ones.
+// Buckets at the edges are clipped to the range when computing available time.
+func (s *service) buildBuckets(rangeStart, rangeEnd time.Time, granularity string, rows []*db.GetProfessionalStatsBucketsRow) []Bucket {
+	// date_trunc returns local wall clock timestamps, scanned as UTC
+	rowsByDate := make(map[string]*db.GetProfessionalStatsBucketsRow, len(rows))
+	for _, row := range rows {
+		rowsByDate[row.Bucket.Format(time.DateOnly)] = row
+	}
+
+	buckets := []Bucket{}
+	for start := truncateToBucket(rangeStart, granularity); start.Before(rangeEnd); start = nextBucket(start, granularity) {
+		bucket := Bucket{Start: start}
+		if row, ok := rowsByDate[start.Format(time.DateOnly)]; ok {
+			bucket.Totals = bucketTotals(row)
+		}
+
+		from, to := start, nextBucket(start, granularity)
+		if from.Before(rangeStart) {
+			from = rangeStart
+		}
+		if to.After(rangeEnd) {
+			to = rangeEnd
+		}
+		bucket.AvailableMinutes = s.availableMinutes(from, to, bucket.UnavailableMinutes)
+
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets
+}
+
+// availableMinutes returns the working minutes of the days in [from, to) minus unavailable minutes
+func (s *service) availableMinutes(from, to time.Time, unavailableMinutes int) int {
+	workingMinutesPerDay := (s.config.WorkingHoursEnd - s.config.WorkingHoursStart) * 60
+
+	days := 0
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+
+	return max(days*workingMinutesPerDay-unavailableMinutes, 0)
+}
+
+// truncateToBucket returns the start of the bucket containing t, weeks start on Monday like date_trunc
+func truncateToBucket(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// nextBucket returns the start of the bucket following the one starting at t
+func nextBucket(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// summaryTotals converts the summary row, AvailableMinutes is filled in by the caller
+func summaryTotals(row *db.GetProfessionalStatsSummaryRow) Totals {
+	return Totals{
+		TotalAppointments:       int(row.TotalCount),
+		PendingAppointments:     int(row.PendingCount),
+		ConfirmedAppointments:   int(row.ConfirmedCount),
+		CancelledAppointments:   int(row.CancelledCount),
+		CompletedAppointments:   int(row.CompletedCount),
+		CancelledByClient:       int(row.CancelledByClientCount),
+		CancelledByProfessional: int(row.CancelledByProfessionalCount),
+		BookedMinutes:           int(row.BookedMinutes),
+		UnavailableMinutes:      int(row.UnavailableMinutes),
+		AvgLeadTimeHours:        row.AvgLeadTimeHours,
+	}
+}
+
+// bucketTotals converts a bucket row, AvailableMinutes is filled in by the caller
+func bucketTotals(row *db.GetProfessionalStatsBucketsRow) Totals {
+	return Totals{
+		TotalAppointments:       int(row.TotalCount),
+		PendingAppointments:     int(row.PendingCount),
+		ConfirmedAppointments:   int(row.ConfirmedCount),
+		CancelledAppointments:   int(row.CancelledCount),
+		CompletedAppointments:   int(row.CompletedCount),
+		CancelledByClient:       int(row.CancelledByClientCount),
+		CancelledByProfessional: int(row.CancelledByProfessionalCount),
+		BookedMinutes:           int(row.BookedMinutes),
+		UnavailableMinutes:      int(row.UnavailableMinutes),
+		AvgLeadTimeHours:        row.AvgLeadTimeHours,
+	}
+}
+
+// ratio returns part/whole, or 0 when whole is 0
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
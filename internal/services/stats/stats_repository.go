@@ -0,0 +1,16 @@
+package stats
+
+import (
+	"context"
+
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// StatsRepository defines the database aggregates needed by the stats service
+type StatsRepository interface {
+	GetProfessionalStatsSummary(ctx context.Context, arg *db.GetProfessionalStatsSummaryParams) (*db.GetProfessionalStatsSummaryRow, error)
+	GetProfessionalStatsBuckets(ctx context.Context, arg *db.GetProfessionalStatsBucketsParams) ([]*db.GetProfessionalStatsBucketsRow, error)
+	GetProfessionalBusiestWeekdays(ctx context.Context, arg *db.GetProfessionalBusiestWeekdaysParams) ([]*db.GetProfessionalBusiestWeekdaysRow, error)
+	GetProfessionalBusiestHours(ctx context.Context, arg *db.GetProfessionalBusiestHoursParams) ([]*db.GetProfessionalBusiestHoursRow, error)
+	GetProfessionalClientRetention(ctx context.Context, arg *db.GetProfessionalClientRetentionParams) (*db.GetProfessionalClientRetentionRow, error)
+}
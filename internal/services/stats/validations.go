@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/vention/booking_api/internal/services/common"
+)
+
+// maxStatsRangeYears bounds the date range of a statistics request, keeping the aggregates cheap
+const maxStatsRangeYears = 1
+
+// validateProfessionalStatsInput validates the date range and bucket granularity
+func validateProfessionalStatsInput(input ProfessionalStatsInput) error {
+	if input.To.Before(input.From) {
+		return common.NewFieldError("to", "gtefield", common.ErrInvalidTimeRange, "field", "from")
+	}
+	if !input.To.Before(input.From.AddDate(maxStatsRangeYears, 0, 0)) {
+		return common.NewFieldError("to", "max", common.ErrInvalidTimeRange,
+			"param", input.From.AddDate(maxStatsRangeYears, 0, -1).Format(time.DateOnly))
+	}
+
+	switch input.Granularity {
+	case GranularityDay, GranularityWeek, GranularityMonth:
+		return nil
+	default:
+		return common.NewFieldError("granularity", "oneof", common.ErrInvalidGranularity, "values", GranularityDay+", "+GranularityWeek+", "+GranularityMonth)
+	}
+}
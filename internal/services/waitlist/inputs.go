@@ -0,0 +1,28 @@
+package waitlist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JoinWaitlistInput represents the input for registering interest in slots of a professional
+type JoinWaitlistInput struct {
+	ClientID       uuid.UUID
+	ProfessionalID uuid.UUID
+	WindowStart    time.Time // Earliest start of an acceptable slot
+	WindowEnd      time.Time // Latest end of an acceptable slot
+}
+
+// LeaveWaitlistInput represents the input for cancelling a waitlist entry
+type LeaveWaitlistInput struct {
+	ClientID uuid.UUID
+	EntryID  uuid.UUID
+}
+
+// AcceptOfferInput represents the input for booking the slot offered to a waitlist entry
+type AcceptOfferInput struct {
+	ClientID    uuid.UUID
+	EntryID     uuid.UUID
+	Description string
+}
@@ -0,0 +1,51 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/vention/booking_api/internal/events"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// RunOffers offers the slots of cancelled appointments to waitlisted clients as the cancellations
+// are published, and every interval passes expired offers on to the next client and offers the free
+// slots of cancelled appointments that are still waited for, until ctx is cancelled.
+// Every replica runs it, concurrent offers of the same slot are resolved by the database.
+func RunOffers(ctx context.Context, service Service, broker *events.Broker, interval time.Duration, logger zerolog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	sub := broker.Subscribe(isCancellation)
+	defer func() { sub.Close() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-sub.C:
+			// Dropped for falling behind, the sweep offers the slots of the missed cancellations
+			if !ok {
+				sub = broker.Subscribe(isCancellation)
+				continue
+			}
+			if _, err := service.OfferSlot(ctx, event.AppointmentID); err != nil && ctx.Err() == nil {
+				logger.Error().Err(err).Str("appointment_id", event.AppointmentID.String()).Msg("Failed to offer slot to waitlist")
+			}
+
+		case <-ticker.C:
+			if expired, err := service.ExpireOffers(ctx); err != nil && ctx.Err() == nil {
+				logger.Error().Err(err).Msg("Failed to expire waitlist offers")
+			} else if expired > 0 {
+				logger.Debug().Int("expired", expired).Msg("Expired waitlist offers")
+			}
+		}
+	}
+}
+
+// isCancellation matches the events of cancelled appointments, whose slots are freed
+func isCancellation(event *db.AppointmentEvent) bool {
+	return event.EventType == events.EventAppointmentCancelled
+}
@@ -0,0 +1,394 @@
+package waitlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/metrics"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+	"github.com/vention/booking_api/internal/tracing"
+	"github.com/vention/booking_api/internal/util"
+)
+
+// Service defines the business logic operations for waitlists
+type Service interface {
+	JoinWaitlist(ctx context.Context, input JoinWaitlistInput) (*db.WaitlistEntry, error)
+	GetWaitlist(ctx context.Context, clientID uuid.UUID) ([]*db.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, input LeaveWaitlistInput) (*db.WaitlistEntry, error)
+	AcceptOffer(ctx context.Context, input AcceptOfferInput) (*db.CreateAppointmentWithDetailsRow, error)
+	OfferSlot(ctx context.Context, appointmentID uuid.UUID) (*db.WaitlistEntry, error)
+	ExpireOffers(ctx context.Context) (int, error)
+}
+
+// Config contains the settings of waitlist offers
+type Config struct {
+	OfferTTL time.Duration // How long an offered slot is held for the client
+}
+
+type service struct {
+	store    WaitlistStore
+	recorder events.Recorder
+	config   Config
+}
+
+// NewService creates a new waitlist service
+func NewService(store WaitlistStore, recorder events.Recorder, config Config) Service {
+	return &service{
+		store:    store,
+		recorder: recorder,
+		config:   config,
+	}
+}
+
+// offer is a slot offered to a waitlisted client, recorded once the transaction commits
+type offer struct {
+	entry       *db.WaitlistEntry
+	appointment *db.Appointment // Cancelled appointment that freed the slot
+}
+
+// JoinWaitlist registers the interest of a client in any slot of the professional within the window
+func (s *service) JoinWaitlist(ctx context.Context, input JoinWaitlistInput) (*db.WaitlistEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.JoinWaitlist")
+	defer span.End()
+
+	windowStart := util.ConvertToAppTimezone(input.WindowStart)
+	windowEnd := util.ConvertToAppTimezone(input.WindowEnd)
+
+	if err := s.validateWindow(windowStart, windowEnd, time.Now()); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.store.CreateWaitlistEntry(ctx, &db.CreateWaitlistEntryParams{
+		ClientID:       input.ClientID,
+		ProfessionalID: input.ProfessionalID,
+		WindowStart:    windowStart,
+		WindowEnd:      windowEnd,
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return entry, nil
+}
+
+// GetWaitlist retrieves the entries of a client that are still waiting or have an offer
+func (s *service) GetWaitlist(ctx context.Context, clientID uuid.UUID) ([]*db.WaitlistEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.GetWaitlist")
+	defer span.End()
+
+	return s.store.GetActiveWaitlistEntriesByClient(ctx, clientID)
+}
+
+// LeaveWaitlist cancels a waitlist entry of the client. A slot offered to the entry is
+// offered to the next waitlisted client right away.
+func (s *service) LeaveWaitlist(ctx context.Context, input LeaveWaitlistInput) (*db.WaitlistEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.LeaveWaitlist")
+	defer span.End()
+
+	var (
+		entry *db.WaitlistEntry
+		next  *offer
+	)
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		entry, next, err = s.leaveWaitlist(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return s.recordOffer(ctx, q, next)
+	}); err != nil {
+		return nil, err
+	}
+
+	if next != nil {
+		metrics.WaitlistSlotOffered()
+	}
+
+	return entry, nil
+}
+
+// leaveWaitlist cancels the entry, locking it until the transaction ends, and passes its offer on
+func (s *service) leaveWaitlist(ctx context.Context, repo WaitlistRepository, input LeaveWaitlistInput) (*db.WaitlistEntry, *offer, error) {
+	entry, err := repo.GetWaitlistEntryByIDForUpdate(ctx, input.EntryID)
+	if err != nil {
+		return nil, nil, db.TranslateError(err, svcCommon.ErrWaitlistEntryNotFound)
+	}
+
+	if err := s.validateEntryOwnership(entry, input.ClientID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.validateEntryActive(entry); err != nil {
+		return nil, nil, err
+	}
+
+	cancelled, err := repo.CancelWaitlistEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if entry.Status != db.WaitlistStatusOffered {
+		return cancelled, nil, nil
+	}
+
+	next, err := s.offerSlot(ctx, repo, entry.OfferedAppointmentID.UUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cancelled, next, nil
+}
+
+// AcceptOffer books the slot offered to a waitlist entry of the client. The appointment is
+// pending unless the professional auto-confirms bookings of the client.
+func (s *service) AcceptOffer(ctx context.Context, input AcceptOfferInput) (*db.CreateAppointmentWithDetailsRow, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.AcceptOffer")
+	defer span.End()
+
+	var result *db.CreateAppointmentWithDetailsRow
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		result, err = s.acceptOffer(ctx, q, input)
+		if err != nil {
+			return err
+		}
+		return s.recorder.Record(ctx, q, events.AppointmentChange{
+			Type:           events.EventAppointmentCreated,
+			AppointmentID:  result.ID,
+			ProfessionalID: result.ProfessionalID,
+			ClientID:       result.ClientID,
+			Payload: events.AppointmentPayload{
+				Type:        string(result.Type),
+				Status:      string(result.Status.AppointmentStatus),
+				StartTime:   result.StartTime,
+				EndTime:     result.EndTime,
+				Description: result.Description.String,
+			},
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	metrics.AppointmentCreated(string(result.Type))
+	if result.Status.AppointmentStatus == db.AppointmentStatusConfirmed {
+		metrics.AppointmentConfirmed()
+	}
+
+	return result, nil
+}
+
+// acceptOffer books the offered slot, locking the entry and the professional until the transaction
+// ends like other bookings of the professional do
+func (s *service) acceptOffer(ctx context.Context, repo WaitlistRepository, input AcceptOfferInput) (*db.CreateAppointmentWithDetailsRow, error) {
+	entry, err := repo.GetWaitlistEntryByIDForUpdate(ctx, input.EntryID)
+	if err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrWaitlistEntryNotFound)
+	}
+
+	if _, err := repo.LockProfessional(ctx, entry.ProfessionalID); err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrProfessionalNotFound)
+	}
+
+	if err := s.validateEntryOwnership(entry, input.ClientID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateOfferOpen(entry, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The offer holds the slot against other bookings, but the professional may have blocked it since
+	taken, err := repo.HasOverlappingAppointment(ctx, &db.HasOverlappingAppointmentParams{
+		ProfessionalID: entry.ProfessionalID,
+		EndTime:        entry.OfferedEndTime.Time,
+		StartTime:      entry.OfferedStartTime.Time,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, svcCommon.ErrNoWaitlistOffer
+	}
+
+	rule, err := svcCommon.GetBookingRule(ctx, repo, entry.ProfessionalID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := svcCommon.InitialStatus(ctx, repo, rule, entry.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := repo.CreateAppointmentWithDetails(ctx, &db.CreateAppointmentWithDetailsParams{
+		ClientID:       uuid.NullUUID{UUID: entry.ClientID, Valid: true},
+		ProfessionalID: entry.ProfessionalID,
+		StartTime:      util.ConvertToAppTimezone(entry.OfferedStartTime.Time),
+		EndTime:        util.ConvertToAppTimezone(entry.OfferedEndTime.Time),
+		Status:         db.NullAppointmentStatus{AppointmentStatus: status, Valid: true},
+		Description:    sql.NullString{String: input.Description, Valid: input.Description != ""},
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	if _, err := repo.BookWaitlistEntry(ctx, &db.BookWaitlistEntryParams{
+		ID:            entry.ID,
+		AppointmentID: uuid.NullUUID{UUID: result.ID, Valid: true},
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// OfferSlot offers the slot of a cancelled appointment to the first waitlisted client whose
+// window contains it. It returns nil when the slot is no longer free or nobody is waiting for it.
+func (s *service) OfferSlot(ctx context.Context, appointmentID uuid.UUID) (*db.WaitlistEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.OfferSlot")
+	defer span.End()
+
+	var next *offer
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		next, err = s.offerSlot(ctx, q, appointmentID)
+		if err != nil {
+			return err
+		}
+		return s.recordOffer(ctx, q, next)
+	})
+	// Another replica offered the slot first
+	if errors.Is(err, svcCommon.ErrAlreadyExists) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		return nil, nil
+	}
+	metrics.WaitlistSlotOffered()
+	return next.entry, nil
+}
+
+// offerSlot offers the slot of the appointment to the next waitlisted client, locking their entry
+// until the transaction ends. Slots that already started, are taken again or start sooner than
+// the booking rule of the professional allows are not offered.
+func (s *service) offerSlot(ctx context.Context, repo WaitlistRepository, appointmentID uuid.UUID) (*offer, error) {
+	appointment, err := repo.GetAppointmentByID(ctx, appointmentID)
+	if err != nil {
+		return nil, db.TranslateError(err, svcCommon.ErrAppointmentNotFound)
+	}
+
+	if appointment.Status.AppointmentStatus != db.AppointmentStatusCancelled {
+		return nil, nil
+	}
+
+	rule, err := svcCommon.GetBookingRule(ctx, repo, appointment.ProfessionalID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if earliest, _ := svcCommon.BookingWindow(rule, now); appointment.StartTime.Before(earliest) || !appointment.StartTime.After(now) {
+		return nil, nil
+	}
+
+	taken, err := repo.HasOverlappingAppointment(ctx, &db.HasOverlappingAppointmentParams{
+		ProfessionalID: appointment.ProfessionalID,
+		EndTime:        appointment.EndTime,
+		StartTime:      appointment.StartTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, nil
+	}
+
+	entry, err := repo.GetNextWaitlistEntryForSlot(ctx, &db.GetNextWaitlistEntryForSlotParams{
+		ProfessionalID:       appointment.ProfessionalID,
+		SlotStart:            appointment.StartTime,
+		SlotEnd:              appointment.EndTime,
+		OfferedAppointmentID: appointment.ID,
+	})
+	err = db.TranslateError(err, svcCommon.ErrWaitlistEntryNotFound)
+	if errors.Is(err, svcCommon.ErrWaitlistEntryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := repo.OfferWaitlistEntry(ctx, &db.OfferWaitlistEntryParams{
+		ID:                   entry.ID,
+		OfferedAppointmentID: appointment.ID,
+		OfferedStartTime:     appointment.StartTime,
+		OfferedEndTime:       appointment.EndTime,
+		OfferExpiresAt:       now.Add(s.config.OfferTTL),
+	})
+	if err != nil {
+		return nil, db.TranslateError(err, nil)
+	}
+
+	return &offer{entry: offered, appointment: appointment}, nil
+}
+
+// ExpireOffers expires the offers that were not accepted in time and offers the free slots of
+// cancelled appointments to the next waitlisted clients: the slots of the expired offers, and those
+// whose cancellation was missed or that a client joining the waitlist later is waiting for.
+// Entries whose window has passed expire as well.
+func (s *service) ExpireOffers(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "waitlist.ExpireOffers")
+	defer span.End()
+
+	if _, err := s.store.ExpireWaitlistEntries(ctx); err != nil {
+		return 0, err
+	}
+
+	expired, err := s.store.ExpireWaitlistOffers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Includes the appointments of the expired offers when somebody else is waiting for them
+	appointmentIDs, err := s.store.GetCancelledAppointmentsToOffer(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, appointmentID := range appointmentIDs {
+		if _, err := s.OfferSlot(ctx, appointmentID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(expired), errors.Join(errs...)
+}
+
+// recordOffer notifies the client of an offered slot through the events of the cancelled appointment
+func (s *service) recordOffer(ctx context.Context, repo events.EventsRepository, next *offer) error {
+	if next == nil {
+		return nil
+	}
+
+	return s.recorder.Record(ctx, repo, events.AppointmentChange{
+		Type:           events.EventAppointmentSlotOffered,
+		AppointmentID:  next.appointment.ID,
+		ProfessionalID: next.appointment.ProfessionalID,
+		ClientID:       uuid.NullUUID{UUID: next.entry.ClientID, Valid: true},
+		Payload: events.AppointmentPayload{
+			Type:            string(next.appointment.Type),
+			Status:          string(next.appointment.Status.AppointmentStatus),
+			StartTime:       next.appointment.StartTime,
+			EndTime:         next.appointment.EndTime,
+			WaitlistEntryID: &next.entry.ID,
+			OfferExpiresAt:  &next.entry.OfferExpiresAt.Time,
+		},
+	})
+}
@@ -0,0 +1,46 @@
+package waitlist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+	svcCommon "github.com/vention/booking_api/internal/services/common"
+)
+
+// validateWindow validates the time window of a waitlist entry
+func (s *service) validateWindow(windowStart, windowEnd time.Time, now time.Time) error {
+	if !windowEnd.After(windowStart) {
+		return svcCommon.NewFieldError("window_end", "gtfield", svcCommon.ErrInvalidTimeRange, "field", "window_start")
+	}
+
+	if !windowEnd.After(now) {
+		return svcCommon.NewFieldError("window_end", "future", svcCommon.ErrPastTime)
+	}
+
+	return nil
+}
+
+// validateEntryOwnership validates that the waitlist entry belongs to the client
+func (s *service) validateEntryOwnership(entry *db.WaitlistEntry, clientID uuid.UUID) error {
+	if entry.ClientID != clientID {
+		return svcCommon.ErrForbidden
+	}
+	return nil
+}
+
+// validateEntryActive validates that the client is still waiting or has an offer
+func (s *service) validateEntryActive(entry *db.WaitlistEntry) error {
+	if entry.Status != db.WaitlistStatusWaiting && entry.Status != db.WaitlistStatusOffered {
+		return svcCommon.ErrWaitlistEntryInactive
+	}
+	return nil
+}
+
+// validateOfferOpen validates that the entry has an offer that has not expired
+func (s *service) validateOfferOpen(entry *db.WaitlistEntry, now time.Time) error {
+	if entry.Status != db.WaitlistStatusOffered || !entry.OfferExpiresAt.Time.After(now) {
+		return svcCommon.ErrNoWaitlistOffer
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package waitlist
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// WaitlistRepository defines the database operations needed by the waitlist service
+type WaitlistRepository interface {
+	CreateWaitlistEntry(ctx context.Context, arg *db.CreateWaitlistEntryParams) (*db.WaitlistEntry, error)
+	GetWaitlistEntryByIDForUpdate(ctx context.Context, id uuid.UUID) (*db.WaitlistEntry, error)
+	LockProfessional(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	GetActiveWaitlistEntriesByClient(ctx context.Context, clientID uuid.UUID) ([]*db.WaitlistEntry, error)
+	CancelWaitlistEntry(ctx context.Context, id uuid.UUID) (*db.WaitlistEntry, error)
+	GetNextWaitlistEntryForSlot(ctx context.Context, arg *db.GetNextWaitlistEntryForSlotParams) (*db.WaitlistEntry, error)
+	OfferWaitlistEntry(ctx context.Context, arg *db.OfferWaitlistEntryParams) (*db.WaitlistEntry, error)
+	BookWaitlistEntry(ctx context.Context, arg *db.BookWaitlistEntryParams) (*db.WaitlistEntry, error)
+	ExpireWaitlistOffers(ctx context.Context) ([]*db.WaitlistEntry, error)
+	ExpireWaitlistEntries(ctx context.Context) (int64, error)
+	GetCancelledAppointmentsToOffer(ctx context.Context) ([]uuid.UUID, error)
+	GetAppointmentByID(ctx context.Context, id uuid.UUID) (*db.Appointment, error)
+	HasOverlappingAppointment(ctx context.Context, arg *db.HasOverlappingAppointmentParams) (bool, error)
+	CreateAppointmentWithDetails(ctx context.Context, arg *db.CreateAppointmentWithDetailsParams) (*db.CreateAppointmentWithDetailsRow, error)
+	GetBookingRule(ctx context.Context, professionalID uuid.UUID) (*db.BookingRule, error)
+	HasClientVisitedProfessional(ctx context.Context, arg *db.HasClientVisitedProfessionalParams) (bool, error)
+}
+
+// WaitlistStore adds transaction support so that offers are made and accepted atomically
+type WaitlistStore interface {
+	WaitlistRepository
+	ExecTx(ctx context.Context, fn func(*db.Queries) error) error
+}
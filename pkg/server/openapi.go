@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/vention/booking_api/internal/api"
+	common "github.com/vention/booking_api/internal/api/common"
+	"github.com/vention/booking_api/internal/openapi"
+)
+
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+	apiTitle    = "Booking API"
+	apiVersion  = "1.0.0"
+)
+
+// operations describes all routes of the server for the OpenAPI document
+func operations(apiGroup, publicRouter *gin.RouterGroup) []openapi.Operation {
+	tags := []string{"system"}
+
+	return slices.Concat(api.Operations(apiGroup, publicRouter), []openapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/health",
+			Summary: "Check the database connection",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: HealthResponse{}},
+				{Status: http.StatusServiceUnavailable, Body: HealthResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/livez",
+			Summary: "Liveness probe",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: StatusResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/readyz",
+			Summary:     "Readiness probe",
+			Description: "Checks the database, the schema version and the background workers, and fails while shutting down.",
+			Tags:        tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Body: ReadinessResponse{}},
+				{Status: http.StatusServiceUnavailable, Body: ReadinessResponse{}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/metrics",
+			Summary: "Prometheus metrics",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, ContentTypes: []string{"text/plain"}},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    openAPIPath,
+			Summary: "This OpenAPI document",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, Description: "OpenAPI 3.1 document"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    docsPath,
+			Summary: "Swagger UI for this OpenAPI document",
+			Tags:    tags,
+			Responses: []openapi.Response{
+				{Status: http.StatusOK, ContentTypes: []string{"text/html"}},
+			},
+		},
+	})
+}
+
+// buildOpenAPI builds the OpenAPI document of the routes registered on the router.
+// Routes missing from the operations are logged rather than failing startup; the
+// route coverage test keeps the document complete.
+func buildOpenAPI(r *gin.Engine, operations []openapi.Operation, logger zerolog.Logger) ([]byte, error) {
+	if err := openapi.Check(r.Routes(), operations); err != nil {
+		logger.Warn().Err(err).Msg("OpenAPI document does not match the registered routes")
+	}
+
+	doc := openapi.Build(openapi.Config{
+		Title:         apiTitle,
+		Version:       apiVersion,
+		Description:   "Appointment booking for professionals and their clients. Routes under /api require a JWT bearer token. Messages are localized with Accept-Language (en, ru, uk, de).",
+		ErrorResponse: common.ErrorResponse{},
+	}, operations)
+	return json.Marshal(doc)
+}
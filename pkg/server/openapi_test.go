@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/vention/booking_api/internal/config"
+	"github.com/vention/booking_api/internal/database"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/health"
+	"github.com/vention/booking_api/internal/openapi"
+	db "github.com/vention/booking_api/internal/repository"
+)
+
+// newTestRouter builds the full router without connecting to a database
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	t.Setenv("DB_PASSWORD", "test")
+	t.Setenv("JWT_SECRET", "test-secret-with-at-least-32-characters")
+	t.Setenv("RATE_LIMIT_ENABLED", "true")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	// sql.Open does not connect, queries are never run with the cancelled context
+	sqlDB, err := sql.Open("postgres", cfg.GetDSN())
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Background workers exit right away
+
+	store := db.NewStore(sqlDB)
+	r, err := newRouter(ctx, routerParams{
+		Config:         cfg,
+		Database:       &database.DB{DB: sqlDB},
+		Store:          store,
+		Probe:          health.NewProbe(nil, 0, zerolog.Nop()),
+		EventsBroker:   events.NewBroker(),
+		EventsRecorder: events.NewRecorder(),
+		Logger:         zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("newRouter: %v", err)
+	}
+	return r
+}
+
+func TestOpenAPIDocumentsAllRoutes(t *testing.T) {
+	r := newTestRouter(t)
+
+	// The groups only provide the base paths of the operations
+	if err := openapi.Check(r.Routes(), operations(r.Group("/api"), &r.RouterGroup)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenAPIDocumentIsServed(t *testing.T) {
+	r := newTestRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s returned %d", openAPIPath, w.Code)
+	}
+
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode document: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("openapi version %q, want %q", doc.OpenAPI, openapi.Version)
+	}
+
+	for _, route := range r.Routes() {
+		path := route.Path
+		for _, segment := range strings.Split(path, "/") {
+			if strings.HasPrefix(segment, ":") {
+				path = strings.Replace(path, segment, "{"+segment[1:]+"}", 1)
+			}
+		}
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("route %s %s is missing from the document", route.Method, path)
+		}
+	}
+}
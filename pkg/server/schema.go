@@ -0,0 +1,25 @@
+package server
+
+// HealthResponse represents the response of the health check endpoint
+type HealthResponse struct {
+	Status    string `json:"status"` // "healthy" or "unhealthy"
+	Timestamp string `json:"timestamp,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// StatusResponse represents the response of the liveness probe
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+// ReadinessResponse represents the response of the readiness probe
+type ReadinessResponse struct {
+	Status string                   `json:"status"` // "ready" or "not_ready"
+	Checks map[string]CheckResponse `json:"checks"`
+}
+
+// CheckResponse represents the result of one readiness check
+type CheckResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
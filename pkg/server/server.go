@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -13,11 +14,34 @@ import (
 	"github.com/vention/booking_api/internal/api/middleware"
 	"github.com/vention/booking_api/internal/config"
 	"github.com/vention/booking_api/internal/database"
+	"github.com/vention/booking_api/internal/events"
+	"github.com/vention/booking_api/internal/health"
+	"github.com/vention/booking_api/internal/metrics"
+	"github.com/vention/booking_api/internal/migrations"
+	"github.com/vention/booking_api/internal/openapi"
+	"github.com/vention/booking_api/internal/ratelimit"
 	db "github.com/vention/booking_api/internal/repository"
 	"github.com/vention/booking_api/internal/token"
+	"github.com/vention/booking_api/internal/tracing"
 )
 
 func Start(ctx context.Context, cfg *config.Config, logger zerolog.Logger) error {
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+		ServiceName: cfg.TracingServiceName,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error().Err(err).Msg("Failed to flush traces")
+		}
+	}()
+
 	// Initialize database
 	database, err := database.NewPostgreSQL(cfg, logger)
 	if err != nil {
@@ -25,9 +49,120 @@ func Start(ctx context.Context, cfg *config.Config, logger zerolog.Logger) error
 	}
 	defer database.Close()
 
+	// Expose connection pool statistics
+	if err := metrics.RegisterDBStats(database.DB, cfg.DBName); err != nil {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	// Initialize repository
+	store := db.NewStore(database.DB)
+	queries := store.Queries
+
+	// Initialize readiness probe, expecting the schema of the embedded migrations
+	expectedMigration, err := migrations.LatestVersion(migrations.FS)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	probe := health.NewProbe(database, expectedMigration, logger)
+
+	// Initialize appointment events pub/sub, fed by PostgreSQL LISTEN/NOTIFY
+	eventsBroker := events.NewBroker()
+	eventsRecorder := events.NewRecorder()
+	probe.Go(ctx, "events_listener", func() {
+		if err := events.Listen(ctx, cfg.GetDSN(), queries, eventsBroker, logger); err != nil {
+			logger.Error().Err(err).Msg("Appointment events listener stopped")
+		}
+	})
+
+	// Create the router with all routes
+	r, err := newRouter(ctx, routerParams{
+		Config:         cfg,
+		Database:       database,
+		Store:          store,
+		Probe:          probe,
+		EventsBroker:   eventsBroker,
+		EventsRecorder: eventsRecorder,
+		Logger:         logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Start server
+	srv := &http.Server{
+		Addr:         fmt.Sprintf("%s:%d", cfg.ServerHost, cfg.ServerPort),
+		Handler:      r,
+		ReadTimeout:  cfg.ServerReadTimeout,
+		WriteTimeout: cfg.ServerWriteTimeout,
+	}
+
+	// Event streams never go idle on their own, so end them when shutdown starts
+	srv.RegisterOnShutdown(eventsBroker.Close)
+
+	logger.Info().
+		Str("address", srv.Addr).
+		Msg("Starting HTTP server")
+
+	// Start server in a goroutine
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal().Err(err).Msg("Failed to start HTTP server")
+		}
+	}()
+
+	// Wait for context cancellation
+	<-ctx.Done()
+
+	// Fail readiness first so that load balancers stop routing new requests
+	// before the listener is closed
+	probe.Drain()
+	logger.Info().
+		Dur("drain_delay", cfg.ShutdownDrainDelay).
+		Msg("Draining HTTP server...")
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	logger.Info().Msg("Shutting down HTTP server...")
+
+	// Create shutdown context with timeout
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Shutdown server
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("HTTP server forced to shutdown")
+		return err
+	}
+
+	logger.Info().Msg("HTTP server exited")
+	return nil
+}
+
+// routerParams holds the dependencies of the HTTP routes
+type routerParams struct {
+	Config         *config.Config
+	Database       *database.DB
+	Store          *db.Store
+	Probe          *health.Probe
+	EventsBroker   *events.Broker
+	EventsRecorder events.Recorder
+	Logger         zerolog.Logger
+}
+
+// newRouter creates the router with its middleware and all routes.
+// Background workers of the routes run until ctx is cancelled.
+func newRouter(ctx context.Context, p routerParams) (*gin.Engine, error) {
+	cfg, database, probe, logger := p.Config, p.Database, p.Probe, p.Logger
+	queries := p.Store.Queries
+
 	// Create Gin router
 	r := gin.New()
 
+	// Only honour X-Forwarded-For from our own proxies, otherwise clients could spoof their IP
+	// and escape per-IP rate limits
+	if err := r.SetTrustedProxies(trustedProxies(cfg.TrustedProxies)); err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
 	// Add middleware
 	r.Use(gin.Recovery())
 
@@ -38,84 +173,155 @@ func Start(ctx context.Context, cfg *config.Config, logger zerolog.Logger) error
 	})
 
 	r.Use(middleware.RequestID()) // Use our custom middleware
+	r.Use(middleware.Tracing())   // Server spans, tagged with the request ID
 	r.Use(middleware.Logger())    // Use our combined logger middleware
+	r.Use(middleware.Locale())    // Language of messages from Accept-Language
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"*"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Idempotent-Replayed", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Content-Language"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Initialize repository
-	queries := db.New(database.DB)
-
 	// Initialize JWT token maker
 	tokenMaker, err := token.NewJWTMaker(cfg.JWTSecret)
 	if err != nil {
-		return fmt.Errorf("failed to create token maker: %w", err)
+		return nil, fmt.Errorf("failed to create token maker: %w", err)
 	}
 
-	// Apply JWT authentication to all /api routes
 	apiGroup := r.Group("/api")
+
+	// Rate limit /api routes per client IP before authentication, so that requests with invalid
+	// tokens are limited too, and per JWT service after it
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimitEnabled {
+		limiter, err = newRateLimiter(cfg, queries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
+		}
+		apiGroup.Use(middleware.RateLimit(limiter, ratelimit.KeyByIP))
+		probe.Go(ctx, "rate_limit_sweeper", func() {
+			ratelimit.RunSweeper(ctx, limiter, logger)
+		})
+	}
+
+	// Apply JWT authentication to all /api routes
 	apiGroup.Use(middleware.AuthMiddleware(tokenMaker))
+	if limiter != nil {
+		apiGroup.Use(middleware.RateLimit(limiter, ratelimit.KeyByToken))
+	}
+
+	// Fall back to the stored language of the client or professional of /api routes
+	apiGroup.Use(middleware.UserLocale(queries))
 
 	// Register API routes with JWT protection
-	if err := api.Register(ctx, cfg, apiGroup, queries); err != nil {
-		return fmt.Errorf("failed to register API routes: %w", err)
+	if err := api.Register(ctx, api.RegisterParams{
+		Config:         cfg,
+		Router:         apiGroup,
+		PublicRouter:   &r.RouterGroup,
+		Queries:        queries,
+		Store:          p.Store,
+		EventsBroker:   p.EventsBroker,
+		EventsRecorder: p.EventsRecorder,
+		Probe:          probe,
+		Logger:         logger,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register API routes: %w", err)
 	}
 
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
 		if err := database.Health(); err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status": "unhealthy",
-				"error":  err.Error(),
+			c.JSON(http.StatusServiceUnavailable, HealthResponse{
+				Status: "unhealthy",
+				Error:  err.Error(),
 			})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"timestamp": time.Now().UTC(),
+		c.JSON(http.StatusOK, HealthResponse{
+			Status:    "healthy",
+			Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
 		})
 	})
 
-	// Start server
-	srv := &http.Server{
-		Addr:         fmt.Sprintf("%s:%d", cfg.ServerHost, cfg.ServerPort),
-		Handler:      r,
-		ReadTimeout:  cfg.ServerReadTimeout,
-		WriteTimeout: cfg.ServerWriteTimeout,
-	}
+	// Liveness probe, the process is up and serving requests
+	r.GET("/livez", func(c *gin.Context) {
+		c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+	})
 
-	logger.Info().
-		Str("address", srv.Addr).
-		Msg("Starting HTTP server")
+	// Readiness probe, the instance can handle traffic
+	r.GET("/readyz", func(c *gin.Context) {
+		report := probe.Ready(c.Request.Context())
 
-	// Start server in a goroutine
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Fatal().Err(err).Msg("Failed to start HTTP server")
+		checks := make(map[string]CheckResponse, len(report.Checks))
+		for name, check := range report.Checks {
+			checks[name] = CheckResponse{Status: check.Status, Error: check.Error}
 		}
-	}()
 
-	// Wait for context cancellation
-	<-ctx.Done()
+		status, code := "ready", http.StatusOK
+		if !report.Ready {
+			status, code = "not_ready", http.StatusServiceUnavailable
+		}
+		c.JSON(code, ReadinessResponse{
+			Status: status,
+			Checks: checks,
+		})
+	})
 
-	logger.Info().Msg("Shutting down HTTP server...")
+	// Prometheus metrics endpoint
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
 
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// OpenAPI document and Swagger UI. The document is built from the registered routes,
+	// so it is generated once all routes are in place.
+	var spec []byte
+	r.GET(openAPIPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	})
+	r.GET(docsPath, openapi.UIHandler(apiTitle, openAPIPath))
 
-	// Shutdown server
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		logger.Error().Err(err).Msg("HTTP server forced to shutdown")
-		return err
+	spec, err = buildOpenAPI(r, operations(apiGroup, &r.RouterGroup), logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI document: %w", err)
 	}
 
-	logger.Info().Msg("HTTP server exited")
-	return nil
+	return r, nil
+}
+
+// trustedProxies splits the comma separated TRUSTED_PROXIES list; an empty list trusts no proxy
+func trustedProxies(value string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(value, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// newRateLimiter creates the rate limiter configured by the RATE_LIMIT_* variables
+func newRateLimiter(cfg *config.Config, queries *db.Queries) (*ratelimit.Limiter, error) {
+	fallback, err := ratelimit.ParsePolicy(ratelimit.DefaultPolicyName, cfg.RateLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := ratelimit.ParsePolicies(cfg.RateLimitPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	var store ratelimit.Store
+	switch cfg.RateLimitStore {
+	case "memory":
+		store = ratelimit.NewMemoryStore()
+	case "postgres":
+		store = ratelimit.NewPostgresStore(queries)
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q, must be memory or postgres", cfg.RateLimitStore)
+	}
+
+	return ratelimit.NewLimiter(store, fallback, policies), nil
 }
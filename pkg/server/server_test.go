@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimitBeforeAuth(t *testing.T) {
+	t.Setenv("RATE_LIMIT_POLICIES", "/api/professionals/sign_in=1/1m:ip,/api/professionals/:id/availability=1/1m")
+	r := newTestRouter(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		wantCode []int
+	}{
+		{
+			name:     "ip policy limits unauthenticated requests",
+			method:   http.MethodPost,
+			path:     "/api/professionals/sign_in",
+			wantCode: []int{http.StatusUnauthorized, http.StatusTooManyRequests},
+		},
+		{
+			name:     "token policy applies after authentication",
+			method:   http.MethodGet,
+			path:     "/api/professionals/7c065dd1-22b9-4bed-82e2-be973cb6ea47/availability",
+			wantCode: []int{http.StatusUnauthorized, http.StatusUnauthorized},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.wantCode {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+				if w.Code != want {
+					t.Errorf("request %d returned %d, want %d", i+1, w.Code, want)
+				}
+			}
+		})
+	}
+}